@@ -15,3 +15,14 @@
 // limitations under the License.
 
 package main
+
+import (
+	"github.com/butlerdotdev/butler/internal/cli/ctl"
+	"github.com/butlerdotdev/butler/internal/logger"
+)
+
+// main initializes the logger and executes the Butler CLI.
+func main() {
+	logger.InitLogger()
+	ctl.Execute()
+}
@@ -0,0 +1,45 @@
+// Package etcd provides commands to back up and restore the management cluster's etcd.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"fmt"
+
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewEtcdCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "etcd",
+		Short: "Back up and restore the management cluster's etcd",
+		Long:  `Takes, schedules and restores etcd snapshots of the Butler management cluster through the Talos API. Snapshots are kept in etcdBackup.localPath or, when etcdBackup.s3.bucket is set, in an S3-compatible bucket. Requires a subcommand to be called.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+			log.Error("etcd needs to be run with a subcommand (e.g., 'butleradm etcd snapshot' or 'butleradm etcd restore').")
+			return fmt.Errorf("etcd needs to be run with a subcommand (e.g., 'butleradm etcd snapshot' or 'butleradm etcd restore')")
+		},
+	}
+
+	// Support CLI-based configuration file override
+	cmd.Flags().String("config", "", "Path to configuration file")
+	viper.BindPFlag("config", cmd.Flags().Lookup("config"))
+
+	return cmd
+}
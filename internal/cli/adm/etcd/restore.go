@@ -0,0 +1,64 @@
+// Package etcd provides commands to back up and restore the management cluster's etcd.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"context"
+
+	handler "github.com/butlerdotdev/butler/internal/handlers/etcd"
+	"github.com/butlerdotdev/butler/internal/logger"
+	service "github.com/butlerdotdev/butler/internal/services/etcd"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func NewRestoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the management cluster's etcd from a snapshot",
+		Long: `Recovers etcd on the management cluster control plane from a snapshot. The snapshot may be a
+local file or the name of a stored snapshot; without --snapshot the newest stored snapshot is used.
+
+etcd must be stopped and waiting to be bootstrapped on every control plane node. Pass
+--wipe-ephemeral to reset the EPHEMERAL partition of each control plane first, which destroys the
+current etcd data on those nodes.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			opts := service.RestoreOptions{}
+			opts.Snapshot, _ = cmd.Flags().GetString("snapshot")
+			opts.Nodes, _ = cmd.Flags().GetStringSlice("nodes")
+			opts.WipeEphemeral, _ = cmd.Flags().GetBool("wipe-ephemeral")
+
+			h := handler.NewEtcdHandler(context.Background(), log)
+			if err := h.HandleRestore(opts); err != nil {
+				log.Error("etcd restore failed", zap.Error(err))
+				return err
+			}
+
+			log.Info("etcd restore completed successfully! 🎉")
+			return nil
+		},
+	}
+
+	cmd.Flags().String("snapshot", "", "Local snapshot file or stored snapshot name (defaults to the newest stored snapshot)")
+	cmd.Flags().StringSlice("nodes", nil, "Control plane node IPs (defaults to talos.controlPlaneNodes)")
+	cmd.Flags().Bool("wipe-ephemeral", false, "Reset the EPHEMERAL partition on every control plane before restoring")
+
+	return cmd
+}
@@ -0,0 +1,60 @@
+// Package etcd provides commands to back up and restore the management cluster's etcd.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"context"
+
+	handler "github.com/butlerdotdev/butler/internal/handlers/etcd"
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func NewSnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Take an etcd snapshot of the management cluster",
+		Long: `Takes an etcd snapshot from a control plane node and stores it in etcdBackup.localPath or the
+configured S3 bucket, pruning snapshots beyond etcdBackup.retention.
+
+With --schedule, a CronJob is installed in the management cluster instead that takes a snapshot on
+the given cron schedule and uploads it to the S3 bucket with the same retention.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			node, _ := cmd.Flags().GetString("node")
+			schedule, _ := cmd.Flags().GetString("schedule")
+			scheduled := cmd.Flags().Changed("schedule")
+
+			h := handler.NewEtcdHandler(context.Background(), log)
+			if err := h.HandleSnapshot(node, schedule, scheduled); err != nil {
+				log.Error("etcd snapshot failed", zap.Error(err))
+				return err
+			}
+
+			log.Info("etcd snapshot completed successfully! 🎉")
+			return nil
+		},
+	}
+
+	cmd.Flags().String("node", "", "Control plane node IP to take the snapshot from (defaults to the first Ready control plane)")
+	cmd.Flags().String("schedule", "", "Install an in-cluster CronJob with this cron schedule instead of taking a snapshot now (empty uses etcdBackup.schedule)")
+
+	return cmd
+}
@@ -0,0 +1,66 @@
+// Package generate provides utilities for Butler, including documentation generation.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/butlerdotdev/butler/internal/logger"
+	"github.com/butlerdotdev/butler/internal/services/machineconfig"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// NewSchematicCmd creates the command that resolves the Talos Image Factory schematic.
+func NewSchematicCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schematic",
+		Short: "Resolve the Talos image for the configured extensions and kernel args",
+		Long: `Registers the schematic described by talos.extensions and talos.kernelArgs with the Talos
+Image Factory and prints the schematic ID, installer image and ISO URL. Upload the ISO to your
+provider and reference it from the node configuration before bootstrapping.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			var config models.BootstrapConfig
+			if err := viper.Unmarshal(&config); err != nil {
+				log.Error("Failed to load config", zap.Error(err))
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			refs, err := machineconfig.ResolveImages(context.Background(), config.ManagementCluster.Talos, config.ManagementCluster.Talos.Version, log)
+			if err != nil {
+				log.Error("Failed to resolve Talos images", zap.Error(err))
+				return err
+			}
+
+			out, err := json.MarshalIndent(refs, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to render image references: %w", err)
+			}
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	return cmd
+}
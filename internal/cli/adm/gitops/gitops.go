@@ -0,0 +1,45 @@
+// Package gitops provides commands to manage the management cluster's GitOps repository.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"fmt"
+
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewGitOpsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gitops",
+		Short: "Manage the management cluster's Flux repository",
+		Long:  `Manages the Flux repository configured in the flux section of the configuration. Requires a subcommand to be called.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+			log.Error("gitops needs to be run with a subcommand (e.g., 'butleradm gitops sync').")
+			return fmt.Errorf("gitops needs to be run with a subcommand (e.g., 'butleradm gitops sync')")
+		},
+	}
+
+	// Support CLI-based configuration file override
+	cmd.Flags().String("config", "", "Path to configuration file")
+	viper.BindPFlag("config", cmd.Flags().Lookup("config"))
+
+	return cmd
+}
@@ -0,0 +1,53 @@
+// Package gitops provides commands to manage the management cluster's GitOps repository.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"context"
+
+	handler "github.com/butlerdotdev/butler/internal/handlers/gitops"
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func NewSyncCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Commit the component catalog to the Flux repository",
+		Long: `Renders every entry of flux.components into a HelmRepository, HelmRelease and values
+ConfigMap under <flux.gitPath>/components/<name> and pushes the result, for Flux to install.
+
+Bootstrap does the same once Flux is ready. Butler owns the components directory: components
+removed from the configuration are removed from the repository, and Flux prunes them.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			h := handler.NewGitOpsHandler(context.Background(), log)
+			if err := h.HandleSync(); err != nil {
+				log.Error("Component sync failed", zap.Error(err))
+				return err
+			}
+
+			log.Info("Components synced successfully! 🎉")
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,58 @@
+// Package network provides commands to manage the management cluster's network.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"context"
+
+	handler "github.com/butlerdotdev/butler/internal/handlers/network"
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func NewConfigureCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "configure",
+		Short: "Reconcile the CNI's network resources with the configuration",
+		Long: `Applies the networks declared in network.kubeOvn (ProviderNetworks, Vlans, Vpcs and Subnets)
+to the management cluster and waits for the subnets to become ready.
+
+Bootstrap does the same once the CNI is ready. Objects are server-side applied, so running this
+again after changing the configuration updates them in place; objects removed from the
+configuration are left in the cluster.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			server, _ := cmd.Flags().GetString("server")
+
+			h := handler.NewNetworkHandler(context.Background(), log)
+			if err := h.HandleConfigure(server); err != nil {
+				log.Error("Network configuration failed", zap.Error(err))
+				return err
+			}
+
+			log.Info("Network configured successfully! 🎉")
+			return nil
+		},
+	}
+
+	cmd.Flags().String("server", "", "Kubernetes API server URL (defaults to the server in the kubeconfig)")
+
+	return cmd
+}
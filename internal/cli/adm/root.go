@@ -22,7 +22,12 @@ import (
 
 	"github.com/butlerdotdev/butler/internal/cli/adm/bootstrap"
 	"github.com/butlerdotdev/butler/internal/cli/adm/bootstrap/providers"
+	"github.com/butlerdotdev/butler/internal/cli/adm/etcd"
 	"github.com/butlerdotdev/butler/internal/cli/adm/generate"
+	"github.com/butlerdotdev/butler/internal/cli/adm/gitops"
+	"github.com/butlerdotdev/butler/internal/cli/adm/network"
+	"github.com/butlerdotdev/butler/internal/cli/adm/scale"
+	"github.com/butlerdotdev/butler/internal/cli/adm/upgrade"
 	"github.com/butlerdotdev/butler/internal/logger"
 
 	"github.com/spf13/cobra"
@@ -111,8 +116,29 @@ func RegisterCommands() {
 	bootstrapCmd.AddCommand(providers.NewProxmoxBootstrapCmd())
 	rootCmd.AddCommand(bootstrapCmd)
 
+	upgradeCmd := upgrade.NewUpgradeCmd()
+	upgradeCmd.AddCommand(upgrade.NewTalosUpgradeCmd())
+	upgradeCmd.AddCommand(upgrade.NewKubernetesUpgradeCmd())
+	rootCmd.AddCommand(upgradeCmd)
+
+	rootCmd.AddCommand(scale.NewScaleCmd())
+
+	etcdCmd := etcd.NewEtcdCmd()
+	etcdCmd.AddCommand(etcd.NewSnapshotCmd())
+	etcdCmd.AddCommand(etcd.NewRestoreCmd())
+	rootCmd.AddCommand(etcdCmd)
+
+	gitopsCmd := gitops.NewGitOpsCmd()
+	gitopsCmd.AddCommand(gitops.NewSyncCmd())
+	rootCmd.AddCommand(gitopsCmd)
+
+	networkCmd := network.NewNetworkCmd()
+	networkCmd.AddCommand(network.NewConfigureCmd())
+	rootCmd.AddCommand(networkCmd)
+
 	genCmd := generate.NewGenerateCmd()
 	genCmd.AddCommand(generate.NewDocsCmd(rootCmd))
+	genCmd.AddCommand(generate.NewSchematicCmd())
 	rootCmd.AddCommand(genCmd)
 }
 
@@ -0,0 +1,76 @@
+// Package scale provides the command to scale the Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"context"
+	"time"
+
+	handler "github.com/butlerdotdev/butler/internal/handlers/scale"
+	"github.com/butlerdotdev/butler/internal/logger"
+	service "github.com/butlerdotdev/butler/internal/services/scale"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+func NewScaleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scale",
+		Short: "Add or remove management cluster nodes",
+		Long: `Brings the number of management cluster nodes with a role to the requested count.
+
+New VMs are created through the configured provider with the sizing from managementCluster.nodes,
+receive the machine config generated during bootstrap, and are labeled for Kube-OVN once they have
+joined the cluster. Removed nodes are cordoned, drained and taken out of etcd before their VM is
+deleted. Nodes are added and removed one at a time at the end of the <cluster>-<role>-<n> sequence.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			opts := service.Options{}
+			opts.Role, _ = cmd.Flags().GetString("role")
+			opts.Count, _ = cmd.Flags().GetInt("count")
+			opts.VMTimeout, _ = cmd.Flags().GetDuration("vm-timeout")
+			opts.NodeTimeout, _ = cmd.Flags().GetDuration("node-timeout")
+			opts.DrainTimeout, _ = cmd.Flags().GetDuration("drain-timeout")
+
+			h := handler.NewScaleHandler(context.Background(), log)
+			if err := h.HandleScale(opts); err != nil {
+				log.Error("Scaling failed", zap.Error(err))
+				return err
+			}
+
+			log.Info("Scaling completed successfully! 🎉")
+			return nil
+		},
+	}
+
+	cmd.Flags().String("role", "", "Node role to scale (control-plane or worker)")
+	cmd.Flags().Int("count", 0, "Desired number of nodes with the role")
+	cmd.Flags().Duration("vm-timeout", 10*time.Minute, "Maximum time to wait for a new VM to become healthy with an IP, or a removed VM to power off")
+	cmd.Flags().Duration("node-timeout", 15*time.Minute, "Maximum time to wait for a new node to join and become Ready")
+	cmd.Flags().Duration("drain-timeout", 5*time.Minute, "Maximum time to wait for a single node to drain")
+	cmd.MarkFlagRequired("role")
+	cmd.MarkFlagRequired("count")
+
+	// Support CLI-based configuration file override
+	cmd.Flags().String("config", "", "Path to configuration file")
+	viper.BindPFlag("config", cmd.Flags().Lookup("config"))
+
+	return cmd
+}
@@ -0,0 +1,61 @@
+// Package upgrade provides commands to upgrade the Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+
+	handler "github.com/butlerdotdev/butler/internal/handlers/upgrade"
+	"github.com/butlerdotdev/butler/internal/logger"
+	service "github.com/butlerdotdev/butler/internal/services/upgrade"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// NewKubernetesUpgradeCmd creates the command that upgrades Kubernetes on the management cluster.
+func NewKubernetesUpgradeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kubernetes",
+		Short: "Upgrade Kubernetes on the management cluster",
+		Long: `Upgrades the Kubernetes control plane components through Talos, then rolls the kubelet upgrade
+through every node, control planes first, one node at a time. Each node is cordoned and drained,
+upgraded, and must become Ready (with a healthy etcd quorum for control planes) before it is
+uncordoned and the next node is started. The upgrade stops at the first failure.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			opts := service.Options{}
+			opts.Version, _ = cmd.Flags().GetString("version")
+			opts.DrainTimeout, _ = cmd.Flags().GetDuration("drain-timeout")
+			opts.NodeTimeout, _ = cmd.Flags().GetDuration("node-timeout")
+
+			h := handler.NewUpgradeHandler(context.Background(), log)
+			if err := h.HandleUpgradeKubernetes(opts); err != nil {
+				log.Error("Kubernetes upgrade failed", zap.Error(err))
+				return err
+			}
+
+			log.Info("Kubernetes upgrade completed successfully! 🎉")
+			return nil
+		},
+	}
+
+	addRolloutFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,63 @@
+// Package upgrade provides commands to upgrade the Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+
+	handler "github.com/butlerdotdev/butler/internal/handlers/upgrade"
+	"github.com/butlerdotdev/butler/internal/logger"
+	service "github.com/butlerdotdev/butler/internal/services/upgrade"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// NewTalosUpgradeCmd creates the command that upgrades Talos OS on every node.
+func NewTalosUpgradeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "talos",
+		Short: "Upgrade Talos OS on the management cluster nodes",
+		Long: `Upgrades Talos OS on every management cluster node, control planes first, one node at a time.
+Each node is cordoned and drained, upgraded, and must become Ready (with a healthy etcd quorum
+for control planes) before it is uncordoned and the next node is started. The upgrade stops at
+the first failure.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			opts := service.Options{}
+			opts.Version, _ = cmd.Flags().GetString("version")
+			opts.Image, _ = cmd.Flags().GetString("image")
+			opts.DrainTimeout, _ = cmd.Flags().GetDuration("drain-timeout")
+			opts.NodeTimeout, _ = cmd.Flags().GetDuration("node-timeout")
+
+			h := handler.NewUpgradeHandler(context.Background(), log)
+			if err := h.HandleUpgradeTalos(opts); err != nil {
+				log.Error("Talos upgrade failed", zap.Error(err))
+				return err
+			}
+
+			log.Info("Talos upgrade completed successfully! 🎉")
+			return nil
+		},
+	}
+
+	addRolloutFlags(cmd)
+	cmd.Flags().String("image", "", "Talos installer image to upgrade to (defaults to the image built from talos.extensions and talos.kernelArgs)")
+
+	return cmd
+}
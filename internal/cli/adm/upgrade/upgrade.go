@@ -0,0 +1,55 @@
+// Package upgrade provides commands to upgrade the Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewUpgradeCmd creates the upgrade command.
+func NewUpgradeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade the Butler management cluster",
+		Long:  `Rolls Talos OS or Kubernetes upgrades through the Butler management cluster one node at a time. Requires a subcommand to be called specifying what to upgrade.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+			log.Error("Upgrade needs to be run with a subcommand (e.g., 'butleradm upgrade talos' or 'butleradm upgrade kubernetes').")
+			return fmt.Errorf("upgrade needs to be run with a subcommand (e.g., 'butleradm upgrade talos' or 'butleradm upgrade kubernetes')")
+		},
+	}
+
+	// Support CLI-based configuration file override
+	cmd.Flags().String("config", "", "Path to configuration file")
+	viper.BindPFlag("config", cmd.Flags().Lookup("config"))
+
+	return cmd
+}
+
+// addRolloutFlags registers the flags shared by all rolling upgrade subcommands.
+func addRolloutFlags(cmd *cobra.Command) {
+	cmd.Flags().String("version", "", "Target version to upgrade to (e.g. v1.9.5)")
+	cmd.Flags().Duration("drain-timeout", 5*time.Minute, "Maximum time to wait for a single node to drain")
+	cmd.Flags().Duration("node-timeout", 15*time.Minute, "Maximum time to wait for a node to become Ready after its upgrade")
+	cmd.MarkFlagRequired("version")
+}
@@ -0,0 +1,43 @@
+// Package cluster implements the butlerctl commands for tenant clusters.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+)
+
+func NewClusterCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Create and manage tenant Kubernetes clusters",
+		Long: `Creates, lists, inspects and deletes tenant Kubernetes clusters on the Butler management cluster.
+Each cluster's control plane runs as pods in Kamaji and its workers are VMs provisioned through
+Cluster API with the provider settings from the clusters section of butlerctl.yaml. Requires a
+subcommand to be called.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+			log.Error("cluster needs to be run with a subcommand (e.g., 'butlerctl cluster create' or 'butlerctl cluster list').")
+			return fmt.Errorf("cluster needs to be run with a subcommand (e.g., 'butlerctl cluster create' or 'butlerctl cluster list')")
+		},
+	}
+
+	return cmd
+}
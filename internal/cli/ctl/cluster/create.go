@@ -0,0 +1,74 @@
+// Package cluster implements the butlerctl commands for tenant clusters.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"time"
+
+	handler "github.com/butlerdotdev/butler/internal/handlers/tenant"
+	"github.com/butlerdotdev/butler/internal/logger"
+	service "github.com/butlerdotdev/butler/internal/services/tenant"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func NewCreateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a tenant cluster",
+		Long: `Creates the Cluster, KamajiControlPlane and worker MachineDeployment of a new tenant cluster.
+
+The control plane is exposed through a LoadBalancer address on the management cluster. Workers are
+sized by --flavor: small (2 CPU, 4GB RAM, 40GB disk), medium (4 CPU, 8GB, 80GB) and large (8 CPU,
+16GB, 120GB), unless your platform team configured clusters.flavors in butlerctl.yaml. With --wait,
+the command returns once the cluster is Ready and its workers have rolled out.`,
+		Example: `  butlerctl cluster create team-a --version v1.31.4 --workers 3 --flavor medium --wait`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			opts := service.CreateOptions{Name: args[0]}
+			opts.Version, _ = cmd.Flags().GetString("version")
+			opts.Workers, _ = cmd.Flags().GetInt("workers")
+			opts.Flavor, _ = cmd.Flags().GetString("flavor")
+			wait, _ := cmd.Flags().GetBool("wait")
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			if !wait {
+				timeout = 0
+			}
+
+			h := handler.NewTenantHandler(context.Background(), log)
+			if err := h.HandleClusterCreate(opts, timeout); err != nil {
+				log.Error("Cluster creation failed", zap.Error(err))
+				return err
+			}
+
+			log.Info("Cluster created successfully! 🎉", zap.String("name", opts.Name))
+			return nil
+		},
+	}
+
+	cmd.Flags().String("version", "", "Kubernetes version of the cluster, e.g. v1.31.4 (defaults to clusters.kubernetesVersion)")
+	cmd.Flags().Int("workers", 1, "Number of worker nodes")
+	cmd.Flags().String("flavor", service.DefaultFlavor, "Worker size (small, medium and large unless clusters.flavors is configured)")
+	cmd.Flags().Bool("wait", false, "Wait for the cluster to become Ready")
+	cmd.Flags().Duration("timeout", 30*time.Minute, "Maximum time to wait with --wait")
+
+	return cmd
+}
@@ -0,0 +1,63 @@
+// Package cluster implements the butlerctl commands for tenant clusters.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"time"
+
+	handler "github.com/butlerdotdev/butler/internal/handlers/tenant"
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func NewDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a tenant cluster",
+		Long: `Deletes a tenant cluster. Cluster API removes its worker VMs and Kamaji its control plane.
+
+By default the command waits until the cluster is gone. With --wait=false it returns once deletion
+has started. Either way the worker templates the cluster was created with are removed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			wait, _ := cmd.Flags().GetBool("wait")
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			if !wait {
+				timeout = 0
+			}
+
+			h := handler.NewTenantHandler(context.Background(), log)
+			if err := h.HandleClusterDelete(args[0], timeout); err != nil {
+				log.Error("Cluster deletion failed", zap.Error(err))
+				return err
+			}
+
+			log.Info("Cluster deleted successfully!", zap.String("name", args[0]))
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("wait", true, "Wait for the cluster and its VMs to be deleted")
+	cmd.Flags().Duration("timeout", 20*time.Minute, "Maximum time to wait with --wait")
+
+	return cmd
+}
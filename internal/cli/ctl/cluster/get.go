@@ -0,0 +1,79 @@
+// Package cluster implements the butlerctl commands for tenant clusters.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/butlerdotdev/butler/internal/cli/ctl/output"
+	handler "github.com/butlerdotdev/butler/internal/handlers/tenant"
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func NewGetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <name>",
+		Short: "Show the status of a tenant cluster",
+		Long:  `Shows a tenant cluster's phase, version, endpoint, control plane and worker replicas, and the conditions Cluster API reports for it.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			format, err := output.Format(cmd)
+			if err != nil {
+				return err
+			}
+
+			h := handler.NewTenantHandler(context.Background(), log)
+			cluster, err := h.HandleClusterGet(args[0])
+			if err != nil {
+				log.Error("Getting cluster failed", zap.Error(err))
+				return err
+			}
+
+			return output.Print(os.Stdout, format, cluster, func(t *tabwriter.Writer) {
+				fmt.Fprintf(t, "Name:\t%s\n", cluster.Name)
+				fmt.Fprintf(t, "Namespace:\t%s\n", cluster.Namespace)
+				fmt.Fprintf(t, "Phase:\t%s\n", output.OrNone(cluster.Phase))
+				fmt.Fprintf(t, "Version:\t%s\n", output.OrNone(cluster.Version))
+				fmt.Fprintf(t, "Flavor:\t%s\n", output.OrNone(cluster.Flavor))
+				fmt.Fprintf(t, "Endpoint:\t%s\n", output.OrNone(cluster.Endpoint))
+				fmt.Fprintf(t, "Control plane:\t%s ready\n", replicas(cluster.ControlPlane))
+				fmt.Fprintf(t, "Workers:\t%s ready\n", replicas(cluster.Workers))
+				fmt.Fprintf(t, "Age:\t%s\n", output.Age(cluster.Created))
+				if len(cluster.Conditions) == 0 {
+					return
+				}
+				fmt.Fprintln(t)
+				fmt.Fprintln(t, "CONDITION\tSTATUS\tREASON\tMESSAGE")
+				for _, c := range cluster.Conditions {
+					fmt.Fprintf(t, "%s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, c.Message)
+				}
+			})
+		},
+	}
+
+	output.AddFlag(cmd)
+
+	return cmd
+}
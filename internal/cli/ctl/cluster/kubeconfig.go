@@ -0,0 +1,66 @@
+// Package cluster implements the butlerctl commands for tenant clusters.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	handler "github.com/butlerdotdev/butler/internal/handlers/tenant"
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func NewKubeconfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kubeconfig <name>",
+		Short: "Print the admin kubeconfig of a tenant cluster",
+		Long:  `Prints the admin kubeconfig of a tenant cluster, or writes it to --file with owner-only permissions.`,
+		Example: `  butlerctl cluster kubeconfig team-a --file team-a.kubeconfig
+  KUBECONFIG=team-a.kubeconfig kubectl get nodes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			file, _ := cmd.Flags().GetString("file")
+
+			h := handler.NewTenantHandler(context.Background(), log)
+			kubeconfig, err := h.HandleClusterKubeconfig(args[0])
+			if err != nil {
+				log.Error("Getting kubeconfig failed", zap.Error(err))
+				return err
+			}
+
+			if file == "" {
+				_, err := os.Stdout.Write(kubeconfig)
+				return err
+			}
+			if err := os.WriteFile(file, kubeconfig, 0600); err != nil {
+				return fmt.Errorf("failed to write kubeconfig to %s: %w", file, err)
+			}
+			log.Info("Kubeconfig written", zap.String("file", file))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Write the kubeconfig to this file instead of stdout")
+
+	return cmd
+}
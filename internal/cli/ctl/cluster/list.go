@@ -0,0 +1,95 @@
+// Package cluster implements the butlerctl commands for tenant clusters.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/butlerdotdev/butler/internal/cli/ctl/output"
+	handler "github.com/butlerdotdev/butler/internal/handlers/tenant"
+	"github.com/butlerdotdev/butler/internal/logger"
+	service "github.com/butlerdotdev/butler/internal/services/tenant"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func NewListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List tenant clusters",
+		Long:    `Lists the tenant clusters in the namespace with their phase, version, ready control plane and worker replicas and API endpoint.`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			format, err := output.Format(cmd)
+			if err != nil {
+				return err
+			}
+			allNamespaces, _ := cmd.Flags().GetBool("all-namespaces")
+
+			h := handler.NewTenantHandler(context.Background(), log)
+			clusters, err := h.HandleClusterList(allNamespaces)
+			if err != nil {
+				log.Error("Listing clusters failed", zap.Error(err))
+				return err
+			}
+
+			return output.Print(os.Stdout, format, clusters, func(t *tabwriter.Writer) {
+				printClusters(t, clusters, allNamespaces)
+			})
+		},
+	}
+
+	cmd.Flags().BoolP("all-namespaces", "A", false, "List clusters in all namespaces")
+	output.AddFlag(cmd)
+
+	return cmd
+}
+
+// printClusters writes one row per cluster.
+func printClusters(t *tabwriter.Writer, clusters []service.ClusterStatus, withNamespace bool) {
+	if withNamespace {
+		fmt.Fprint(t, "NAMESPACE\t")
+	}
+	fmt.Fprintln(t, "NAME\tPHASE\tVERSION\tCONTROL PLANE\tWORKERS\tFLAVOR\tENDPOINT\tAGE")
+	for _, c := range clusters {
+		if withNamespace {
+			fmt.Fprintf(t, "%s\t", c.Namespace)
+		}
+		fmt.Fprintf(t, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			c.Name,
+			output.OrNone(c.Phase),
+			output.OrNone(c.Version),
+			replicas(c.ControlPlane),
+			replicas(c.Workers),
+			output.OrNone(c.Flavor),
+			output.OrNone(c.Endpoint),
+			output.Age(c.Created),
+		)
+	}
+}
+
+// replicas formats ready out of desired replicas, e.g. "2/3".
+func replicas(r service.ReplicaStatus) string {
+	return fmt.Sprintf("%d/%d", r.Ready, r.Desired)
+}
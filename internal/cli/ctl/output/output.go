@@ -0,0 +1,91 @@
+// Package output prints butlerctl results as tables, JSON or YAML.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/yaml"
+)
+
+// Formats accepted by --output. An empty format prints a table.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// AddFlag adds the -o/--output flag to a command.
+func AddFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", "", "Output format: json or yaml (default a table)")
+}
+
+// Format returns the validated --output format of a command.
+func Format(cmd *cobra.Command) (string, error) {
+	format, _ := cmd.Flags().GetString("output")
+	switch format {
+	case "", FormatJSON, FormatYAML:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported output format %q; expected json or yaml", format)
+	}
+}
+
+// Print writes v as JSON or YAML, or calls table to write it as a table.
+func Print(w io.Writer, format string, v interface{}, table func(t *tabwriter.Writer)) error {
+	switch format {
+	case FormatJSON:
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	case FormatYAML:
+		out, err := yaml.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to marshal YAML: %w", err)
+		}
+		_, err = w.Write(out)
+		return err
+	default:
+		t := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		table(t)
+		return t.Flush()
+	}
+}
+
+// Age formats the time since t like kubectl, e.g. "5m" or "3d".
+func Age(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(t))
+}
+
+// OrNone shows empty values as "<none>" like kubectl.
+func OrNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
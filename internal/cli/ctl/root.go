@@ -15,3 +15,120 @@
 // limitations under the License.
 
 package ctl
+
+import (
+	"os"
+	"strings"
+
+	"github.com/butlerdotdev/butler/internal/cli/ctl/cluster"
+	"github.com/butlerdotdev/butler/internal/cli/ctl/vm"
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+var cfgFile string
+
+// rootCmd represents the base command when called without any subcommands
+var rootCmd = &cobra.Command{
+	Use:   "butlerctl",
+	Short: "Butler - Kubernetes as a Service",
+	Long: `butlerctl requests and manages tenant Kubernetes clusters and KubeVirt virtual machines on a
+Butler management cluster. It talks to the management cluster with the kubeconfig from --kubeconfig,
+butlerctl.yaml, $KUBECONFIG or ~/.kube/config, in that order.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		cfgFile, _ = cmd.Flags().GetString("config")
+		initConfig()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		log := logger.GetLogger()
+		log.Info("Welcome to Butler CLI! Use --help to view available commands.")
+	},
+}
+
+// Execute runs the CLI
+func Execute() {
+	logger.InitLogger()
+	log := logger.GetLogger()
+
+	if err := rootCmd.Execute(); err != nil {
+		log.Fatal("Execution failed", zap.Error(err))
+		os.Exit(1)
+	}
+}
+
+func init() {
+	// Global flags, bound to the configuration keys they override
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "Path to configuration file")
+	rootCmd.PersistentFlags().String("kubeconfig", "", "Path to the management cluster kubeconfig")
+	rootCmd.PersistentFlags().StringP("namespace", "n", "", "Management cluster namespace of your clusters and VMs")
+	viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
+	viper.BindPFlag("kubeconfig", rootCmd.PersistentFlags().Lookup("kubeconfig"))
+	viper.BindPFlag("namespace", rootCmd.PersistentFlags().Lookup("namespace"))
+
+	// Initialize configuration
+	cobra.OnInitialize(initConfig)
+
+	// Register subcommands
+	RegisterCommands()
+}
+
+func initConfig() {
+	log := logger.GetLogger()
+
+	// If the user specified a config file via --config
+	if cfgFile != "" {
+		log.Info("Explicit config file detected", zap.String("cfgFile", cfgFile))
+		viper.SetConfigFile(cfgFile)
+
+		if err := viper.ReadInConfig(); err != nil {
+			log.Fatal("Failed to read config file", zap.Error(err))
+		} else {
+			log.Info("Using config file", zap.String("file", viper.ConfigFileUsed()))
+		}
+	} else {
+		// Fallback to default config search locations
+		viper.SetConfigName("butlerctl")
+		viper.SetConfigType("yaml")
+		viper.AddConfigPath(".")
+		viper.AddConfigPath("$HOME/.butler")
+
+		if err := viper.ReadInConfig(); err != nil {
+			log.Warn("No config file found", zap.Error(err))
+		} else {
+			log.Info("Using default config file", zap.String("file", viper.ConfigFileUsed()))
+		}
+	}
+
+	viper.AutomaticEnv()
+	viper.SetEnvPrefix("BUTLER")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+}
+
+// RegisterCommands explicitly registers all subcommands
+func RegisterCommands() {
+	clusterCmd := cluster.NewClusterCmd()
+	clusterCmd.AddCommand(cluster.NewCreateCmd())
+	clusterCmd.AddCommand(cluster.NewListCmd())
+	clusterCmd.AddCommand(cluster.NewGetCmd())
+	clusterCmd.AddCommand(cluster.NewDeleteCmd())
+	clusterCmd.AddCommand(cluster.NewKubeconfigCmd())
+	rootCmd.AddCommand(clusterCmd)
+
+	vmCmd := vm.NewVMCmd()
+	vmCmd.AddCommand(vm.NewCreateCmd())
+	vmCmd.AddCommand(vm.NewListCmd())
+	vmCmd.AddCommand(vm.NewStartCmd())
+	vmCmd.AddCommand(vm.NewStopCmd())
+	vmCmd.AddCommand(vm.NewRestartCmd())
+	vmCmd.AddCommand(vm.NewDeleteCmd())
+	vmCmd.AddCommand(vm.NewConsoleCmd())
+	rootCmd.AddCommand(vmCmd)
+}
+
+// GetRootCmd returns the root command
+func GetRootCmd() *cobra.Command {
+	return rootCmd
+}
@@ -0,0 +1,87 @@
+// Package vm implements the butlerctl commands for KubeVirt virtual machines.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	handler "github.com/butlerdotdev/butler/internal/handlers/tenant"
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"golang.org/x/term"
+)
+
+// escapeKey is Ctrl+], which detaches from the console like telnet and virtctl.
+const escapeKey = 0x1d
+
+func NewConsoleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "console <name>",
+		Short: "Attach to the serial console of a virtual machine",
+		Long:  `Attaches the terminal to the serial console of a running VM. Press Ctrl+] to detach.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			fd := int(os.Stdin.Fd())
+			if term.IsTerminal(fd) {
+				state, err := term.MakeRaw(fd)
+				if err != nil {
+					return fmt.Errorf("failed to put the terminal in raw mode: %w", err)
+				}
+				defer term.Restore(fd, state)
+			}
+			fmt.Fprintf(os.Stderr, "Connected to %s. Press Ctrl+] to detach.\r\n", args[0])
+
+			h := handler.NewTenantHandler(context.Background(), log)
+			if err := h.HandleVMConsole(args[0], &escapeReader{r: os.Stdin}, os.Stdout); err != nil {
+				log.Error("VM console failed", zap.Error(err))
+				return err
+			}
+
+			fmt.Fprint(os.Stderr, "\r\nDetached.\r\n")
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// escapeReader ends the input at the escape key.
+type escapeReader struct {
+	r    io.Reader
+	done bool
+}
+
+func (e *escapeReader) Read(p []byte) (int, error) {
+	if e.done {
+		return 0, io.EOF
+	}
+	n, err := e.r.Read(p)
+	for i := 0; i < n; i++ {
+		if p[i] == escapeKey {
+			e.done = true
+			return i, nil
+		}
+	}
+	return n, err
+}
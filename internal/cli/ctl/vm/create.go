@@ -0,0 +1,84 @@
+// Package vm implements the butlerctl commands for KubeVirt virtual machines.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vm
+
+import (
+	"context"
+	"time"
+
+	handler "github.com/butlerdotdev/butler/internal/handlers/tenant"
+	"github.com/butlerdotdev/butler/internal/logger"
+	service "github.com/butlerdotdev/butler/internal/services/tenant"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func NewCreateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a virtual machine",
+		Long: `Creates a KubeVirt VirtualMachine whose root disk is a DataVolume CDI imports from --image, either
+an image name from vms.images in butlerctl.yaml or an http(s):// or docker:// URL.
+
+The VM is sized by --flavor: small (2 CPU, 4GB RAM, 40GB disk), medium (4 CPU, 8GB, 80GB) and large
+(8 CPU, 16GB, 120GB), unless your platform team configured vms.flavors. --subnet places its network
+interface in a Kube-OVN subnet, either of the pod network or one of the VM networks configured for
+the management cluster. --user-data passes a cloud-init user data file, stored in a Secret that is
+deleted with the VM.`,
+		Example: `  butlerctl vm create web-1 --image ubuntu-24.04 --flavor small --subnet vm-net --user-data cloud-init.yaml`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			opts := service.VMCreateOptions{Name: args[0]}
+			opts.Flavor, _ = cmd.Flags().GetString("flavor")
+			opts.Image, _ = cmd.Flags().GetString("image")
+			opts.DiskSize, _ = cmd.Flags().GetString("disk-size")
+			opts.StorageClass, _ = cmd.Flags().GetString("storage-class")
+			opts.Subnet, _ = cmd.Flags().GetString("subnet")
+			opts.UserDataFile, _ = cmd.Flags().GetString("user-data")
+			opts.Start, _ = cmd.Flags().GetBool("start")
+			wait, _ := cmd.Flags().GetBool("wait")
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			if !wait {
+				timeout = 0
+			}
+
+			h := handler.NewTenantHandler(context.Background(), log)
+			if err := h.HandleVMCreate(opts, timeout); err != nil {
+				log.Error("VM creation failed", zap.Error(err))
+				return err
+			}
+
+			log.Info("VM created successfully! 🎉", zap.String("name", opts.Name))
+			return nil
+		},
+	}
+
+	cmd.Flags().String("flavor", service.DefaultFlavor, "VM size (small, medium and large unless vms.flavors is configured)")
+	cmd.Flags().String("image", "", "Image name from vms.images, or an http(s):// or docker:// image URL (defaults to vms.image)")
+	cmd.Flags().String("disk-size", "", "Root disk size, e.g. 100GB (defaults to the flavor's disk)")
+	cmd.Flags().String("storage-class", "", "StorageClass of the root disk (defaults to vms.storageClass or the cluster default)")
+	cmd.Flags().String("subnet", "", "Kube-OVN subnet to place the VM in (defaults to vms.subnet or the pod network)")
+	cmd.Flags().String("user-data", "", "Path to a cloud-init user data file")
+	cmd.Flags().Bool("start", true, "Start the VM once it is created")
+	cmd.Flags().Bool("wait", false, "Wait for the VM to become Ready")
+	cmd.Flags().Duration("timeout", 15*time.Minute, "Maximum time to wait with --wait")
+
+	return cmd
+}
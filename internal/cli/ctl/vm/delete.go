@@ -0,0 +1,50 @@
+// Package vm implements the butlerctl commands for KubeVirt virtual machines.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vm
+
+import (
+	"context"
+
+	handler "github.com/butlerdotdev/butler/internal/handlers/tenant"
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func NewDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a virtual machine",
+		Long:  `Deletes a VM together with its root disk and cloud-init user data.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			h := handler.NewTenantHandler(context.Background(), log)
+			if err := h.HandleVMDelete(args[0]); err != nil {
+				log.Error("VM deletion failed", zap.Error(err))
+				return err
+			}
+
+			log.Info("VM deleted successfully!", zap.String("name", args[0]))
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,85 @@
+// Package vm implements the butlerctl commands for KubeVirt virtual machines.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/butlerdotdev/butler/internal/cli/ctl/output"
+	handler "github.com/butlerdotdev/butler/internal/handlers/tenant"
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func NewListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List virtual machines",
+		Long:    `Lists the VMs in the namespace with their status, size, address and the node they run on.`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			format, err := output.Format(cmd)
+			if err != nil {
+				return err
+			}
+			allNamespaces, _ := cmd.Flags().GetBool("all-namespaces")
+
+			h := handler.NewTenantHandler(context.Background(), log)
+			vms, err := h.HandleVMList(allNamespaces)
+			if err != nil {
+				log.Error("Listing VMs failed", zap.Error(err))
+				return err
+			}
+
+			return output.Print(os.Stdout, format, vms, func(t *tabwriter.Writer) {
+				if allNamespaces {
+					fmt.Fprint(t, "NAMESPACE\t")
+				}
+				fmt.Fprintln(t, "NAME\tSTATUS\tREADY\tFLAVOR\tCPU\tMEMORY\tIP\tNODE\tAGE")
+				for _, vm := range vms {
+					if allNamespaces {
+						fmt.Fprintf(t, "%s\t", vm.Namespace)
+					}
+					fmt.Fprintf(t, "%s\t%s\t%t\t%s\t%d\t%s\t%s\t%s\t%s\n",
+						vm.Name,
+						output.OrNone(vm.Status),
+						vm.Ready,
+						output.OrNone(vm.Flavor),
+						vm.CPU,
+						output.OrNone(vm.Memory),
+						output.OrNone(vm.IP),
+						output.OrNone(vm.Node),
+						output.Age(vm.Created),
+					)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().BoolP("all-namespaces", "A", false, "List VMs in all namespaces")
+	output.AddFlag(cmd)
+
+	return cmd
+}
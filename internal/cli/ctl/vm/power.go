@@ -0,0 +1,60 @@
+// Package vm implements the butlerctl commands for KubeVirt virtual machines.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vm
+
+import (
+	"context"
+
+	handler "github.com/butlerdotdev/butler/internal/handlers/tenant"
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func NewStartCmd() *cobra.Command {
+	return newPowerCmd("start", "Start a stopped virtual machine", "started")
+}
+
+func NewStopCmd() *cobra.Command {
+	return newPowerCmd("stop", "Shut down a virtual machine and keep it stopped", "stopped")
+}
+
+func NewRestartCmd() *cobra.Command {
+	return newPowerCmd("restart", "Restart a running virtual machine", "restarted")
+}
+
+// newPowerCmd builds a command that changes a VM's run state through KubeVirt.
+func newPowerCmd(action, short, done string) *cobra.Command {
+	return &cobra.Command{
+		Use:   action + " <name>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+
+			h := handler.NewTenantHandler(context.Background(), log)
+			if err := h.HandleVMPower(args[0], action); err != nil {
+				log.Error("VM "+action+" failed", zap.Error(err))
+				return err
+			}
+
+			log.Info("VM "+done+" successfully!", zap.String("name", args[0]))
+			return nil
+		},
+	}
+}
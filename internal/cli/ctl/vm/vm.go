@@ -0,0 +1,43 @@
+// Package vm implements the butlerctl commands for KubeVirt virtual machines.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vm
+
+import (
+	"fmt"
+
+	"github.com/butlerdotdev/butler/internal/logger"
+
+	"github.com/spf13/cobra"
+)
+
+func NewVMCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vm",
+		Short: "Create and operate KubeVirt virtual machines",
+		Long: `Creates, lists, starts, stops, restarts and deletes KubeVirt virtual machines in your namespace on
+the Butler management cluster, and attaches to their serial consoles. VMs are sized by flavor, boot
+from a root disk CDI imports from an image, and can be placed in a Kube-OVN subnet. Requires a
+subcommand to be called.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := logger.GetLogger()
+			log.Error("vm needs to be run with a subcommand (e.g., 'butlerctl vm create' or 'butlerctl vm list').")
+			return fmt.Errorf("vm needs to be run with a subcommand (e.g., 'butlerctl vm create' or 'butlerctl vm list')")
+		},
+	}
+
+	return cmd
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	service "github.com/butlerdotdev/butler/internal/services/bootstrap/nutanix"
+	"github.com/butlerdotdev/butler/internal/services/secrets"
 	"github.com/butlerdotdev/butler/pkg/models"
 
 	"github.com/spf13/viper"
@@ -58,6 +59,11 @@ func (h *BootstrapHandler) HandleProvisionCluster() error {
 		return fmt.Errorf("configuration invalid: %w", err)
 	}
 
+	if err := secrets.NewResolver(h.logger).ResolveConfig(h.ctx, &config); err != nil {
+		h.logger.Error("Failed to resolve secrets", zap.Error(err))
+		return fmt.Errorf("failed to resolve configuration secrets: %w", err)
+	}
+
 	// Initialize bootstrap service
 	bootstrapService, err := service.NewBootstrapService(h.ctx, &config, h.logger)
 	if err != nil {
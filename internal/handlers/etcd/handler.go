@@ -0,0 +1,114 @@
+// Package etcd provides handlers for backing up and restoring the management cluster's etcd.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+
+	service "github.com/butlerdotdev/butler/internal/services/etcd"
+	"github.com/butlerdotdev/butler/internal/services/secrets"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// EtcdHandler handles etcd snapshot and restore requests.
+type EtcdHandler struct {
+	ctx    context.Context
+	logger *zap.Logger
+}
+
+// NewEtcdHandler initializes a new EtcdHandler.
+func NewEtcdHandler(ctx context.Context, logger *zap.Logger) *EtcdHandler {
+	return &EtcdHandler{
+		ctx:    ctx,
+		logger: logger,
+	}
+}
+
+// HandleSnapshot takes a one-off snapshot, or installs the in-cluster backup CronJob when scheduled is set.
+func (h *EtcdHandler) HandleSnapshot(node, schedule string, scheduled bool) error {
+	h.logger.Info("Handling etcd snapshot request...", zap.Bool("scheduled", scheduled))
+
+	etcdService, err := h.newService()
+	if err != nil {
+		return err
+	}
+
+	if scheduled {
+		if err := etcdService.Schedule(h.ctx, schedule); err != nil {
+			h.logger.Error("Failed to schedule etcd snapshots", zap.Error(err))
+			return err
+		}
+		return nil
+	}
+
+	name, err := etcdService.Snapshot(h.ctx, node)
+	if err != nil {
+		h.logger.Error("etcd snapshot failed", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("etcd snapshot completed successfully.", zap.String("snapshot", name))
+	return nil
+}
+
+// HandleRestore restores etcd on the control plane from a snapshot.
+func (h *EtcdHandler) HandleRestore(opts service.RestoreOptions) error {
+	h.logger.Info("Handling etcd restore request...", zap.String("snapshot", opts.Snapshot))
+
+	etcdService, err := h.newService()
+	if err != nil {
+		return err
+	}
+
+	if err := etcdService.Restore(h.ctx, opts); err != nil {
+		h.logger.Error("etcd restore failed", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("etcd restore completed successfully.")
+	return nil
+}
+
+// newService loads and validates the config and initializes the etcd service.
+func (h *EtcdHandler) newService() (*service.EtcdService, error) {
+	var config models.BootstrapConfig
+	if err := viper.Unmarshal(&config); err != nil {
+		h.logger.Error("Failed to load config", zap.Error(err))
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if config.ManagementCluster.Name == "" {
+		h.logger.Error("Configuration validation failed", zap.String("field", "managementcluster.name"))
+		return nil, fmt.Errorf("configuration invalid: managementcluster.name is required")
+	}
+
+	if err := secrets.NewResolver(h.logger).ResolveConfig(h.ctx, &config); err != nil {
+		h.logger.Error("Failed to resolve secrets", zap.Error(err))
+		return nil, fmt.Errorf("failed to resolve configuration secrets: %w", err)
+	}
+
+	etcdService, err := service.NewEtcdService(h.ctx, &config, h.logger)
+	if err != nil {
+		h.logger.Error("Failed to initialize etcd service", zap.Error(err))
+		return nil, err
+	}
+	return etcdService, nil
+}
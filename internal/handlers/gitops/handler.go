@@ -0,0 +1,81 @@
+// Package gitops provides handlers for managing the management cluster's GitOps repository.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/butlerdotdev/butler/internal/services/gitops"
+	"github.com/butlerdotdev/butler/internal/services/secrets"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// GitOpsHandler handles GitOps repository requests.
+type GitOpsHandler struct {
+	ctx    context.Context
+	logger *zap.Logger
+}
+
+// NewGitOpsHandler initializes a new GitOpsHandler.
+func NewGitOpsHandler(ctx context.Context, logger *zap.Logger) *GitOpsHandler {
+	return &GitOpsHandler{
+		ctx:    ctx,
+		logger: logger,
+	}
+}
+
+// HandleSync commits the configured component catalog to the Flux repository.
+func (h *GitOpsHandler) HandleSync() error {
+	h.logger.Info("Handling gitops sync request...")
+
+	var config models.BootstrapConfig
+	if err := viper.Unmarshal(&config); err != nil {
+		h.logger.Error("Failed to load config", zap.Error(err))
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if config.ManagementCluster.Name == "" {
+		h.logger.Error("Configuration validation failed", zap.String("field", "managementcluster.name"))
+		return fmt.Errorf("configuration invalid: managementcluster.name is required")
+	}
+	if err := gitops.Validate(config.ManagementCluster.Flux); err != nil {
+		h.logger.Error("Configuration validation failed", zap.Error(err))
+		return fmt.Errorf("configuration invalid: %w", err)
+	}
+
+	if err := secrets.NewResolver(h.logger).ResolveConfig(h.ctx, &config); err != nil {
+		h.logger.Error("Failed to resolve secrets", zap.Error(err))
+		return fmt.Errorf("failed to resolve configuration secrets: %w", err)
+	}
+
+	renderer, err := gitops.NewComponentRendererForConfig(config.ManagementCluster.Flux, h.logger)
+	if err != nil {
+		h.logger.Error("Failed to initialize component renderer", zap.Error(err))
+		return err
+	}
+	if err := renderer.Sync(h.ctx, config.ManagementCluster.Flux.Components); err != nil {
+		h.logger.Error("Component sync failed", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("Components synced successfully.", zap.Int("components", len(config.ManagementCluster.Flux.Components)))
+	return nil
+}
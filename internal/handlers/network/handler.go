@@ -0,0 +1,82 @@
+// Package network provides handlers for managing the management cluster's network.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/butlerdotdev/butler/internal/services/cni"
+	"github.com/butlerdotdev/butler/internal/services/secrets"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// NetworkHandler handles network configuration requests.
+type NetworkHandler struct {
+	ctx    context.Context
+	logger *zap.Logger
+}
+
+// NewNetworkHandler initializes a new NetworkHandler.
+func NewNetworkHandler(ctx context.Context, logger *zap.Logger) *NetworkHandler {
+	return &NetworkHandler{
+		ctx:    ctx,
+		logger: logger,
+	}
+}
+
+// HandleConfigure reconciles the CNI's network resources with the configuration.
+func (h *NetworkHandler) HandleConfigure(server string) error {
+	h.logger.Info("Handling network configure request...")
+
+	var config models.BootstrapConfig
+	if err := viper.Unmarshal(&config); err != nil {
+		h.logger.Error("Failed to load config", zap.Error(err))
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if config.ManagementCluster.Name == "" {
+		h.logger.Error("Configuration validation failed", zap.String("field", "managementcluster.name"))
+		return fmt.Errorf("configuration invalid: managementcluster.name is required")
+	}
+
+	if err := secrets.NewResolver(h.logger).ResolveConfig(h.ctx, &config); err != nil {
+		h.logger.Error("Failed to resolve secrets", zap.Error(err))
+		return fmt.Errorf("failed to resolve configuration secrets: %w", err)
+	}
+
+	installer, err := cni.NewCNIInstallerForCluster(&config, h.logger)
+	if err != nil {
+		h.logger.Error("Failed to initialize CNI installer", zap.Error(err))
+		return err
+	}
+	if err := installer.Validate(config.ManagementCluster.Network); err != nil {
+		h.logger.Error("Configuration validation failed", zap.Error(err))
+		return err
+	}
+
+	if err := installer.Configure(h.ctx, server, &config); err != nil {
+		h.logger.Error("Network configuration failed", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("Network configured successfully.", zap.String("cni", installer.Name()))
+	return nil
+}
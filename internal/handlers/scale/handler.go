@@ -0,0 +1,82 @@
+// Package scale provides handlers for scaling the Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"context"
+	"fmt"
+
+	service "github.com/butlerdotdev/butler/internal/services/scale"
+	"github.com/butlerdotdev/butler/internal/services/secrets"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// ScaleHandler handles requests for scaling the management cluster.
+type ScaleHandler struct {
+	ctx    context.Context
+	logger *zap.Logger
+}
+
+// NewScaleHandler initializes a new ScaleHandler.
+func NewScaleHandler(ctx context.Context, logger *zap.Logger) *ScaleHandler {
+	return &ScaleHandler{
+		ctx:    ctx,
+		logger: logger,
+	}
+}
+
+// HandleScale loads config and brings the number of nodes with a role to the requested count.
+func (h *ScaleHandler) HandleScale(opts service.Options) error {
+	h.logger.Info("Handling scale request...", zap.String("role", opts.Role), zap.Int("count", opts.Count))
+
+	var config models.BootstrapConfig
+	if err := viper.Unmarshal(&config); err != nil {
+		h.logger.Error("Failed to load config", zap.Error(err))
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if config.ManagementCluster.Name == "" {
+		h.logger.Error("Configuration validation failed", zap.String("field", "managementcluster.name"))
+		return fmt.Errorf("configuration invalid: managementcluster.name is required")
+	}
+	if config.ManagementCluster.Provider == "" {
+		h.logger.Error("Configuration validation failed", zap.String("field", "managementcluster.provider"))
+		return fmt.Errorf("configuration invalid: managementcluster.provider is required")
+	}
+
+	if err := secrets.NewResolver(h.logger).ResolveConfig(h.ctx, &config); err != nil {
+		h.logger.Error("Failed to resolve secrets", zap.Error(err))
+		return fmt.Errorf("failed to resolve configuration secrets: %w", err)
+	}
+
+	scaleService, err := service.NewScaleService(h.ctx, &config, h.logger)
+	if err != nil {
+		h.logger.Error("Failed to initialize scale service", zap.Error(err))
+		return err
+	}
+
+	if err := scaleService.Scale(h.ctx, opts); err != nil {
+		h.logger.Error("Scaling failed", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("Scaling completed successfully.")
+	return nil
+}
@@ -0,0 +1,148 @@
+// Package tenant provides handlers for the tenant clusters and virtual machines butlerctl manages.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	service "github.com/butlerdotdev/butler/internal/services/tenant"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// defaultNamespace holds tenant objects when neither --namespace nor namespace is set.
+const defaultNamespace = "default"
+
+// TenantHandler handles butlerctl requests against the management cluster.
+type TenantHandler struct {
+	ctx    context.Context
+	logger *zap.Logger
+}
+
+// NewTenantHandler initializes a new TenantHandler.
+func NewTenantHandler(ctx context.Context, logger *zap.Logger) *TenantHandler {
+	return &TenantHandler{
+		ctx:    ctx,
+		logger: logger,
+	}
+}
+
+// Namespace returns the namespace requests apply to.
+func (h *TenantHandler) Namespace() string {
+	if namespace := viper.GetString("namespace"); namespace != "" {
+		return namespace
+	}
+	return defaultNamespace
+}
+
+// HandleClusterCreate creates a tenant cluster, waiting for it to become ready when timeout is set.
+func (h *TenantHandler) HandleClusterCreate(opts service.CreateOptions, timeout time.Duration) error {
+	h.logger.Info("Handling cluster create request...", zap.String("name", opts.Name))
+
+	clusters, err := h.clusterService()
+	if err != nil {
+		return err
+	}
+	opts.Namespace = h.Namespace()
+	return clusters.Create(h.ctx, opts, timeout)
+}
+
+// HandleClusterList returns the tenant clusters in the namespace, or in all namespaces.
+func (h *TenantHandler) HandleClusterList(allNamespaces bool) ([]service.ClusterStatus, error) {
+	clusters, err := h.clusterService()
+	if err != nil {
+		return nil, err
+	}
+	namespace := h.Namespace()
+	if allNamespaces {
+		namespace = ""
+	}
+	return clusters.List(h.ctx, namespace)
+}
+
+// HandleClusterGet returns the status of a tenant cluster.
+func (h *TenantHandler) HandleClusterGet(name string) (*service.ClusterStatus, error) {
+	clusters, err := h.clusterService()
+	if err != nil {
+		return nil, err
+	}
+	return clusters.Get(h.ctx, h.Namespace(), name)
+}
+
+// HandleClusterDelete deletes a tenant cluster, waiting for its teardown when timeout is set.
+func (h *TenantHandler) HandleClusterDelete(name string, timeout time.Duration) error {
+	h.logger.Info("Handling cluster delete request...", zap.String("name", name))
+
+	clusters, err := h.clusterService()
+	if err != nil {
+		return err
+	}
+	return clusters.Delete(h.ctx, h.Namespace(), name, timeout)
+}
+
+// HandleClusterKubeconfig returns the admin kubeconfig of a tenant cluster.
+func (h *TenantHandler) HandleClusterKubeconfig(name string) ([]byte, error) {
+	clusters, err := h.clusterService()
+	if err != nil {
+		return nil, err
+	}
+	return clusters.Kubeconfig(h.ctx, h.Namespace(), name)
+}
+
+// clusterService loads the configuration and connects a ClusterService to the management cluster.
+func (h *TenantHandler) clusterService() (*service.ClusterService, error) {
+	config, err := h.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	kube := kubernetes.NewKubernetesAdapter(managementKubeconfig(config.Kubeconfig), h.logger)
+	return service.NewClusterService(kube, config.Clusters, h.logger), nil
+}
+
+// loadConfig reads the butlerctl configuration. Flags are bound to the same keys and take precedence.
+func (h *TenantHandler) loadConfig() (*models.CtlConfig, error) {
+	var config models.CtlConfig
+	if err := viper.Unmarshal(&config); err != nil {
+		h.logger.Error("Failed to load config", zap.Error(err))
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return &config, nil
+}
+
+// managementKubeconfig returns the configured kubeconfig, falling back to $KUBECONFIG and then
+// ~/.kube/config like kubectl. A leading ~/ is expanded.
+func managementKubeconfig(configured string) string {
+	home, _ := os.UserHomeDir()
+	switch {
+	case strings.HasPrefix(configured, "~/") && home != "":
+		return filepath.Join(home, configured[2:])
+	case configured != "":
+		return configured
+	}
+	if env := os.Getenv("KUBECONFIG"); env != "" {
+		return filepath.SplitList(env)[0]
+	}
+	return filepath.Join(home, ".kube", "config")
+}
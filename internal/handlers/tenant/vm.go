@@ -0,0 +1,103 @@
+// Package tenant provides handlers for the tenant clusters and virtual machines butlerctl manages.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	service "github.com/butlerdotdev/butler/internal/services/tenant"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+
+	"go.uber.org/zap"
+)
+
+// HandleVMCreate creates a VM, waiting for it to become ready when timeout is set.
+func (h *TenantHandler) HandleVMCreate(opts service.VMCreateOptions, timeout time.Duration) error {
+	h.logger.Info("Handling VM create request...", zap.String("name", opts.Name))
+
+	vms, err := h.vmService()
+	if err != nil {
+		return err
+	}
+	opts.Namespace = h.Namespace()
+	return vms.Create(h.ctx, opts, timeout)
+}
+
+// HandleVMList returns the VMs in the namespace, or in all namespaces.
+func (h *TenantHandler) HandleVMList(allNamespaces bool) ([]service.VMStatus, error) {
+	vms, err := h.vmService()
+	if err != nil {
+		return nil, err
+	}
+	namespace := h.Namespace()
+	if allNamespaces {
+		namespace = ""
+	}
+	return vms.List(h.ctx, namespace)
+}
+
+// HandleVMPower starts, stops or restarts a VM.
+func (h *TenantHandler) HandleVMPower(name, action string) error {
+	h.logger.Info("Handling VM power request...", zap.String("name", name), zap.String("action", action))
+
+	vms, err := h.vmService()
+	if err != nil {
+		return err
+	}
+	switch action {
+	case "start":
+		return vms.Start(h.ctx, h.Namespace(), name)
+	case "stop":
+		return vms.Stop(h.ctx, h.Namespace(), name)
+	case "restart":
+		return vms.Restart(h.ctx, h.Namespace(), name)
+	default:
+		return fmt.Errorf("unsupported VM action %q", action)
+	}
+}
+
+// HandleVMDelete deletes a VM along with its root disk.
+func (h *TenantHandler) HandleVMDelete(name string) error {
+	h.logger.Info("Handling VM delete request...", zap.String("name", name))
+
+	vms, err := h.vmService()
+	if err != nil {
+		return err
+	}
+	return vms.Delete(h.ctx, h.Namespace(), name)
+}
+
+// HandleVMConsole attaches in and out to a VM's serial console.
+func (h *TenantHandler) HandleVMConsole(name string, in io.Reader, out io.Writer) error {
+	vms, err := h.vmService()
+	if err != nil {
+		return err
+	}
+	return vms.Console(h.ctx, h.Namespace(), name, in, out)
+}
+
+// vmService loads the configuration and connects a VMService to the management cluster.
+func (h *TenantHandler) vmService() (*service.VMService, error) {
+	config, err := h.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	kube := kubernetes.NewKubernetesAdapter(managementKubeconfig(config.Kubeconfig), h.logger)
+	return service.NewVMService(kube, config.VMs, h.logger), nil
+}
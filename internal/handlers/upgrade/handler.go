@@ -0,0 +1,105 @@
+// Package upgrade provides handlers for upgrading the Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/butlerdotdev/butler/internal/services/secrets"
+	service "github.com/butlerdotdev/butler/internal/services/upgrade"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// UpgradeHandler handles requests for upgrading the management cluster.
+type UpgradeHandler struct {
+	ctx    context.Context
+	logger *zap.Logger
+}
+
+// NewUpgradeHandler initializes a new UpgradeHandler.
+func NewUpgradeHandler(ctx context.Context, logger *zap.Logger) *UpgradeHandler {
+	return &UpgradeHandler{
+		ctx:    ctx,
+		logger: logger,
+	}
+}
+
+// HandleUpgradeTalos loads config and rolls a Talos upgrade through the cluster.
+func (h *UpgradeHandler) HandleUpgradeTalos(opts service.Options) error {
+	h.logger.Info("Handling Talos upgrade request...", zap.String("version", opts.Version))
+
+	upgradeService, err := h.newService()
+	if err != nil {
+		return err
+	}
+
+	if err := upgradeService.UpgradeTalos(h.ctx, opts); err != nil {
+		h.logger.Error("Talos upgrade failed", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("Talos upgrade completed successfully.")
+	return nil
+}
+
+// HandleUpgradeKubernetes loads config and rolls a Kubernetes upgrade through the cluster.
+func (h *UpgradeHandler) HandleUpgradeKubernetes(opts service.Options) error {
+	h.logger.Info("Handling Kubernetes upgrade request...", zap.String("version", opts.Version))
+
+	upgradeService, err := h.newService()
+	if err != nil {
+		return err
+	}
+
+	if err := upgradeService.UpgradeKubernetes(h.ctx, opts); err != nil {
+		h.logger.Error("Kubernetes upgrade failed", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info("Kubernetes upgrade completed successfully.")
+	return nil
+}
+
+// newService loads and validates the config and initializes the upgrade service.
+func (h *UpgradeHandler) newService() (*service.UpgradeService, error) {
+	var config models.BootstrapConfig
+	if err := viper.Unmarshal(&config); err != nil {
+		h.logger.Error("Failed to load config", zap.Error(err))
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if config.ManagementCluster.Name == "" {
+		h.logger.Error("Configuration validation failed", zap.String("field", "managementcluster.name"))
+		return nil, fmt.Errorf("configuration invalid: managementcluster.name is required")
+	}
+
+	if err := secrets.NewResolver(h.logger).ResolveConfig(h.ctx, &config); err != nil {
+		h.logger.Error("Failed to resolve secrets", zap.Error(err))
+		return nil, fmt.Errorf("failed to resolve configuration secrets: %w", err)
+	}
+
+	upgradeService, err := service.NewUpgradeService(h.ctx, &config, h.logger)
+	if err != nil {
+		h.logger.Error("Failed to initialize upgrade service", zap.Error(err))
+		return nil, err
+	}
+	return upgradeService, nil
+}
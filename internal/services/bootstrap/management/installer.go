@@ -0,0 +1,213 @@
+// Package management runs the provider-independent steps of bootstrapping the Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package management
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/capi"
+	"github.com/butlerdotdev/butler/internal/services/cluster"
+	"github.com/butlerdotdev/butler/internal/services/cni"
+	"github.com/butlerdotdev/butler/internal/services/controlplane"
+	"github.com/butlerdotdev/butler/internal/services/gitops"
+	"github.com/butlerdotdev/butler/internal/services/ingress"
+	"github.com/butlerdotdev/butler/internal/services/kubevip"
+	"github.com/butlerdotdev/butler/internal/services/loadbalancer"
+	"github.com/butlerdotdev/butler/internal/services/readiness"
+	"github.com/butlerdotdev/butler/internal/services/storage"
+	"github.com/butlerdotdev/butler/internal/services/virtualization"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/flux"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/helm"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubectl"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+)
+
+// apiTimeout bounds each wait for the Kubernetes API, on a node or through the control plane endpoint.
+const apiTimeout = 5 * time.Minute
+
+// Installer brings a freshly bootstrapped Talos cluster up to a running management cluster. The
+// steps are the same on every provider; only provisioning the VMs and configuring Talos differ.
+type Installer struct {
+	logger            *zap.Logger
+	kube              *kubernetes.KubernetesAdapter
+	kubeConfigManager *KubeConfigManager
+	kubeVipInit       *KubeVipInitializer
+	cni               cni.CNIInstaller
+	metalLB           *loadbalancer.MetalLBInstaller
+	traefik           *ingress.TraefikInstaller
+	linstor           *storage.LinstorInstaller
+	nutanixCSI        *storage.NutanixCSIInstaller
+	clusterAPI        *capi.Installer
+	kubeVirt          *virtualization.KubeVirtInstaller
+	flux              *gitops.FluxBootstrapper
+}
+
+// NewInstaller creates an Installer from the bootstrap adapters and the configured CNI.
+func NewInstaller(kube *kubernetes.KubernetesAdapter, kubectlAdapter *kubectl.KubectlAdapter, helmAdapter *helm.HelmAdapter, fluxAdapter *flux.FluxAdapter, talos *cluster.TalosOperator, cniInstaller cni.CNIInstaller, logger *zap.Logger) *Installer {
+	return &Installer{
+		logger:            logger,
+		kube:              kube,
+		kubeConfigManager: NewKubeConfigManager(logger, kubectlAdapter),
+		kubeVipInit:       NewKubeVipInitializer(talos, kube, logger),
+		cni:               cniInstaller,
+		metalLB:           loadbalancer.NewMetalLBInstaller(kube, helmAdapter, logger),
+		traefik:           ingress.NewTraefikInstaller(kube, helmAdapter, logger),
+		linstor:           storage.NewLinstorInstaller(kube, logger),
+		nutanixCSI:        storage.NewNutanixCSIInstaller(kube, helmAdapter, logger),
+		clusterAPI:        capi.NewInstaller(kube, helmAdapter, logger),
+		kubeVirt:          virtualization.NewKubeVirtInstaller(kube, talos, logger),
+		flux:              gitops.NewFluxBootstrapper(fluxAdapter, kube.Kubeconfig(), logger),
+	}
+}
+
+// Install runs everything after Talos is bootstrapped: it waits for the API and the nodes,
+// brings up the control plane endpoint, installs the CNI and the enabled platform components,
+// bootstraps Flux and commits the component catalog.
+func (i *Installer) Install(ctx context.Context, config *models.BootstrapConfig, controlPlanes, workers []string) error {
+	mc := config.ManagementCluster
+
+	// Validate kubeconfig
+	kubeconfigPath := i.kube.Kubeconfig()
+	if err := i.kubeConfigManager.ValidateKubeConfig(kubeconfigPath); err != nil {
+		return fmt.Errorf("kubeconfig validation failed: %w", err)
+	}
+	if err := i.kubeConfigManager.EnsureCorrectContext(kubeconfigPath, mc.Name); err != nil {
+		return fmt.Errorf("failed to set kubeconfig context: %w", err)
+	}
+	if err := i.kubeConfigManager.WaitForKubernetesAPI(kubeconfigPath, controlPlanes[0], apiTimeout); err != nil {
+		return fmt.Errorf("kubernetes API not ready: %w", err)
+	}
+
+	// Until the control plane endpoint is up, talk to the first control plane node directly.
+	server := fmt.Sprintf("https://%s:6443", controlPlanes[0])
+
+	// Wait until all nodes register and map their IPs to names before a VIP moves onto a node.
+	if err := cni.WaitForNodes(ctx, i.kube.WithServer(server), len(controlPlanes)+len(workers), 5*time.Minute, i.logger); err != nil {
+		return fmt.Errorf("failed waiting for nodes to register: %w", err)
+	}
+	ipToNodeMap, err := i.kube.WithServer(server).InternalIPToNodeName(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to collect node IP-to-name map: %w", err)
+	}
+	cniNodes := cni.Nodes{ControlPlanes: controlPlanes, Workers: workers, Names: ipToNodeMap}
+
+	// Kube-Vip
+	if controlplane.UsesKubeVip(mc.Talos) {
+		if err := i.kubeVipInit.ConfigureKubeVip(ctx, config, controlPlanes[0]); err != nil {
+			return fmt.Errorf("failed to configure Kube-Vip: %w", err)
+		}
+		if err := readiness.NewWaiter(i.kube.WithServer(server), i.logger).Wait(ctx, readiness.DaemonSet(kubevip.Namespace, kubevip.DaemonSetName)); err != nil {
+			return fmt.Errorf("Kube-Vip did not become ready: %w", err)
+		}
+	}
+
+	// Check the API through the control plane endpoint; everything from here on goes through it.
+	endpointHost := controlplane.Host(mc.Talos)
+	if err := i.kubeConfigManager.WaitForKubernetesAPI(kubeconfigPath, endpointHost, apiTimeout); err != nil {
+		return fmt.Errorf("Kubernetes API not ready on control plane endpoint %s: %w", endpointHost, err)
+	}
+	endpointServer := fmt.Sprintf("https://%s:6443", endpointHost)
+
+	// Label nodes, install the CNI with templated values and wait for it to roll out
+	if err := i.cni.LabelNodes(ctx, endpointServer, cniNodes); err != nil {
+		return fmt.Errorf("failed to label nodes for %s: %w", i.cni.Name(), err)
+	}
+	if err := i.cni.Install(ctx, config, cniNodes); err != nil {
+		return fmt.Errorf("failed to install %s: %w", i.cni.Name(), err)
+	}
+	if err := i.cni.WaitForReady(ctx, endpointServer); err != nil {
+		return fmt.Errorf("%s did not become ready: %w", i.cni.Name(), err)
+	}
+	if err := i.cni.Configure(ctx, endpointServer, config); err != nil {
+		return fmt.Errorf("failed to configure %s networks: %w", i.cni.Name(), err)
+	}
+
+	// Install MetalLB so LoadBalancer Services get addresses from the configured pools
+	if len(mc.LoadBalancer.Pools) > 0 {
+		if err := i.metalLB.Install(ctx, endpointServer, mc.LoadBalancer); err != nil {
+			return fmt.Errorf("failed to install MetalLB: %w", err)
+		}
+	}
+
+	// Install Traefik behind a MetalLB address
+	if mc.Ingress.Enabled {
+		if err := i.traefik.Install(ctx, endpointServer, mc.Ingress); err != nil {
+			return fmt.Errorf("failed to install Traefik: %w", err)
+		}
+	}
+
+	// Install the Piraeus operator and build LINSTOR storage pools on the workers' extra disks
+	if mc.Storage.Linstor.Enabled {
+		if err := i.linstor.Install(ctx, endpointServer, mc.Storage.Linstor, mc.Nodes); err != nil {
+			return fmt.Errorf("failed to install LINSTOR: %w", err)
+		}
+	}
+
+	// Install the Nutanix CSI driver and StorageClasses for the configured storage containers
+	if mc.Storage.NutanixCSI.Enabled {
+		if err := i.nutanixCSI.Install(ctx, endpointServer, mc.Storage.NutanixCSI, mc.Nutanix); err != nil {
+			return fmt.Errorf("failed to install Nutanix CSI driver: %w", err)
+		}
+	}
+
+	// Install Cluster API, Kamaji and their providers so tenant clusters can be created
+	if mc.ClusterAPI.Enabled {
+		if err := i.clusterAPI.Install(ctx, endpointServer, mc); err != nil {
+			return fmt.Errorf("failed to install Cluster API: %w", err)
+		}
+	}
+
+	// Install KubeVirt and CDI on the nodes VMs run on, workers unless there are none
+	if mc.Virtualization.Enabled {
+		vmNodes := workers
+		if len(vmNodes) == 0 {
+			vmNodes = controlPlanes
+		}
+		if err := i.kubeVirt.Install(ctx, endpointServer, mc.Virtualization, vmNodes); err != nil {
+			return fmt.Errorf("failed to install KubeVirt: %w", err)
+		}
+	}
+
+	// Bootstrap Flux
+	if err := i.flux.Bootstrap(ctx, config); err != nil {
+		return fmt.Errorf("failed to bootstrap Flux: %w", err)
+	}
+	if err := readiness.NewWaiter(i.kube.WithServer(endpointServer), i.logger).Wait(ctx,
+		readiness.Kustomization("flux-system", "flux-system").WithTimeout(10*time.Minute),
+	); err != nil {
+		return fmt.Errorf("Flux did not become ready: %w", err)
+	}
+
+	// Commit the component catalog for Flux to install
+	if components := mc.Flux.Components; len(components) > 0 {
+		renderer, err := gitops.NewComponentRendererForConfig(mc.Flux, i.logger)
+		if err != nil {
+			return fmt.Errorf("failed to initialize component renderer: %w", err)
+		}
+		if err := renderer.Sync(ctx, components); err != nil {
+			return fmt.Errorf("failed to sync components: %w", err)
+		}
+	}
+
+	i.logger.Info("Flux bootstrap completed successfully")
+	return nil
+}
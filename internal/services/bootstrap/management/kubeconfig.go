@@ -1,4 +1,4 @@
-// Package bootstrap provides services for KubeConfig in Butler.
+// Package management provides services for KubeConfig in Butler.
 //
 // Copyright (c) 2025, The Butler Authors
 //
@@ -14,7 +14,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package bootstrap
+package management
 
 import (
 	"context"
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/butlerdotdev/butler/pkg/adapters/platforms"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
 
 	"go.uber.org/zap"
 )
@@ -67,37 +68,19 @@ func (k *KubeConfigManager) WaitForKubernetesAPI(kubeconfigPath, controlPlaneNod
 
 	k.logger.Info("Waiting for Kubernetes API to be ready", zap.String("controlPlaneNode", controlPlaneNode))
 	server := fmt.Sprintf("https://%s:6443", controlPlaneNode)
+	kube := kubernetes.NewKubernetesAdapter(kubeconfigPath, k.logger).WithServer(server)
 	deadline := time.Now().Add(timeout)
 
 	for time.Now().Before(deadline) {
-		_, err := k.platformAdapter.ExecuteCommand(context.Background(),
-			"--server", server, "--kubeconfig", kubeconfigPath, "get", "nodes", "--request-timeout=15s",
-		)
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		err := kube.Ready(ctx)
+		cancel()
 		if err == nil {
 			k.logger.Info("Kubernetes API is ready", zap.String("server", server))
 			return nil
 		}
 
 		k.logger.Warn("Kubernetes API not yet ready, retrying...", zap.String("server", server), zap.Error(err))
-
-		// 🔄 Attempt to refresh kubeconfig if API is still unreachable after multiple tries
-		if time.Now().Add(-30 * time.Second).After(deadline) {
-			k.logger.Warn("Kubernetes API still unavailable, attempting to refresh kubeconfig")
-
-			_, err = k.platformAdapter.ExecuteCommand(context.Background(),
-				"--server", server, "--kubeconfig", kubeconfigPath, "config", "view",
-			)
-			if err != nil {
-				k.logger.Warn("Kubeconfig may be invalid, attempting to refresh it...")
-				_, _ = k.platformAdapter.ExecuteCommand(context.Background(),
-					"--server", server, "--kubeconfig", kubeconfigPath, "config", "unset", "current-context",
-				)
-				_, _ = k.platformAdapter.ExecuteCommand(context.Background(),
-					"--server", server, "--kubeconfig", kubeconfigPath, "config", "use-context", "admin@butler-r",
-				)
-			}
-		}
-
 		time.Sleep(10 * time.Second)
 	}
 
@@ -0,0 +1,133 @@
+// Package management provides services for configuring Kube-Vip in Butler.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package management
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/butlerdotdev/butler/internal/services/cluster"
+	"github.com/butlerdotdev/butler/internal/services/kubevip"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+)
+
+// KubeVipInitializer handles generating and deploying the Kube-Vip manifest.
+type KubeVipInitializer struct {
+	talos  *cluster.TalosOperator
+	kube   *kubernetes.KubernetesAdapter
+	logger *zap.Logger
+}
+
+// NewKubeVipInitializer creates a new KubeVip initializer.
+func NewKubeVipInitializer(talos *cluster.TalosOperator, kube *kubernetes.KubernetesAdapter, logger *zap.Logger) *KubeVipInitializer {
+	return &KubeVipInitializer{talos: talos, kube: kube, logger: logger}
+}
+
+// ConfigureKubeVip performs all steps to set up Kube-Vip through the given control plane node: generating the manifest, applying RBAC, and deploying the DaemonSet.
+func (k *KubeVipInitializer) ConfigureKubeVip(ctx context.Context, config *models.BootstrapConfig, node string) error {
+	k.logger.Info("Starting Kube-Vip configuration")
+	server := fmt.Sprintf("https://%s:6443", node)
+	manifestPath := filepath.Join(cluster.OutputDir(config.ManagementCluster.Talos), "kube-vip-ds.yaml")
+
+	// Generate Kube-Vip manifest
+	if err := k.GenerateManifest(ctx, config, node, manifestPath); err != nil {
+		return fmt.Errorf("failed to generate Kube-Vip manifest: %w", err)
+	}
+
+	// Apply Kube-Vip RBAC
+	if err := k.ApplyRBAC(ctx, server); err != nil {
+		return fmt.Errorf("failed to apply Kube-Vip RBAC: %w", err)
+	}
+
+	// Apply Kube-Vip DaemonSet
+	if err := k.ApplyDaemonSet(ctx, server, manifestPath); err != nil {
+		return fmt.Errorf("failed to apply Kube-Vip DaemonSet: %w", err)
+	}
+
+	k.logger.Info("Kube-Vip setup completed successfully")
+	return nil
+}
+
+// GenerateManifest renders the Kube-Vip DaemonSet manifest to outputFile. Unless configured, the interface is
+// the one on the control plane node whose subnet contains the VIP, or in BGP mode, where the VIP
+// is usually routed from another subnet, the one carrying the node's own address.
+func (k *KubeVipInitializer) GenerateManifest(ctx context.Context, config *models.BootstrapConfig, node, outputFile string) error {
+	k.logger.Info("Generating Kube-Vip manifest...")
+
+	opts := kubevip.OptionsFromConfig(config)
+	if opts.Interface == "" {
+		target := opts.Address
+		if opts.BGP != nil {
+			target = node
+		}
+		iface, err := k.talos.LinkForAddress(ctx, node, target)
+		if err != nil {
+			return fmt.Errorf("failed to detect Kube-Vip interface (set kubeVip.interface to skip detection): %w", err)
+		}
+		k.logger.Info("Detected Kube-Vip interface", zap.String("node", node), zap.String("interface", iface))
+		opts.Interface = iface
+	}
+
+	manifest, err := kubevip.DaemonSet(opts)
+	if err != nil {
+		return err
+	}
+
+	// The manifest carries the BGP peer passwords in BGP mode.
+	if err := os.WriteFile(outputFile, manifest, 0600); err != nil {
+		return fmt.Errorf("failed to write Kube-Vip manifest to file: %w", err)
+	}
+
+	k.logger.Info("Kube-Vip manifest saved", zap.String("file", outputFile))
+	return nil
+}
+
+// ApplyRBAC applies the Kube-Vip RBAC manifest.
+func (k *KubeVipInitializer) ApplyRBAC(ctx context.Context, server string) error {
+	k.logger.Info("Applying Kube-Vip RBAC configuration", zap.String("server", server))
+
+	manifest, err := kubevip.RBAC()
+	if err != nil {
+		return err
+	}
+	if err := k.kube.WithServer(server).Apply(ctx, manifest); err != nil {
+		return fmt.Errorf("failed to apply Kube-Vip RBAC: %w", err)
+	}
+
+	k.logger.Info("Kube-Vip RBAC successfully applied")
+	return nil
+}
+
+// ApplyDaemonSet applies the Kube-Vip DaemonSet manifest GenerateManifest wrote.
+func (k *KubeVipInitializer) ApplyDaemonSet(ctx context.Context, server, manifestPath string) error {
+	k.logger.Info("Applying Kube-Vip DaemonSet",
+		zap.String("server", server),
+		zap.String("manifest", manifestPath),
+	)
+
+	if err := k.kube.WithServer(server).ApplyFile(ctx, manifestPath); err != nil {
+		return fmt.Errorf("failed to apply Kube-Vip DaemonSet: %w", err)
+	}
+
+	k.logger.Info("Kube-Vip successfully applied")
+	return nil
+}
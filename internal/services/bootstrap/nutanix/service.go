@@ -22,12 +22,24 @@ import (
 	"time"
 
 	"github.com/butlerdotdev/butler/internal/mappers"
+	"github.com/butlerdotdev/butler/internal/services/bootstrap/management"
+	"github.com/butlerdotdev/butler/internal/services/capi"
+	"github.com/butlerdotdev/butler/internal/services/cluster"
+	"github.com/butlerdotdev/butler/internal/services/cni"
+	"github.com/butlerdotdev/butler/internal/services/controlplane"
+	"github.com/butlerdotdev/butler/internal/services/gitops"
+	"github.com/butlerdotdev/butler/internal/services/ingress"
+	"github.com/butlerdotdev/butler/internal/services/loadbalancer"
+	"github.com/butlerdotdev/butler/internal/services/machineconfig"
+	"github.com/butlerdotdev/butler/internal/services/network"
+	"github.com/butlerdotdev/butler/internal/services/storage"
+	"github.com/butlerdotdev/butler/internal/services/virtualization"
 	"github.com/butlerdotdev/butler/pkg/adapters/exec"
 	"github.com/butlerdotdev/butler/pkg/adapters/platforms"
-	"github.com/butlerdotdev/butler/pkg/adapters/platforms/docker"
 	"github.com/butlerdotdev/butler/pkg/adapters/platforms/flux"
 	"github.com/butlerdotdev/butler/pkg/adapters/platforms/helm"
 	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubectl"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
 	"github.com/butlerdotdev/butler/pkg/adapters/platforms/talos"
 	"github.com/butlerdotdev/butler/pkg/adapters/providers"
 	"github.com/butlerdotdev/butler/pkg/models"
@@ -37,17 +49,14 @@ import (
 
 // BootstrapService orchestrates provisioning the management cluster.
 type BootstrapService struct {
-	logger            *zap.Logger
-	provider          providers.ProviderInterface
-	provisioner       *Provisioner
-	healthCheck       *HealthChecker
-	talosInit         *TalosInitializer
-	kubeVipInit       *KubeVipInitializer
-	kubeOvnInit       *KubeOvnInitializer
-	fluxInit          *FluxInitializer
-	kubectl           *kubectl.KubectlAdapter
-	kubeConfigManager *KubeConfigManager
-	config            *models.BootstrapConfig
+	logger      *zap.Logger
+	provider    providers.ProviderInterface
+	provisioner *Provisioner
+	healthCheck *HealthChecker
+	talosInit   *TalosInitializer
+	cni         cni.CNIInstaller
+	management  *management.Installer
+	config      *models.BootstrapConfig
 }
 
 // NewBootstrapService initializes BootstrapService using Viper for config.
@@ -67,16 +76,6 @@ func NewBootstrapService(ctx context.Context, config *models.BootstrapConfig, lo
 	// Init Adapters and Type Assertions
 	execAdapter := exec.NewClient(logger)
 
-	dockerAdapter, err := platforms.GetPlatformAdapter("docker", execAdapter, logger)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize Docker adapter: %w", err)
-	}
-	dockerConcrete := dockerAdapter.(*docker.DockerAdapter)
-	dockerConcrete, ok := dockerAdapter.(*docker.DockerAdapter)
-	if !ok {
-		return nil, fmt.Errorf("failed to assert DockerAdapter type")
-	}
-
 	talosAdapter, err := platforms.GetPlatformAdapter("talos", execAdapter, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize Talos adapter: %w", err)
@@ -104,8 +103,6 @@ func NewBootstrapService(ctx context.Context, config *models.BootstrapConfig, lo
 		return nil, fmt.Errorf("failed to assert HelmAdapter type")
 	}
 
-	kubeOvnInit := NewKubeOvnInitializer(kubectlConcrete, helmConcrete, talosConcrete, logger)
-
 	fluxAdapter, err := platforms.GetPlatformAdapter("flux", execAdapter, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize Flux adapter: %w", err)
@@ -115,20 +112,23 @@ func NewBootstrapService(ctx context.Context, config *models.BootstrapConfig, lo
 		return nil, fmt.Errorf("failed to assert FluxAdapter type")
 	}
 
-	kubeConfigManager := NewKubeConfigManager(logger, kubectlConcrete)
+	// The kubeconfig only exists once Talos is bootstrapped; the adapter reads it on first use.
+	kube := kubernetes.NewKubernetesAdapter(cluster.KubeconfigPath(config.ManagementCluster.Talos), logger)
+
+	cniInstaller, err := cni.NewCNIInstaller(config.ManagementCluster.Network.CNI, kube, helmConcrete, talosConcrete, logger)
+	if err != nil {
+		return nil, err
+	}
 
 	return &BootstrapService{
-		logger:            logger,
-		provider:          provider,
-		provisioner:       NewProvisioner(provider, logger),
-		healthCheck:       NewHealthChecker(provider, logger),
-		talosInit:         NewTalosInitializer(talosConcrete, logger),
-		kubeVipInit:       NewKubeVipInitializer(dockerConcrete, kubectlConcrete, logger),
-		fluxInit:          NewFluxInitializer(fluxConcrete, logger),
-		kubectl:           kubectlConcrete,
-		kubeOvnInit:       kubeOvnInit,
-		kubeConfigManager: kubeConfigManager,
-		config:            config,
+		logger:      logger,
+		provider:    provider,
+		provisioner: NewProvisioner(provider, logger),
+		healthCheck: NewHealthChecker(provider, logger),
+		talosInit:   NewTalosInitializer(talosConcrete, cniInstaller, logger),
+		cni:         cniInstaller,
+		management:  management.NewInstaller(kube, kubectlConcrete, helmConcrete, fluxConcrete, cluster.NewTalosOperator(talosConcrete, cluster.TalosconfigPath(config.ManagementCluster.Talos), logger), cniInstaller, logger),
+		config:      config,
 	}, nil
 }
 
@@ -140,6 +140,44 @@ func (b *BootstrapService) ProvisionManagementCluster() error {
 		zap.String("cluster_name", config.ManagementCluster.Name),
 	)
 
+	if err := controlplane.Validate(config.ManagementCluster.Talos); err != nil {
+		return err
+	}
+	if err := machineconfig.Validate(config.ManagementCluster.Nodes, config.ManagementCluster.Provider); err != nil {
+		return err
+	}
+	if err := machineconfig.ValidateImages(config.ManagementCluster.Talos); err != nil {
+		return err
+	}
+	if err := network.Validate(config.ManagementCluster.Network, config.ManagementCluster.Talos.CIDR, config.ManagementCluster.Talos.ControlPlaneVIP); err != nil {
+		return err
+	}
+	if err := gitops.Validate(config.ManagementCluster.Flux); err != nil {
+		return err
+	}
+	clusterNetwork := network.WithDefaults(config.ManagementCluster.Network)
+	if err := loadbalancer.Validate(config.ManagementCluster.LoadBalancer, config.ManagementCluster.Talos.ControlPlaneVIP, clusterNetwork.PodCIDR, clusterNetwork.ServiceCIDR, clusterNetwork.JoinCIDR); err != nil {
+		return err
+	}
+	if err := ingress.Validate(config.ManagementCluster.Ingress, config.ManagementCluster.LoadBalancer); err != nil {
+		return err
+	}
+	if err := storage.ValidateLinstor(config.ManagementCluster.Storage.Linstor, config.ManagementCluster.Provider, config.ManagementCluster.Nodes, config.ManagementCluster.Talos); err != nil {
+		return err
+	}
+	if err := storage.ValidateNutanixCSI(config.ManagementCluster.Storage, config.ManagementCluster.Provider, config.ManagementCluster.Nutanix, config.ManagementCluster.Talos); err != nil {
+		return err
+	}
+	if err := capi.Validate(config.ManagementCluster.ClusterAPI, config.ManagementCluster.Provider); err != nil {
+		return err
+	}
+	if err := virtualization.Validate(config.ManagementCluster.Virtualization, config.ManagementCluster.Network); err != nil {
+		return err
+	}
+	if err := b.cni.Validate(config.ManagementCluster.Network); err != nil {
+		return err
+	}
+
 	// Provision VMs
 	if err := b.provisioner.ProvisionVMs(config); err != nil {
 		return err
@@ -157,90 +195,40 @@ func (b *BootstrapService) ProvisionManagementCluster() error {
 		return err
 	}
 
+	if err := network.Validate(config.ManagementCluster.Network, append(controlPlanes, workers...)...); err != nil {
+		return err
+	}
+	if err := loadbalancer.Validate(config.ManagementCluster.LoadBalancer, append([]string{config.ManagementCluster.Talos.ControlPlaneVIP, clusterNetwork.PodCIDR, clusterNetwork.ServiceCIDR, clusterNetwork.JoinCIDR}, append(controlPlanes, workers...)...)...); err != nil {
+		return err
+	}
+
 	if len(controlPlanes) == 0 {
-		return fmt.Errorf("no available control plane nodes for Kube-Vip setup")
+		return fmt.Errorf("no available control plane nodes")
 	}
 
 	// Talos config
 	talosConfig := models.TalosConfig{
 		ClusterName:          config.ManagementCluster.Name,
 		ControlPlaneEndpoint: config.ManagementCluster.Talos.ControlPlaneEndpoint,
-		OutputDir:            "./talosconfig",
+		ControlPlaneVIP:      config.ManagementCluster.Talos.ControlPlaneVIP,
+		ControlPlaneHA:       config.ManagementCluster.Talos.ControlPlaneHA,
+		OutputDir:            cluster.OutputDir(config.ManagementCluster.Talos),
 		ControlPlaneNodes:    controlPlanes,
 		WorkerNodes:          workers,
+		Version:              config.ManagementCluster.Talos.Version,
+		Extensions:           config.ManagementCluster.Talos.Extensions,
+		KernelArgs:           config.ManagementCluster.Talos.KernelArgs,
+		ImageFactoryURL:      config.ManagementCluster.Talos.ImageFactoryURL,
 	}
 
-	if err := b.talosInit.ConfigureTalos(context.Background(), &talosConfig, true); err != nil {
+	if err := b.talosInit.ConfigureTalos(context.Background(), &talosConfig, config.ManagementCluster.Nodes, clusterNetwork, true); err != nil {
 		return fmt.Errorf("failed to configure Talos: %w", err)
 	}
 
-	// Validate kubeconfig
-	kubeconfigPath := "talosconfig/kubeconfig"
-	if err := b.kubeConfigManager.ValidateKubeConfig(kubeconfigPath); err != nil {
-		return fmt.Errorf("kubeconfig validation failed: %w", err)
-	}
-	if err := b.kubeConfigManager.EnsureCorrectContext(kubeconfigPath, config.ManagementCluster.Name); err != nil {
-		return fmt.Errorf("failed to set kubeconfig context: %w", err)
-	}
-	if err := b.kubeConfigManager.WaitForKubernetesAPI(kubeconfigPath, controlPlanes[0], 5*time.Minute); err != nil {
-		return fmt.Errorf("kubernetes API not ready: %w", err)
-	}
-
-	// Kube-Vip
-	server := fmt.Sprintf("https://%s:6443", controlPlanes[0])
-	config.ManagementCluster.Talos.BoundNodeIP = controlPlanes[0]
-	b.logger.Info("Applying Kube-Vip RBAC configuration",
-		zap.String("server", server),
-		zap.String("manifest", "https://kube-vip.io/manifests/rbac.yaml"),
-	)
-
-	// Wait until at least one node registers
-	if err := b.kubeOvnInit.WaitForNodes(context.Background(), server, 2*time.Minute); err != nil {
-		return fmt.Errorf("failed waiting for nodes to register: %w", err)
-	}
-
-	// Gather node IP-to-name mapping prior to VIP being associated - This allows for reduction in VIP vs Bound IP Logic later when mapping.
-	ipToNodeMap, err := b.kubeOvnInit.getInternalIPToNodeNameMap(context.Background(), server)
-	if err != nil {
-		return fmt.Errorf("failed to collect node IP-to-name map: %w", err)
-	}
-
-	// KubeVip configuration
-	if err := b.kubeVipInit.ConfigureKubeVip(context.Background(), config, server); err != nil {
-		return fmt.Errorf("failed to configure Kube-Vip: %w", err)
-	}
-
-	// Check if the VIP is ready
-	if err := b.kubeConfigManager.WaitForKubernetesAPI(kubeconfigPath, config.ManagementCluster.Talos.ControlPlaneVIP, 2*time.Minute); err != nil {
-		return fmt.Errorf("VIP-based Kubernetes API not ready: %w", err)
-	}
-
-	// Label nodes for kube-ovn
-	if err := b.kubeOvnInit.LabelNodes(context.Background(), config, controlPlanes, workers, ipToNodeMap); err != nil {
-		return fmt.Errorf("failed to label nodes for Kube-OVN: %w", err)
-	}
-
-	// Helm Install kube-ovn with templated values
-	if err := b.kubeOvnInit.ConfigureKubeOvn(context.Background(), controlPlanes, config); err != nil {
-		return fmt.Errorf("failed to install Kube-OVN: %w", err)
+	if err := b.management.Install(context.Background(), config, controlPlanes, workers); err != nil {
+		return err
 	}
 
-	// TODO: Add a check to ensure that the Kube-OVN pods are running before proceeding.
-
-	// TODO: Piraeus Operator(Linstor) - V2 of this operator does not have a helm chart that they suggest to use. They have one for V1, but their docs show to use the V2 operator.
-	// We will install this similarly to kube ovn, Outside of the flux process. BUT, we can still use flux to manage parts of linstor.
-
-	// Bootstrap Flux
-	// if err := b.fluxInit.FluxBootstrap(context.Background(), config); err != nil {
-	// 	return fmt.Errorf("failed to bootstrap Flux: %w", err)
-	// }
-
-	// After Flux is bootstrapped the following should be provisioned Via Flux:
-	// MetalLB
-	// Traefik
-	// CAPI
-
-	b.logger.Info("Flux bootstrap completed successfully")
 	b.logger.Info("Management cluster provisioned successfully")
 	return nil
 }
@@ -18,10 +18,17 @@ package bootstrap
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/butlerdotdev/butler/internal/services/cni"
+	"github.com/butlerdotdev/butler/internal/services/controlplane"
+	"github.com/butlerdotdev/butler/internal/services/machineconfig"
 	"github.com/butlerdotdev/butler/pkg/adapters/platforms/talos"
+	talosModels "github.com/butlerdotdev/butler/pkg/adapters/platforms/talos/models"
 	"github.com/butlerdotdev/butler/pkg/models"
 
 	"go.uber.org/zap"
@@ -30,20 +37,23 @@ import (
 // TalosInitializer handles configuring Talos on provisioned VMs.
 type TalosInitializer struct {
 	talosAdapter *talos.TalosAdapter
+	cni          cni.CNIInstaller
 	logger       *zap.Logger
 }
 
-// NewTalosInitializer creates a new Talos initializer.
-func NewTalosInitializer(talosAdapter *talos.TalosAdapter, logger *zap.Logger) *TalosInitializer {
-	return &TalosInitializer{talosAdapter: talosAdapter, logger: logger}
+// NewTalosInitializer creates a new Talos initializer. The CNI installer contributes the machine
+// config its network needs.
+func NewTalosInitializer(talosAdapter *talos.TalosAdapter, cniInstaller cni.CNIInstaller, logger *zap.Logger) *TalosInitializer {
+	return &TalosInitializer{talosAdapter: talosAdapter, cni: cniInstaller, logger: logger}
 }
 
-// ConfigureTalos sets up Talos on the cluster nodes.
-func (t *TalosInitializer) ConfigureTalos(ctx context.Context, config *models.TalosConfig, insecure bool) error {
+// ConfigureTalos sets up Talos on the cluster nodes. The node pools supply the per-role disk layout
+// and the network settings the pod and service subnets.
+func (t *TalosInitializer) ConfigureTalos(ctx context.Context, config *models.TalosConfig, nodes []models.NodeConfig, network models.NetworkConfig, insecure bool) error {
 	t.logger.Info("Starting Talos setup", zap.String("cluster", config.ClusterName))
 
 	// Generate Talos Configuration
-	if err := t.GenerateConfig(ctx, config); err != nil {
+	if err := t.GenerateConfig(ctx, config, nodes, network); err != nil {
 		return fmt.Errorf("failed to generate Talos config: %w", err)
 	}
 
@@ -66,17 +76,17 @@ func (t *TalosInitializer) ConfigureTalos(ctx context.Context, config *models.Ta
 
 	// Set Talos Endpoint
 	controlPlaneNode := config.ControlPlaneNodes[0]
-	if err := t.SetEndpoint(ctx, controlPlaneNode); err != nil {
+	if err := t.SetEndpoint(ctx, controlPlaneNode, config.OutputDir); err != nil {
 		return fmt.Errorf("failed to configure Talos endpoint: %w", err)
 	}
 
 	// Bootstrap Talos on the First Control Plane Node
-	if err := t.BootstrapControlPlane(ctx, controlPlaneNode); err != nil {
+	if err := t.BootstrapControlPlane(ctx, controlPlaneNode, config.OutputDir); err != nil {
 		return fmt.Errorf("failed to bootstrap Talos on control plane: %w", err)
 	}
 
 	// Retrieve KubeConfig
-	if err := t.RetrieveKubeConfig(ctx, controlPlaneNode); err != nil {
+	if err := t.RetrieveKubeConfig(ctx, controlPlaneNode, config.OutputDir); err != nil {
 		return fmt.Errorf("failed to retrieve kubeconfig: %w", err)
 	}
 
@@ -85,14 +95,15 @@ func (t *TalosInitializer) ConfigureTalos(ctx context.Context, config *models.Ta
 }
 
 // GenerateConfig generates Talos configuration files.
-func (t *TalosInitializer) GenerateConfig(ctx context.Context, config *models.TalosConfig) error {
+func (t *TalosInitializer) GenerateConfig(ctx context.Context, config *models.TalosConfig, nodes []models.NodeConfig, network models.NetworkConfig) error {
 	t.logger.Info("Generating Talos configuration",
 		zap.String("cluster", config.ClusterName),
-		zap.String("endpoint", config.ControlPlaneEndpoint),
+		zap.String("endpoint", controlplane.Endpoint(*config)),
+		zap.String("controlPlaneHA", controlplane.Mode(*config)),
 	)
 
-	_, err := t.talosAdapter.ExecuteCommand(ctx,
-		"gen", "config", config.ClusterName, fmt.Sprintf("https://%s", config.ControlPlaneEndpoint),
+	args := []string{
+		"gen", "config", config.ClusterName, fmt.Sprintf("https://%s", controlplane.Endpoint(*config)),
 		"--output", config.OutputDir,
 		"--config-patch", `[
 	{
@@ -104,9 +115,7 @@ func (t *TalosInitializer) GenerateConfig(ctx context.Context, config *models.Ta
 		"op": "add",
 		"path": "/machine/kernel",
 		"value": {
-			"modules": [
-				{ "name": "openvswitch" }
-			]
+			"modules": []
 		}
 	},
 	{
@@ -114,44 +123,82 @@ func (t *TalosInitializer) GenerateConfig(ctx context.Context, config *models.Ta
 		"path": "/cluster/network/cni",
 		"value": { "name": "none" }
 	},
-	{
-		"op": "replace",
-		"path": "/cluster/network/podSubnets",
-		"value": [ "10.16.0.0/16" ]
-	},
 	{
 		"op": "add",
 		"path": "/machine/kubelet/extraMounts",
-		"value": [
-			{
-				"source": "/run/openvswitch",
-				"destination": "/run/openvswitch",
-				"type": "bind",
-				"options": ["rbind", "rw"]
-			},
-			{
-				"source": "/run/ovn",
-				"destination": "/run/ovn",
-				"type": "bind",
-				"options": ["rbind", "rw"]
-			},
-			{
-				"source": "/var/log/openvswitch",
-				"destination": "/var/log/openvswitch",
-				"type": "bind",
-				"options": ["rbind", "rw"]
-			},
-			{
-				"source": "/var/log/ovn",
-				"destination": "/var/log/ovn",
-				"type": "bind",
-				"options": ["rbind", "rw"]
-			}
-		]
+		"value": []
 	}
 ]`,
+	}
+
+	networkPatch, err := machineconfig.ClusterNetwork(network.PodCIDR, network.ServiceCIDR).String()
+	if err != nil {
+		return err
+	}
+	args = append(args, "--config-patch", networkPatch)
+
+	// The CNI patch extends the base patch, e.g. appends kernel modules and kubelet mounts.
+	if cniPatch := t.cni.TalosPatch(network); len(cniPatch) > 0 {
+		patch, err := cniPatch.String()
+		if err != nil {
+			return err
+		}
+		args = append(args, "--config-patch", patch)
+	}
+
+	// Install disk selection and extra disk layout differ per node pool, so patch each role separately.
+	roleArgs, err := machineconfig.RolePatchArgs(nodes)
+	if err != nil {
+		return err
+	}
+	args = append(args, roleArgs...)
+
+	// With the Talos shared VIP the control plane nodes announce the VIP themselves.
+	if controlplane.Mode(*config) == controlplane.ModeTalosVIP {
+		vipPatch, err := machineconfig.SharedVIP(config.ControlPlaneVIP, config.ControlPlaneHA.Interface).String()
+		if err != nil {
+			return err
+		}
+		args = append(args, "--config-patch-control-plane", vipPatch)
+	}
+
+	// Pin the installer image so nodes install (and later upgrade) with the configured extensions.
+	refs, err := machineconfig.ResolveImages(ctx, *config, config.Version, t.logger)
+	if err != nil {
+		return err
+	}
+	installPatch, err := machineconfig.InstallImage(refs.InstallerImage).String()
+	if err != nil {
+		return err
+	}
+	args = append(args, "--config-patch", installPatch)
+
+	if _, err := t.talosAdapter.ExecuteCommand(ctx, args...); err != nil {
+		return err
+	}
+
+	t.writeImageReferences(config.OutputDir, refs)
+	return nil
+}
+
+// writeImageReferences records the installer image and ISO URL next to the generated configs so
+// operators know which ISO to upload for new nodes.
+func (t *TalosInitializer) writeImageReferences(outputDir string, refs talosModels.ImageReferences) {
+	data, err := json.MarshalIndent(refs, "", "  ")
+	if err != nil {
+		t.logger.Warn("Failed to marshal Talos image references", zap.Error(err))
+		return
+	}
+	path := filepath.Join(outputDir, "images.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.logger.Warn("Failed to write Talos image references", zap.String("file", path), zap.Error(err))
+		return
+	}
+	t.logger.Info("Talos image references saved",
+		zap.String("file", path),
+		zap.String("installerImage", refs.InstallerImage),
+		zap.String("isoURL", refs.ISOURL),
 	)
-	return err
 }
 
 // ApplyConfig applies the Talos configuration to a node.
@@ -161,8 +208,8 @@ func (t *TalosInitializer) ApplyConfig(ctx context.Context, node, configDir, con
 	args := []string{
 		"apply-config",
 		"--nodes", node,
-		"--file", fmt.Sprintf("%s/%s", configDir, configFile),
-		"--talosconfig", "talosconfig/talosconfig",
+		"--file", filepath.Join(configDir, configFile),
+		"--talosconfig", filepath.Join(configDir, "talosconfig"),
 	}
 	if insecure {
 		args = append(args, "--insecure")
@@ -179,34 +226,34 @@ func (t *TalosInitializer) WaitForNodesToRegister() {
 	time.Sleep(waitTime)
 }
 
-// SetEndpoint configures the Talos endpoint.
-func (t *TalosInitializer) SetEndpoint(ctx context.Context, node string) error {
+// SetEndpoint configures the Talos endpoint in the talosconfig in configDir.
+func (t *TalosInitializer) SetEndpoint(ctx context.Context, node, configDir string) error {
 	t.logger.Info("Configuring Talos endpoint", zap.String("node", node))
 
 	_, err := t.talosAdapter.ExecuteCommand(ctx,
-		"config", "endpoint", node, "--talosconfig", "talosconfig/talosconfig",
+		"config", "endpoint", node, "--talosconfig", filepath.Join(configDir, "talosconfig"),
 	)
 	return err
 }
 
 // BootstrapControlPlane bootstraps Talos on a control-plane node.
-func (t *TalosInitializer) BootstrapControlPlane(ctx context.Context, node string) error {
+func (t *TalosInitializer) BootstrapControlPlane(ctx context.Context, node, configDir string) error {
 	t.logger.Info("Bootstrapping Talos control plane", zap.String("node", node))
 
 	_, err := t.talosAdapter.ExecuteCommand(ctx,
-		"bootstrap", "--nodes", node, "--talosconfig", "talosconfig/talosconfig",
+		"bootstrap", "--nodes", node, "--talosconfig", filepath.Join(configDir, "talosconfig"),
 	)
 	return err
 }
 
-// RetrieveKubeConfig fetches and stores the Kubernetes kubeconfig.
-func (t *TalosInitializer) RetrieveKubeConfig(ctx context.Context, node string) error {
+// RetrieveKubeConfig fetches the Kubernetes kubeconfig and stores it in configDir.
+func (t *TalosInitializer) RetrieveKubeConfig(ctx context.Context, node, configDir string) error {
 	t.logger.Info("Retrieving kubeconfig from Talos", zap.String("node", node))
 
 	_, err := t.talosAdapter.ExecuteCommand(ctx,
-		"kubeconfig", "talosconfig/kubeconfig",
+		"kubeconfig", filepath.Join(configDir, "kubeconfig"),
 		"--nodes", node,
-		"--talosconfig", "talosconfig/talosconfig",
+		"--talosconfig", filepath.Join(configDir, "talosconfig"),
 		"--force",
 		"--merge",
 	)
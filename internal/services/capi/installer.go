@@ -0,0 +1,173 @@
+// Package capi installs Cluster API, its providers and Kamaji so the management cluster can host tenant clusters.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/readiness"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/helm"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+)
+
+// Installer installs cert-manager, Cluster API with its bootstrap and infrastructure providers,
+// Kamaji and the Kamaji control plane provider.
+type Installer struct {
+	kube   *kubernetes.KubernetesAdapter
+	helm   *helm.HelmAdapter
+	logger *zap.Logger
+}
+
+// NewInstaller constructs a new Installer instance.
+func NewInstaller(kube *kubernetes.KubernetesAdapter, helm *helm.HelmAdapter, logger *zap.Logger) *Installer {
+	return &Installer{
+		kube:   kube,
+		helm:   helm,
+		logger: logger,
+	}
+}
+
+// Install installs every component at its configured version, in dependency order, and waits
+// until their controllers and webhooks are ready. Rerunning it upgrades components in place.
+func (i *Installer) Install(ctx context.Context, server string, mc models.ManagementClusterConfig) error {
+	config := mc.ClusterAPI
+	kube := i.kube.WithServer(server)
+	vars := Variables(config, mc)
+
+	coreVersion := coreProvider.version(config.Version)
+	bootstrap := bootstrapProviders[BootstrapProvider(config)]
+	bootstrapVersion := bootstrap.version(config.BootstrapProviderVersion)
+	if bootstrapVersion == "" {
+		// kubeadm is released together with the core provider.
+		bootstrapVersion = coreVersion
+	}
+	infrastructure := infrastructureProviders[InfrastructureProvider(config, mc.Provider)]
+
+	// cert-manager issues the providers' webhook certificates, so it must serve first.
+	if err := i.installComponent(ctx, kube, certManager, config.CertManagerVersion, nil); err != nil {
+		return err
+	}
+	if err := waitForWebhooks(ctx, kube, []string{certManager.namespace}, 5*time.Minute, i.logger); err != nil {
+		return fmt.Errorf("cert-manager did not become ready: %w", err)
+	}
+
+	for _, step := range []struct {
+		component component
+		version   string
+	}{
+		{coreProvider, coreVersion},
+		{bootstrap, bootstrapVersion},
+		{infrastructure, infrastructure.version(config.ProviderVersion)},
+	} {
+		if err := i.installComponent(ctx, kube, step.component, step.version, vars); err != nil {
+			return err
+		}
+	}
+
+	if err := i.installKamaji(ctx, kube, config); err != nil {
+		return err
+	}
+	if err := i.installComponent(ctx, kube, kamajiControlPlane, config.ControlPlaneProviderVersion, vars); err != nil {
+		return err
+	}
+
+	namespaces := []string{
+		coreProvider.namespace,
+		bootstrap.namespace,
+		infrastructure.namespace,
+		kamajiNamespace,
+		kamajiControlPlane.namespace,
+	}
+	if err := waitForWebhooks(ctx, kube, namespaces, 5*time.Minute, i.logger); err != nil {
+		return fmt.Errorf("Cluster API did not become ready: %w", err)
+	}
+
+	i.logger.Info("Cluster API installed successfully",
+		zap.String("core", coreVersion),
+		zap.String("bootstrap", bootstrap.name),
+		zap.String("infrastructure", infrastructure.name),
+		zap.String("controlPlane", kamajiControlPlane.name),
+	)
+	return nil
+}
+
+// installComponent downloads a component's release manifest, substitutes vars when set, applies
+// it and waits for its controller to roll out.
+func (i *Installer) installComponent(ctx context.Context, kube *kubernetes.KubernetesAdapter, c component, version string, vars map[string]string) error {
+	version = c.version(version)
+	i.logger.Info("Installing Cluster API component", zap.String("component", c.name), zap.String("version", version))
+
+	manifest, err := kubernetes.DownloadManifest(ctx, fmt.Sprintf(c.url, version))
+	if err != nil {
+		return fmt.Errorf("failed to download %s %s: %w", c.name, version, err)
+	}
+	if vars != nil {
+		if manifest, err = substitute(manifest, vars); err != nil {
+			return fmt.Errorf("failed to render %s %s: %w", c.name, version, err)
+		}
+	}
+	if err := kube.Apply(ctx, manifest); err != nil {
+		return fmt.Errorf("failed to apply %s %s: %w", c.name, version, err)
+	}
+
+	if err := readiness.NewWaiter(kube, i.logger).Wait(ctx,
+		readiness.Deployment(c.namespace, c.deployment).WithTimeout(5*time.Minute),
+	); err != nil {
+		return fmt.Errorf("%s did not become ready: %w", c.name, err)
+	}
+	return nil
+}
+
+// installKamaji installs or upgrades the Kamaji Helm chart, which runs tenant control planes.
+func (i *Installer) installKamaji(ctx context.Context, kube *kubernetes.KubernetesAdapter, config models.ClusterAPI) error {
+	version := config.KamajiVersion
+	if version == "" {
+		version = DefaultKamajiVersion
+	}
+	var values []map[string]interface{}
+	if len(config.KamajiValues) > 0 {
+		values = append(values, config.KamajiValues)
+	}
+
+	i.logger.Info("Installing Kamaji via Helm", zap.String("version", version))
+	if _, err := i.helm.UpgradeInstall(ctx, "kamaji", helm.Chart{
+		Name:    "kamaji",
+		Repo:    kamajiChartRepo,
+		Version: version,
+	}, helm.ReleaseOptions{
+		Namespace:       kamajiNamespace,
+		Kubeconfig:      kube.Kubeconfig(),
+		CreateNamespace: true,
+		Values:          values,
+		Timeout:         10 * time.Minute,
+	}); err != nil {
+		return fmt.Errorf("failed to install Kamaji: %w", err)
+	}
+
+	if err := readiness.NewWaiter(kube, i.logger).Wait(ctx,
+		readiness.CRD("tenantcontrolplanes.kamaji.clastix.io"),
+		readiness.Deployment(kamajiNamespace, kamajiDeployment).WithTimeout(5*time.Minute),
+	); err != nil {
+		return fmt.Errorf("Kamaji did not become ready: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+// Package capi installs Cluster API, its providers and Kamaji so the management cluster can host tenant clusters.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capi
+
+import (
+	"fmt"
+
+	"github.com/butlerdotdev/butler/pkg/models"
+)
+
+// Supported providers.
+const (
+	InfrastructureNutanix = "nutanix"
+	InfrastructureProxmox = "proxmox"
+
+	BootstrapKubeadm = "kubeadm"
+
+	ControlPlaneKamaji = "kamaji"
+)
+
+// Versions Butler is tested with.
+const (
+	DefaultVersion             = "v1.9.4"
+	DefaultCertManagerVersion  = "v1.16.3"
+	DefaultKamajiVersion       = "1.0.0"
+	DefaultControlPlaneVersion = "v0.14.0"
+)
+
+// component is one set of release manifests, installed like clusterctl installs a provider.
+type component struct {
+	name string
+	// url is the release manifest, formatted with the version.
+	url            string
+	defaultVersion string
+	// namespace and deployment locate the controller the component is ready with.
+	namespace  string
+	deployment string
+}
+
+var certManager = component{
+	name:           "cert-manager",
+	url:            "https://github.com/cert-manager/cert-manager/releases/download/%s/cert-manager.yaml",
+	defaultVersion: DefaultCertManagerVersion,
+	namespace:      "cert-manager",
+	deployment:     "cert-manager-webhook",
+}
+
+var coreProvider = component{
+	name:           "cluster-api",
+	url:            "https://github.com/kubernetes-sigs/cluster-api/releases/download/%s/core-components.yaml",
+	defaultVersion: DefaultVersion,
+	namespace:      "capi-system",
+	deployment:     "capi-controller-manager",
+}
+
+// bootstrapProviders default to the core version for kubeadm, which is released with Cluster API.
+// Workers joining a Kamaji control plane need kubeadm: Kamaji publishes a kubeadm join token and
+// CA, not the Talos cluster secrets a Talos worker config is generated from.
+var bootstrapProviders = map[string]component{
+	BootstrapKubeadm: {
+		name:       "bootstrap-kubeadm",
+		url:        "https://github.com/kubernetes-sigs/cluster-api/releases/download/%s/bootstrap-components.yaml",
+		namespace:  "capi-kubeadm-bootstrap-system",
+		deployment: "capi-kubeadm-bootstrap-controller-manager",
+	},
+}
+
+var infrastructureProviders = map[string]component{
+	InfrastructureNutanix: {
+		name:           "infrastructure-nutanix",
+		url:            "https://github.com/nutanix-cloud-native/cluster-api-provider-nutanix/releases/download/%s/infrastructure-components.yaml",
+		defaultVersion: "v1.5.3",
+		namespace:      "capx-system",
+		deployment:     "capx-controller-manager",
+	},
+	InfrastructureProxmox: {
+		name:           "infrastructure-proxmox",
+		url:            "https://github.com/ionos-cloud/cluster-api-provider-proxmox/releases/download/%s/infrastructure-components.yaml",
+		defaultVersion: "v0.6.2",
+		namespace:      "capmox-system",
+		deployment:     "capmox-controller-manager",
+	},
+}
+
+var kamajiControlPlane = component{
+	name:           "control-plane-kamaji",
+	url:            "https://github.com/clastix/cluster-api-control-plane-provider-kamaji/releases/download/%s/control-plane-components.yaml",
+	defaultVersion: DefaultControlPlaneVersion,
+	namespace:      "kamaji-system",
+	deployment:     "capi-kamaji-controller-manager",
+}
+
+// Kamaji itself is installed from its Helm chart.
+const (
+	kamajiNamespace  = "kamaji-system"
+	kamajiChartRepo  = "https://clastix.github.io/charts"
+	kamajiDeployment = "kamaji"
+)
+
+// Validate checks the Cluster API settings for the management cluster's provider.
+func Validate(config models.ClusterAPI, provider string) error {
+	if !config.Enabled {
+		return nil
+	}
+	if _, ok := infrastructureProviders[InfrastructureProvider(config, provider)]; !ok {
+		return fmt.Errorf("unsupported clusterAPI.provider %q; expected %s or %s", InfrastructureProvider(config, provider), InfrastructureNutanix, InfrastructureProxmox)
+	}
+	if _, ok := bootstrapProviders[BootstrapProvider(config)]; !ok {
+		return fmt.Errorf("unsupported clusterAPI.bootstrapProvider %q; Kamaji control planes are joined with %s", config.BootstrapProvider, BootstrapKubeadm)
+	}
+	if config.ControlPlaneProvider != "" && config.ControlPlaneProvider != ControlPlaneKamaji {
+		return fmt.Errorf("unsupported clusterAPI.controlPlaneProvider %q; Butler runs tenant control planes with %s", config.ControlPlaneProvider, ControlPlaneKamaji)
+	}
+	return nil
+}
+
+// InfrastructureProvider returns the configured infrastructure provider, defaulting to the
+// management cluster's own provider.
+func InfrastructureProvider(config models.ClusterAPI, provider string) string {
+	if config.Provider == "" {
+		return provider
+	}
+	return config.Provider
+}
+
+// BootstrapProvider returns the configured bootstrap provider, defaulting to kubeadm.
+func BootstrapProvider(config models.ClusterAPI) string {
+	if config.BootstrapProvider == "" {
+		return BootstrapKubeadm
+	}
+	return config.BootstrapProvider
+}
+
+// version returns configured, or the component's default version.
+func (c component) version(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	return c.defaultVersion
+}
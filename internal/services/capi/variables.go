@@ -0,0 +1,90 @@
+// Package capi installs Cluster API, its providers and Kamaji so the management cluster can host tenant clusters.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capi
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/butlerdotdev/butler/pkg/models"
+)
+
+// variablePattern matches clusterctl variables: ${NAME}, optionally with a :=, :-, = or - default.
+var variablePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?:(:?[-=])([^}]*))?\}`)
+
+// Variables returns the values substituted into provider manifests. Configured variables take
+// precedence over those derived from the management cluster's provider settings. Keys are
+// upper-cased since the configuration loader lower-cases map keys.
+func Variables(config models.ClusterAPI, mc models.ManagementClusterConfig) map[string]string {
+	vars := map[string]string{}
+
+	switch InfrastructureProvider(config, mc.Provider) {
+	case InfrastructureNutanix:
+		if u, err := url.Parse(mc.Nutanix.Endpoint); err == nil && u.Host != "" {
+			vars["NUTANIX_ENDPOINT"] = u.Hostname()
+			if port := u.Port(); port != "" {
+				vars["NUTANIX_PORT"] = port
+			}
+		}
+		vars["NUTANIX_USER"] = mc.Nutanix.Username
+		vars["NUTANIX_PASSWORD"] = mc.Nutanix.Password
+	case InfrastructureProxmox:
+		vars["PROXMOX_URL"] = mc.Proxmox.Endpoint
+	}
+
+	for key, value := range config.Variables {
+		vars[strings.ToUpper(key)] = value
+	}
+	return vars
+}
+
+// substitute replaces the clusterctl variables in a manifest. Variables that are neither set in
+// vars nor in the environment take their default; the names of those without one are reported.
+func substitute(manifest []byte, vars map[string]string) ([]byte, error) {
+	missing := map[string]bool{}
+	out := variablePattern.ReplaceAllFunc(manifest, func(match []byte) []byte {
+		groups := variablePattern.FindSubmatch(match)
+		name, operator, fallback := string(groups[1]), string(groups[2]), string(groups[3])
+
+		value, ok := vars[name]
+		if !ok {
+			value, ok = os.LookupEnv(name)
+		}
+		if ok && (value != "" || !strings.HasPrefix(operator, ":")) {
+			return []byte(value)
+		}
+		if operator != "" {
+			return []byte(fallback)
+		}
+		missing[name] = true
+		return match
+	})
+
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("missing variables %s; set them in clusterAPI.variables", strings.Join(names, ", "))
+	}
+	return out, nil
+}
@@ -0,0 +1,146 @@
+// Package capi installs Cluster API, its providers and Kamaji so the management cluster can host tenant clusters.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// webhook is an admission or conversion webhook served from one of the installed namespaces.
+type webhook struct {
+	owner     string
+	namespace string
+	service   string
+	caBundle  bool
+}
+
+// waitForWebhooks waits until every admission and CRD conversion webhook served from namespaces
+// has its CA bundle injected by cert-manager and a ready endpoint behind its Service.
+func waitForWebhooks(ctx context.Context, kube *kubernetes.KubernetesAdapter, namespaces []string, timeout time.Duration, logger *zap.Logger) error {
+	served := make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		served[namespace] = true
+	}
+
+	var pending []string
+	deadline := time.Now().Add(timeout)
+	for {
+		webhooks, err := listWebhooks(ctx, kube, served)
+		if err != nil {
+			return err
+		}
+
+		pending = pending[:0]
+		for _, wh := range webhooks {
+			if !wh.caBundle {
+				pending = append(pending, fmt.Sprintf("%s (CA bundle not injected)", wh.owner))
+				continue
+			}
+			ready, err := hasReadyEndpoints(ctx, kube, wh.namespace, wh.service)
+			if err != nil {
+				return err
+			}
+			if !ready {
+				pending = append(pending, fmt.Sprintf("%s (no ready endpoints for Service %s/%s)", wh.owner, wh.namespace, wh.service))
+			}
+		}
+		if len(pending) == 0 {
+			logger.Info("Cluster API webhooks are ready", zap.Int("webhooks", len(webhooks)))
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("webhooks not ready after %s: %s", timeout, strings.Join(pending, "; "))
+		}
+		logger.Debug("Waiting for webhooks", zap.Strings("pending", pending))
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// listWebhooks returns the admission and conversion webhooks whose Service is in a served namespace.
+func listWebhooks(ctx context.Context, kube *kubernetes.KubernetesAdapter, served map[string]bool) ([]webhook, error) {
+	var webhooks []webhook
+
+	for _, kind := range []string{"ValidatingWebhookConfiguration", "MutatingWebhookConfiguration"} {
+		configs, err := kube.List(ctx, "admissionregistration.k8s.io/v1", kind, "", "")
+		if err != nil {
+			return nil, err
+		}
+		for _, config := range configs {
+			entries, _, _ := unstructured.NestedSlice(config.Object, "webhooks")
+			for _, entry := range entries {
+				fields, ok := entry.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if wh, ok := serviceWebhook(fields, fmt.Sprintf("%s %s", kind, config.GetName()), served, "clientConfig"); ok {
+					webhooks = append(webhooks, wh)
+				}
+			}
+		}
+	}
+
+	crds, err := kube.List(ctx, "apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "")
+	if err != nil {
+		return nil, err
+	}
+	for _, crd := range crds {
+		if strategy, _, _ := unstructured.NestedString(crd.Object, "spec", "conversion", "strategy"); strategy != "Webhook" {
+			continue
+		}
+		if wh, ok := serviceWebhook(crd.Object, "CRD "+crd.GetName(), served, "spec", "conversion", "webhook", "clientConfig"); ok {
+			webhooks = append(webhooks, wh)
+		}
+	}
+	return webhooks, nil
+}
+
+// serviceWebhook reads the webhook clientConfig at path, if it points at a served namespace.
+func serviceWebhook(obj map[string]interface{}, owner string, served map[string]bool, path ...string) (webhook, bool) {
+	namespace, _, _ := unstructured.NestedString(obj, append(path, "service", "namespace")...)
+	if !served[namespace] {
+		return webhook{}, false
+	}
+	service, _, _ := unstructured.NestedString(obj, append(path, "service", "name")...)
+	caBundle, _, _ := unstructured.NestedString(obj, append(path, "caBundle")...)
+	return webhook{owner: owner, namespace: namespace, service: service, caBundle: caBundle != ""}, true
+}
+
+// hasReadyEndpoints reports whether a Service has at least one ready address.
+func hasReadyEndpoints(ctx context.Context, kube *kubernetes.KubernetesAdapter, namespace, name string) (bool, error) {
+	endpoints, err := kube.Get(ctx, "v1", "Endpoints", namespace, name)
+	if err != nil {
+		// The Endpoints object appears with the Service's first pod.
+		return false, nil
+	}
+	subsets, _, _ := unstructured.NestedSlice(endpoints.Object, "subsets")
+	for _, subset := range subsets {
+		if fields, ok := subset.(map[string]interface{}); ok {
+			if addresses, ok := fields["addresses"].([]interface{}); ok && len(addresses) > 0 {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
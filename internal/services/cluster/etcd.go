@@ -0,0 +1,236 @@
+// Package cluster provides day-2 operations against a running Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// EtcdMember is a single etcd member as reported by `talosctl etcd members`.
+type EtcdMember struct {
+	ID       string
+	Hostname string
+	Learner  bool
+}
+
+// EtcdMembers returns the etcd members as seen from the given control plane node.
+func (t *TalosOperator) EtcdMembers(ctx context.Context, node string) ([]EtcdMember, error) {
+	out, err := t.talos.ExecuteCommand(ctx,
+		"etcd", "members",
+		"--nodes", node,
+		"--talosconfig", t.talosconfig,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list etcd members from node %s: %w", node, err)
+	}
+
+	// Columns: NODE ID HOSTNAME PEER URLS CLIENT URLS LEARNER
+	var members []EtcdMember
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 || fields[0] == "NODE" {
+			continue
+		}
+		members = append(members, EtcdMember{
+			ID:       fields[1],
+			Hostname: fields[2],
+			Learner:  fields[len(fields)-1] == "true",
+		})
+	}
+	return members, nil
+}
+
+// EtcdHealthy reports whether etcd answers on the given control plane node.
+func (t *TalosOperator) EtcdHealthy(ctx context.Context, node string) error {
+	if _, err := t.talos.ExecuteCommand(ctx,
+		"etcd", "status",
+		"--nodes", node,
+		"--talosconfig", t.talosconfig,
+	); err != nil {
+		return fmt.Errorf("etcd is not healthy on node %s: %w", node, err)
+	}
+	return nil
+}
+
+// WaitForEtcdQuorum waits until etcd answers on every control plane node and the member list
+// contains one voting member per control plane node.
+func (t *TalosOperator) WaitForEtcdQuorum(ctx context.Context, controlPlanes []string, timeout time.Duration) error {
+	if len(controlPlanes) == 0 {
+		return fmt.Errorf("no control plane nodes to check etcd quorum against")
+	}
+	t.logger.Info("Waiting for etcd quorum", zap.Strings("controlPlanes", controlPlanes))
+
+	deadline := time.Now().Add(timeout)
+	var lastErr error
+	for time.Now().Before(deadline) {
+		lastErr = t.checkEtcdQuorum(ctx, controlPlanes)
+		if lastErr == nil {
+			t.logger.Info("etcd quorum is healthy", zap.Int("members", len(controlPlanes)))
+			return nil
+		}
+		t.logger.Warn("etcd quorum not healthy yet, retrying...", zap.Error(lastErr))
+		time.Sleep(10 * time.Second)
+	}
+
+	return fmt.Errorf("timed out waiting for etcd quorum: %w", lastErr)
+}
+
+// checkEtcdQuorum performs a single etcd health and membership check.
+func (t *TalosOperator) checkEtcdQuorum(ctx context.Context, controlPlanes []string) error {
+	for _, node := range controlPlanes {
+		if err := t.EtcdHealthy(ctx, node); err != nil {
+			return err
+		}
+	}
+
+	members, err := t.EtcdMembers(ctx, controlPlanes[0])
+	if err != nil {
+		return err
+	}
+
+	voting := 0
+	for _, member := range members {
+		if !member.Learner {
+			voting++
+		}
+	}
+	if voting != len(controlPlanes) {
+		return fmt.Errorf("expected %d voting etcd members, found %d", len(controlPlanes), voting)
+	}
+	return nil
+}
+
+// LeaveEtcd makes a control plane node leave the etcd cluster gracefully.
+func (t *TalosOperator) LeaveEtcd(ctx context.Context, node string) error {
+	t.logger.Info("Removing node from etcd", zap.String("node", node))
+
+	if _, err := t.talos.ExecuteCommand(ctx,
+		"etcd", "leave",
+		"--nodes", node,
+		"--talosconfig", t.talosconfig,
+	); err != nil {
+		return fmt.Errorf("failed to leave etcd on node %s: %w", node, err)
+	}
+	return nil
+}
+
+// RemoveEtcdMember removes the member with the given hostname through another control plane
+// node. Use it when the departing node can no longer leave etcd by itself.
+func (t *TalosOperator) RemoveEtcdMember(ctx context.Context, node, hostname string) error {
+	members, err := t.EtcdMembers(ctx, node)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if member.Hostname != hostname {
+			continue
+		}
+		t.logger.Info("Removing etcd member", zap.String("hostname", hostname), zap.String("id", member.ID))
+		if _, err := t.talos.ExecuteCommand(ctx,
+			"etcd", "remove-member", member.ID,
+			"--nodes", node,
+			"--talosconfig", t.talosconfig,
+		); err != nil {
+			return fmt.Errorf("failed to remove etcd member %s: %w", hostname, err)
+		}
+		return nil
+	}
+	t.logger.Info("Node is not an etcd member", zap.String("hostname", hostname))
+	return nil
+}
+
+// EtcdSnapshot streams an etcd snapshot from a control plane node to a local file.
+func (t *TalosOperator) EtcdSnapshot(ctx context.Context, node, path string) error {
+	t.logger.Info("Taking etcd snapshot", zap.String("node", node), zap.String("file", path))
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	if _, err := t.talos.ExecuteCommand(ctx,
+		"etcd", "snapshot", path,
+		"--nodes", node,
+		"--talosconfig", t.talosconfig,
+	); err != nil {
+		return fmt.Errorf("failed to snapshot etcd from node %s: %w", node, err)
+	}
+	return nil
+}
+
+// ServiceState returns the state Talos reports for a system service, e.g. "Running" or "Preparing".
+func (t *TalosOperator) ServiceState(ctx context.Context, node, service string) (string, error) {
+	out, err := t.talos.ExecuteCommand(ctx,
+		"service", service,
+		"--nodes", node,
+		"--talosconfig", t.talosconfig,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s service state on node %s: %w", service, node, err)
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "STATE" {
+			return fields[1], nil
+		}
+	}
+	return "", fmt.Errorf("could not find %s service state in talosctl output for node %s", service, node)
+}
+
+// WipeEphemeral resets the EPHEMERAL partition of a node, discarding its etcd data, and reboots it.
+func (t *TalosOperator) WipeEphemeral(ctx context.Context, node string) error {
+	t.logger.Warn("Wiping EPHEMERAL partition", zap.String("node", node))
+
+	if _, err := t.talos.ExecuteCommand(ctx,
+		"reset",
+		"--nodes", node,
+		"--graceful=false",
+		"--reboot",
+		"--system-labels-to-wipe=EPHEMERAL",
+		"--wait=false",
+		"--talosconfig", t.talosconfig,
+	); err != nil {
+		return fmt.Errorf("failed to wipe EPHEMERAL on node %s: %w", node, err)
+	}
+	return nil
+}
+
+// RecoverEtcd bootstraps etcd on a control plane node from a snapshot file. etcd must be in the
+// Preparing state on every control plane node for Talos to accept the recovery.
+func (t *TalosOperator) RecoverEtcd(ctx context.Context, node, snapshotPath string) error {
+	t.logger.Info("Recovering etcd from snapshot", zap.String("node", node), zap.String("file", snapshotPath))
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	if _, err := t.talos.ExecuteCommand(ctx,
+		"bootstrap",
+		"--nodes", node,
+		"--recover-from", snapshotPath,
+		"--talosconfig", t.talosconfig,
+	); err != nil {
+		return fmt.Errorf("failed to recover etcd on node %s: %w", node, err)
+	}
+	return nil
+}
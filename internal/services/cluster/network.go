@@ -0,0 +1,75 @@
+// Package cluster provides day-2 operations against a running Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// addressStatus is the part of a Talos AddressStatus resource Butler reads.
+type addressStatus struct {
+	Spec struct {
+		Address  string `json:"address"`
+		LinkName string `json:"linkName"`
+	} `json:"spec"`
+}
+
+// LinkForAddress returns the name of the network link on a node whose subnet contains ip, as
+// reported by the node's Talos address resources. It is used to find the interface a VIP is
+// announced on without knowing how the platform names its NICs.
+func (t *TalosOperator) LinkForAddress(ctx context.Context, node, ip string) (string, error) {
+	target := net.ParseIP(ip)
+	if target == nil {
+		return "", fmt.Errorf("invalid IP address %q", ip)
+	}
+
+	out, err := t.talos.ExecuteCommand(ctx,
+		"get", "addresses",
+		"--nodes", node,
+		"--output", "json",
+		"--talosconfig", t.talosconfig,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to list addresses on node %s: %w", node, err)
+	}
+
+	// talosctl prints one JSON document per resource rather than a list.
+	decoder := json.NewDecoder(strings.NewReader(out))
+	for {
+		var status addressStatus
+		if err := decoder.Decode(&status); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return "", fmt.Errorf("failed to parse addresses of node %s: %w", node, err)
+		}
+
+		_, subnet, err := net.ParseCIDR(status.Spec.Address)
+		if err != nil || status.Spec.LinkName == "" || status.Spec.LinkName == "lo" {
+			continue
+		}
+		if subnet.Contains(target) {
+			return status.Spec.LinkName, nil
+		}
+	}
+	return "", fmt.Errorf("no interface on node %s has an address in the same subnet as %s", node, ip)
+}
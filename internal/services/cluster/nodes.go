@@ -0,0 +1,193 @@
+// Package cluster provides day-2 operations against a running Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubectl"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+
+	"go.uber.org/zap"
+)
+
+// controlPlaneLabel is the well-known label Talos sets on control plane nodes.
+const controlPlaneLabel = "node-role.kubernetes.io/control-plane"
+
+// Node describes a Kubernetes node as seen by Butler's day-2 operations.
+type Node struct {
+	Name           string
+	InternalIP     string
+	ControlPlane   bool
+	Ready          bool
+	Unschedulable  bool
+	KubeletVersion string
+}
+
+// NodeOperator performs node-level operations such as cordon, drain and readiness checks.
+// Drains go through kubectl, which implements eviction; everything else uses the typed client.
+type NodeOperator struct {
+	kube       *kubernetes.KubernetesAdapter
+	kubectl    *kubectl.KubectlAdapter
+	kubeconfig string
+	logger     *zap.Logger
+}
+
+// NewNodeOperator creates a new NodeOperator using the given kubeconfig.
+func NewNodeOperator(kubectl *kubectl.KubectlAdapter, kubeconfig string, logger *zap.Logger) *NodeOperator {
+	return &NodeOperator{
+		kube:       kubernetes.NewKubernetesAdapter(kubeconfig, logger),
+		kubectl:    kubectl,
+		kubeconfig: kubeconfig,
+		logger:     logger,
+	}
+}
+
+// ListNodes returns every node registered in the cluster.
+func (n *NodeOperator) ListNodes(ctx context.Context) ([]Node, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	items, err := n.kube.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]Node, 0, len(items))
+	for _, item := range items {
+		node := Node{
+			Name:           item.Name,
+			InternalIP:     kubernetes.NodeInternalIP(item),
+			Ready:          kubernetes.NodeReady(item),
+			Unschedulable:  item.Spec.Unschedulable,
+			KubeletVersion: item.Status.NodeInfo.KubeletVersion,
+		}
+		_, node.ControlPlane = item.Labels[controlPlaneLabel]
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+// Cordon marks a node as unschedulable.
+func (n *NodeOperator) Cordon(ctx context.Context, name string) error {
+	n.logger.Info("Cordoning node", zap.String("node", name))
+
+	if err := n.kube.SetUnschedulable(ctx, name, true); err != nil {
+		return fmt.Errorf("failed to cordon node %s: %w", name, err)
+	}
+	return nil
+}
+
+// Drain evicts all workloads from a node, ignoring DaemonSet-managed pods.
+func (n *NodeOperator) Drain(ctx context.Context, name string, timeout time.Duration) error {
+	n.logger.Info("Draining node", zap.String("node", name), zap.Duration("timeout", timeout))
+
+	// Give kubectl a little headroom over its own timeout so it can report why the drain stalled.
+	ctx, cancel := context.WithTimeout(ctx, timeout+30*time.Second)
+	defer cancel()
+
+	if _, err := n.kubectl.ExecuteCommand(ctx,
+		"--kubeconfig", n.kubeconfig,
+		"drain", name,
+		"--ignore-daemonsets",
+		"--delete-emptydir-data",
+		fmt.Sprintf("--timeout=%s", timeout),
+	); err != nil {
+		return fmt.Errorf("failed to drain node %s: %w", name, err)
+	}
+	return nil
+}
+
+// Uncordon marks a node as schedulable again.
+func (n *NodeOperator) Uncordon(ctx context.Context, name string) error {
+	n.logger.Info("Uncordoning node", zap.String("node", name))
+
+	if err := n.kube.SetUnschedulable(ctx, name, false); err != nil {
+		return fmt.Errorf("failed to uncordon node %s: %w", name, err)
+	}
+	return nil
+}
+
+// Label sets labels on a node, replacing any existing values.
+func (n *NodeOperator) Label(ctx context.Context, name string, labels map[string]string) error {
+	return n.kube.LabelNode(ctx, name, labels)
+}
+
+// Delete removes a node object from the cluster.
+func (n *NodeOperator) Delete(ctx context.Context, name string) error {
+	n.logger.Info("Deleting node", zap.String("node", name))
+
+	return n.kube.DeleteNode(ctx, name)
+}
+
+// WaitForNodeByIP polls until a node with the given internal IP has registered and reports
+// Ready, and returns it. Used for new nodes whose names are not known in advance.
+func (n *NodeOperator) WaitForNodeByIP(ctx context.Context, ip string, timeout time.Duration) (Node, error) {
+	n.logger.Info("Waiting for node to join and become Ready", zap.String("ip", ip), zap.Duration("timeout", timeout))
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		nodes, err := n.ListNodes(ctx)
+		if err != nil {
+			n.logger.Warn("Failed to list nodes, retrying...", zap.Error(err))
+		} else {
+			for _, node := range nodes {
+				if node.InternalIP == ip && node.Ready {
+					n.logger.Info("Node is Ready", zap.String("node", node.Name), zap.String("ip", ip))
+					return node, nil
+				}
+			}
+		}
+		time.Sleep(10 * time.Second)
+	}
+
+	return Node{}, fmt.Errorf("timed out waiting for node with IP %s to become Ready", ip)
+}
+
+// WaitForNodeReady polls until the named node reports Ready. When kubeletVersion is set,
+// the node must also report that kubelet version before it is considered ready.
+func (n *NodeOperator) WaitForNodeReady(ctx context.Context, name, kubeletVersion string, timeout time.Duration) error {
+	n.logger.Info("Waiting for node to become Ready",
+		zap.String("node", name),
+		zap.String("kubeletVersion", kubeletVersion),
+		zap.Duration("timeout", timeout),
+	)
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		nodes, err := n.ListNodes(ctx)
+		if err != nil {
+			n.logger.Warn("Failed to list nodes, retrying...", zap.Error(err))
+		} else {
+			for _, node := range nodes {
+				if node.Name != name {
+					continue
+				}
+				if node.Ready && (kubeletVersion == "" || node.KubeletVersion == kubeletVersion) {
+					n.logger.Info("Node is Ready", zap.String("node", name), zap.String("kubeletVersion", node.KubeletVersion))
+					return nil
+				}
+			}
+		}
+		time.Sleep(10 * time.Second)
+	}
+
+	return fmt.Errorf("timed out waiting for node %s to become Ready", name)
+}
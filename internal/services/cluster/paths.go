@@ -0,0 +1,46 @@
+// Package cluster provides day-2 operations against a running Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"path/filepath"
+
+	"github.com/butlerdotdev/butler/pkg/models"
+)
+
+// DefaultOutputDir is where bootstrap writes the talosconfig, kubeconfig and machine configs
+// unless talos.outputDir is set.
+const DefaultOutputDir = "talosconfig"
+
+// OutputDir returns the directory holding the management cluster's talosconfig, kubeconfig and
+// machine configs.
+func OutputDir(config models.TalosConfig) string {
+	if config.OutputDir == "" {
+		return DefaultOutputDir
+	}
+	return config.OutputDir
+}
+
+// KubeconfigPath returns the management cluster kubeconfig bootstrap writes.
+func KubeconfigPath(config models.TalosConfig) string {
+	return filepath.Join(OutputDir(config), "kubeconfig")
+}
+
+// TalosconfigPath returns the talosconfig bootstrap writes.
+func TalosconfigPath(config models.TalosConfig) string {
+	return filepath.Join(OutputDir(config), "talosconfig")
+}
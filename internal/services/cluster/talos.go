@@ -0,0 +1,219 @@
+// Package cluster provides day-2 operations against a running Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/talos"
+
+	"go.uber.org/zap"
+)
+
+// TalosOperator performs node-level operations through the Talos API.
+type TalosOperator struct {
+	talos       *talos.TalosAdapter
+	talosconfig string
+	logger      *zap.Logger
+}
+
+// NewTalosOperator creates a new TalosOperator using the given talosconfig.
+func NewTalosOperator(talos *talos.TalosAdapter, talosconfig string, logger *zap.Logger) *TalosOperator {
+	return &TalosOperator{
+		talos:       talos,
+		talosconfig: talosconfig,
+		logger:      logger,
+	}
+}
+
+// Version returns the Talos version running on a node.
+func (t *TalosOperator) Version(ctx context.Context, node string) (string, error) {
+	out, err := t.talos.ExecuteCommand(ctx,
+		"version", "--short",
+		"--nodes", node,
+		"--talosconfig", t.talosconfig,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to get Talos version of node %s: %w", node, err)
+	}
+
+	// The output lists the client tag first, then a tag per queried node under "Server:".
+	inServer := false
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "Server:" {
+			inServer = true
+			continue
+		}
+		if inServer && strings.HasPrefix(line, "Tag:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "Tag:")), nil
+		}
+	}
+	return "", fmt.Errorf("could not find server version in talosctl output for node %s", node)
+}
+
+// Upgrade requests a Talos upgrade of a node to the given installer image. It does not wait
+// for the node to come back; use WaitForVersion for that.
+func (t *TalosOperator) Upgrade(ctx context.Context, node, image string) error {
+	t.logger.Info("Upgrading Talos", zap.String("node", node), zap.String("image", image))
+
+	if _, err := t.talos.ExecuteCommand(ctx,
+		"upgrade",
+		"--nodes", node,
+		"--image", image,
+		"--wait=false",
+		"--talosconfig", t.talosconfig,
+	); err != nil {
+		return fmt.Errorf("failed to upgrade Talos on node %s: %w", node, err)
+	}
+	return nil
+}
+
+// WaitForVersion polls a node until it reports the given Talos version. Errors while the
+// node reboots are expected and only logged.
+func (t *TalosOperator) WaitForVersion(ctx context.Context, node, version string, timeout time.Duration) error {
+	t.logger.Info("Waiting for node to report Talos version", zap.String("node", node), zap.String("version", version))
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		current, err := t.Version(ctx, node)
+		if err != nil {
+			t.logger.Debug("Node not reachable yet", zap.String("node", node), zap.Error(err))
+		} else if current == version {
+			t.logger.Info("Node is running the expected Talos version", zap.String("node", node), zap.String("version", version))
+			return nil
+		}
+		time.Sleep(10 * time.Second)
+	}
+
+	return fmt.Errorf("timed out waiting for node %s to report Talos %s", node, version)
+}
+
+// Virtualization describes a node's support for running KVM guests.
+type Virtualization struct {
+	// KVM is set when the CPU has hardware virtualization extensions and /dev/kvm exists.
+	KVM bool
+	// Nested is set when the node is itself a virtual machine, so KVM guests run nested.
+	Nested bool
+}
+
+// Virtualization reads a node's CPU flags and checks for /dev/kvm through the Talos API.
+func (t *TalosOperator) Virtualization(ctx context.Context, node string) (Virtualization, error) {
+	cpuinfo, err := t.talos.ExecuteCommand(ctx,
+		"read", "/proc/cpuinfo",
+		"--nodes", node,
+		"--talosconfig", t.talosconfig,
+	)
+	if err != nil {
+		return Virtualization{}, fmt.Errorf("failed to read CPU flags of node %s: %w", node, err)
+	}
+
+	flags := map[string]bool{}
+	scanner := bufio.NewScanner(strings.NewReader(cpuinfo))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || strings.TrimSpace(key) != "flags" {
+			continue
+		}
+		for _, flag := range strings.Fields(value) {
+			flags[flag] = true
+		}
+	}
+
+	result := Virtualization{Nested: flags["hypervisor"]}
+	if !flags["vmx"] && !flags["svm"] {
+		return result, nil
+	}
+	// The extensions can be present while KVM is unavailable, e.g. disabled in firmware.
+	if _, err := t.talos.ExecuteCommand(ctx,
+		"ls", "/dev/kvm",
+		"--nodes", node,
+		"--talosconfig", t.talosconfig,
+	); err != nil {
+		t.logger.Debug("Node does not expose /dev/kvm", zap.String("node", node), zap.Error(err))
+		return result, nil
+	}
+	result.KVM = true
+	return result, nil
+}
+
+// PatchMachineConfig applies a JSON patch to a node's machine configuration.
+func (t *TalosOperator) PatchMachineConfig(ctx context.Context, node, patch string) error {
+	t.logger.Info("Patching Talos machine config", zap.String("node", node))
+
+	if _, err := t.talos.ExecuteCommand(ctx,
+		"patch", "machineconfig",
+		"--nodes", node,
+		"--patch", patch,
+		"--talosconfig", t.talosconfig,
+	); err != nil {
+		return fmt.Errorf("failed to patch machine config on node %s: %w", node, err)
+	}
+	return nil
+}
+
+// ApplyConfig applies a machine config file to a node. Use insecure for nodes still in
+// maintenance mode, which do not have a Talos PKI yet.
+func (t *TalosOperator) ApplyConfig(ctx context.Context, node, file string, insecure bool) error {
+	t.logger.Info("Applying Talos config", zap.String("node", node), zap.String("file", file))
+
+	args := []string{
+		"apply-config",
+		"--nodes", node,
+		"--file", file,
+		"--talosconfig", t.talosconfig,
+	}
+	if insecure {
+		args = append(args, "--insecure")
+	}
+
+	if _, err := t.talos.ExecuteCommand(ctx, args...); err != nil {
+		return fmt.Errorf("failed to apply Talos config to node %s: %w", node, err)
+	}
+	return nil
+}
+
+// UpgradeKubernetesControlPlane upgrades the Kubernetes control plane components (API server,
+// controller manager, scheduler, kube-proxy and bootstrap manifests) without touching kubelets.
+func (t *TalosOperator) UpgradeKubernetesControlPlane(ctx context.Context, node, version string, dryRun bool) error {
+	t.logger.Info("Upgrading Kubernetes control plane components",
+		zap.String("node", node),
+		zap.String("version", version),
+		zap.Bool("dryRun", dryRun),
+	)
+
+	args := []string{
+		"upgrade-k8s",
+		"--nodes", node,
+		"--to", strings.TrimPrefix(version, "v"),
+		"--upgrade-kubelet=false",
+		"--talosconfig", t.talosconfig,
+	}
+	if dryRun {
+		args = append(args, "--dry-run")
+	}
+
+	if _, err := t.talos.ExecuteCommand(ctx, args...); err != nil {
+		return fmt.Errorf("failed to upgrade Kubernetes control plane to %s: %w", version, err)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Package cni installs the management cluster's container network.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cni
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"path"
+
+	"github.com/butlerdotdev/butler/internal/services/machineconfig"
+	"github.com/butlerdotdev/butler/internal/services/network"
+	"github.com/butlerdotdev/butler/internal/services/readiness"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/helm"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+)
+
+//go:embed assets/cilium/values.yaml
+var ciliumValues string
+
+const (
+	// ciliumChartRepo and ciliumChartVersion pin the Cilium Helm chart.
+	ciliumChartRepo    = "https://helm.cilium.io/"
+	ciliumChartVersion = "1.17.2"
+
+	// defaultCiliumRepository is the image path under network.image.registry when only the registry is overridden.
+	defaultCiliumRepository = "cilium/cilium"
+)
+
+// ciliumWorkloads are the Cilium components that must be rolled out before the cluster network is usable.
+var ciliumWorkloads = []readiness.Resource{
+	readiness.DaemonSet("kube-system", "cilium"),
+	readiness.Deployment("kube-system", "cilium-operator"),
+}
+
+// CiliumInstaller installs Cilium as the CNI and kube-proxy replacement.
+type CiliumInstaller struct {
+	kube   *kubernetes.KubernetesAdapter
+	helm   *helm.HelmAdapter
+	logger *zap.Logger
+}
+
+// NewCiliumInstaller constructs a new CiliumInstaller instance.
+func NewCiliumInstaller(kube *kubernetes.KubernetesAdapter, helm *helm.HelmAdapter, logger *zap.Logger) *CiliumInstaller {
+	return &CiliumInstaller{
+		kube:   kube,
+		helm:   helm,
+		logger: logger,
+	}
+}
+
+// Name returns "cilium".
+func (c *CiliumInstaller) Name() string {
+	return Cilium
+}
+
+// Validate rejects the tunnel types Cilium does not implement and Kube-OVN network declarations.
+func (c *CiliumInstaller) Validate(networkConfig models.NetworkConfig) error {
+	tunnelType := network.WithDefaults(networkConfig).TunnelType
+	if tunnelType != "geneve" && tunnelType != "vxlan" {
+		return fmt.Errorf("network.tunnelType %q is not supported by Cilium; use geneve or vxlan", tunnelType)
+	}
+	kubeOvn := networkConfig.KubeOvn
+	if len(kubeOvn.ProviderNetworks)+len(kubeOvn.Vlans)+len(kubeOvn.Vpcs)+len(kubeOvn.Subnets) > 0 {
+		return fmt.Errorf("network.kubeOvn is only supported with the %s CNI", KubeOvn)
+	}
+	return nil
+}
+
+// TalosPatch disables kube-proxy, which Cilium replaces.
+func (c *CiliumInstaller) TalosPatch(networkConfig models.NetworkConfig) machineconfig.Patch {
+	return machineconfig.Patch{
+		{Op: "add", Path: "/cluster/proxy/disabled", Value: true},
+	}
+}
+
+// LabelNodes is a no-op; Cilium runs on every node without role labels.
+func (c *CiliumInstaller) LabelNodes(ctx context.Context, server string, nodes Nodes) error {
+	return nil
+}
+
+// Install renders the Cilium values and performs a Helm install of the Cilium chart.
+func (c *CiliumInstaller) Install(ctx context.Context, config *models.BootstrapConfig, nodes Nodes) error {
+	networkConfig := network.WithDefaults(config.ManagementCluster.Network)
+
+	var imageRepository string
+	if image := networkConfig.Image; image.Registry != "" || image.Repository != "" {
+		repository := image.Repository
+		if repository == "" {
+			repository = defaultCiliumRepository
+		}
+		imageRepository = path.Join(image.Registry, repository)
+	}
+
+	values, err := renderValues("cilium", ciliumValues, map[string]interface{}{
+		"TUNNEL_TYPE":      networkConfig.TunnelType,
+		"MTU":              networkConfig.MTU,
+		"IFACE":            networkConfig.Interface,
+		"IMAGE_REPOSITORY": imageRepository,
+		"IMAGE_TAG":        networkConfig.Image.Tag,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("Installing Cilium via Helm", zap.String("version", ciliumChartVersion))
+	if err := helmInstall(ctx, c.helm, c.kube.Kubeconfig(), "cilium", helm.Chart{
+		Name:    "cilium",
+		Repo:    ciliumChartRepo,
+		Version: ciliumChartVersion,
+	}, values, networkConfig.Values); err != nil {
+		return fmt.Errorf("failed to install Cilium: %w", err)
+	}
+
+	c.logger.Info("Cilium installed successfully")
+	return nil
+}
+
+// Configure is a no-op; Cilium's network resources are not managed by Butler.
+func (c *CiliumInstaller) Configure(ctx context.Context, server string, config *models.BootstrapConfig) error {
+	return nil
+}
+
+// WaitForReady waits until the Cilium agents and operator are rolled out.
+func (c *CiliumInstaller) WaitForReady(ctx context.Context, server string) error {
+	return readiness.NewWaiter(c.kube.WithServer(server), c.logger).Wait(ctx, ciliumWorkloads...)
+}
@@ -0,0 +1,186 @@
+// Package cni installs the management cluster's container network.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cni
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/cluster"
+	"github.com/butlerdotdev/butler/internal/services/machineconfig"
+	"github.com/butlerdotdev/butler/pkg/adapters/exec"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/helm"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/talos"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// KubeOvn selects Kube-OVN, the default, which provides the overlays KubeVirt workloads need.
+	KubeOvn = "kube-ovn"
+	// Cilium selects Cilium with kube-proxy replacement.
+	Cilium = "cilium"
+)
+
+// Nodes identifies the cluster's nodes for a CNI installation.
+type Nodes struct {
+	// ControlPlanes and Workers are the node IPs by role.
+	ControlPlanes []string
+	Workers       []string
+	// Names maps node internal IPs to node names, collected before a VIP moves onto a node.
+	Names map[string]string
+}
+
+// CNIInstaller installs and verifies a CNI on the management cluster.
+type CNIInstaller interface {
+	// Name returns the CNI's name as used in network.cni.
+	Name() string
+	// Validate checks that the network settings are supported by the CNI.
+	Validate(network models.NetworkConfig) error
+	// TalosPatch returns the machine config changes every node needs for the CNI. It is applied
+	// after Butler's base patch, which sets the CNI to none.
+	TalosPatch(network models.NetworkConfig) machineconfig.Patch
+	// LabelNodes applies the labels the CNI schedules its components by.
+	LabelNodes(ctx context.Context, server string, nodes Nodes) error
+	// Install installs the CNI with Helm.
+	Install(ctx context.Context, config *models.BootstrapConfig, nodes Nodes) error
+	// WaitForReady waits until the CNI's workloads are rolled out.
+	WaitForReady(ctx context.Context, server string) error
+	// Configure applies the CNI's own network resources declared in the config once it is
+	// ready. It is safe to run repeatedly and reconciles the resources to the config.
+	Configure(ctx context.Context, server string, config *models.BootstrapConfig) error
+}
+
+// NewCNIInstaller returns the installer for the named CNI; an empty name selects Kube-OVN.
+func NewCNIInstaller(name string, kube *kubernetes.KubernetesAdapter, helm *helm.HelmAdapter, talos *talos.TalosAdapter, logger *zap.Logger) (CNIInstaller, error) {
+	switch name {
+	case "", KubeOvn:
+		return NewKubeOvnInstaller(kube, helm, talos, logger), nil
+	case Cilium:
+		return NewCiliumInstaller(kube, helm, logger), nil
+	default:
+		return nil, fmt.Errorf("unsupported network.cni %q; expected %s or %s", name, KubeOvn, Cilium)
+	}
+}
+
+// NewCNIInstallerForCluster returns the configured CNI's installer for an existing management
+// cluster, using the kubeconfig bootstrap wrote to the Talos output directory.
+func NewCNIInstallerForCluster(config *models.BootstrapConfig, logger *zap.Logger) (CNIInstaller, error) {
+	execAdapter := exec.NewClient(logger)
+
+	talosAdapter, err := platforms.GetPlatformAdapter("talos", execAdapter, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Talos adapter: %w", err)
+	}
+	talosConcrete, ok := talosAdapter.(*talos.TalosAdapter)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert TalosAdapter type")
+	}
+
+	helmAdapter, err := platforms.GetPlatformAdapter("helm", execAdapter, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Helm adapter: %w", err)
+	}
+	helmConcrete, ok := helmAdapter.(*helm.HelmAdapter)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert HelmAdapter type")
+	}
+
+	kube := kubernetes.NewKubernetesAdapter(cluster.KubeconfigPath(config.ManagementCluster.Talos), logger)
+
+	return NewCNIInstaller(config.ManagementCluster.Network.CNI, kube, helmConcrete, talosConcrete, logger)
+}
+
+// WaitForNodes waits until the expected number of nodes have registered with the API server.
+// Nodes stay NotReady until the CNI is installed, so readiness is not required.
+func WaitForNodes(ctx context.Context, kube *kubernetes.KubernetesAdapter, expected int, timeout time.Duration, logger *zap.Logger) error {
+	logger.Info("Waiting for nodes to register", zap.Int("expected", expected))
+
+	deadline := time.Now().Add(timeout)
+	registered := 0
+	for time.Now().Before(deadline) {
+		reqCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+		nodes, err := kube.ListNodes(reqCtx)
+		cancel()
+		if err != nil {
+			logger.Debug("Failed to list nodes", zap.Error(err))
+		} else if registered = len(nodes); registered >= expected {
+			logger.Info("Nodes registered in cluster", zap.Int("count", registered))
+			return nil
+		}
+		time.Sleep(5 * time.Second)
+	}
+	return fmt.Errorf("timed out waiting for nodes to register: %d of %d registered", registered, expected)
+}
+
+// NodeNames resolves the given IPs to node names, logging and skipping IPs without a node.
+func NodeNames(logger *zap.Logger, names map[string]string, ips []string) []string {
+	var resolved []string
+	seen := make(map[string]bool)
+
+	for _, ip := range ips {
+		name, found := names[ip]
+		if !found {
+			logger.Warn("No node found for IP", zap.String("ip", ip))
+			continue
+		}
+		if !seen[name] {
+			resolved = append(resolved, name)
+			seen[name] = true
+		}
+	}
+	return resolved
+}
+
+// helmInstall installs or upgrades a release into kube-system of the cluster in kubeconfig with the
+// rendered values, followed by the network.values overlay so user-supplied keys win. Re-running it
+// upgrades the release in place.
+func helmInstall(ctx context.Context, adapter *helm.HelmAdapter, kubeconfig, name string, chart helm.Chart, values []byte, overlay map[string]interface{}) error {
+	var rendered map[string]interface{}
+	if err := yaml.Unmarshal(values, &rendered); err != nil {
+		return fmt.Errorf("failed to parse rendered %s values: %w", name, err)
+	}
+
+	_, err := adapter.UpgradeInstall(ctx, name, chart, helm.ReleaseOptions{
+		Namespace:  "kube-system",
+		Kubeconfig: kubeconfig,
+		Values:     []map[string]interface{}{rendered, overlay},
+		Timeout:    10 * time.Minute,
+	})
+	return err
+}
+
+// renderValues renders a chart values template.
+func renderValues(name, values string, data map[string]interface{}) ([]byte, error) {
+	tmpl, err := template.New(name).Parse(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s values template: %w", name, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s values template: %w", name, err)
+	}
+	return rendered.Bytes(), nil
+}
@@ -0,0 +1,263 @@
+// Package cni installs the management cluster's container network.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cni
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/cluster"
+	"github.com/butlerdotdev/butler/internal/services/controlplane"
+	"github.com/butlerdotdev/butler/internal/services/machineconfig"
+	"github.com/butlerdotdev/butler/internal/services/network"
+	"github.com/butlerdotdev/butler/internal/services/readiness"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/helm"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/talos"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+)
+
+//go:embed assets/kubeovn/values.yaml
+var kubeOvnValues string
+
+const (
+	// kubeOvnChartRepo and kubeOvnChartVersion pin the Kube-OVN Helm chart.
+	kubeOvnChartRepo    = "https://kubeovn.github.io/kube-ovn/"
+	kubeOvnChartVersion = "v1.13.3"
+
+	// defaultKubeOvnRegistry, defaultKubeOvnRepository and defaultKubeOvnTag locate the Kube-OVN
+	// image when network.image does not override them.
+	defaultKubeOvnRegistry   = "ghcr.io/cozystack/cozystack"
+	defaultKubeOvnRepository = "kubeovn"
+	defaultKubeOvnTag        = "v1.13.3@sha256:1ce5fb7d596d2a6a52982e3d7541d56d75e14e8b0a1331c262bcbb9793a317af"
+)
+
+// kubeOvnWorkloads are the Kube-OVN components that must be rolled out before the cluster network
+// is usable, in dependency order.
+var kubeOvnWorkloads = []readiness.Resource{
+	readiness.Deployment("kube-system", "ovn-central").WithTimeout(10 * time.Minute),
+	readiness.DaemonSet("kube-system", "ovs-ovn").WithTimeout(10 * time.Minute),
+	readiness.Deployment("kube-system", "kube-ovn-controller"),
+	readiness.DaemonSet("kube-system", "kube-ovn-cni"),
+}
+
+// kubeOvnMounts are the host paths Open vSwitch and OVN share with the kubelet.
+var kubeOvnMounts = []string{"/run/openvswitch", "/run/ovn", "/var/log/openvswitch", "/var/log/ovn"}
+
+// KubeOvnInstaller installs Kube-OVN, with OVN central running on the control plane nodes.
+type KubeOvnInstaller struct {
+	kube   *kubernetes.KubernetesAdapter
+	helm   *helm.HelmAdapter
+	talos  *talos.TalosAdapter
+	logger *zap.Logger
+}
+
+// NewKubeOvnInstaller constructs a new KubeOvnInstaller instance.
+func NewKubeOvnInstaller(kube *kubernetes.KubernetesAdapter, helm *helm.HelmAdapter, talos *talos.TalosAdapter, logger *zap.Logger) *KubeOvnInstaller {
+	return &KubeOvnInstaller{
+		kube:   kube,
+		helm:   helm,
+		talos:  talos,
+		logger: logger,
+	}
+}
+
+// Name returns "kube-ovn".
+func (k *KubeOvnInstaller) Name() string {
+	return KubeOvn
+}
+
+// Validate checks the declared Kube-OVN networks. Every tunnel type network.Validate allows is Kube-OVN's.
+func (k *KubeOvnInstaller) Validate(networkConfig models.NetworkConfig) error {
+	return validateKubeOvnResources(networkConfig.KubeOvn)
+}
+
+// TalosPatch loads the openvswitch module and shares the Open vSwitch and OVN run and log
+// directories with the kubelet.
+func (k *KubeOvnInstaller) TalosPatch(networkConfig models.NetworkConfig) machineconfig.Patch {
+	patch := machineconfig.Patch{
+		{Op: "add", Path: "/machine/kernel/modules/-", Value: map[string]interface{}{"name": "openvswitch"}},
+	}
+	for _, path := range kubeOvnMounts {
+		patch = append(patch, machineconfig.Operation{
+			Op:   "add",
+			Path: "/machine/kubelet/extraMounts/-",
+			Value: map[string]interface{}{
+				"source":      path,
+				"destination": path,
+				"type":        "bind",
+				"options":     []string{"rbind", "rw"},
+			},
+		})
+	}
+	return patch
+}
+
+// LabelNodes applies Kube-OVN-specific labels to control plane and worker nodes.
+// Control plane nodes receive 'kube-ovn/role=master'; workers get 'node-role.kubernetes.io/worker='.
+func (k *KubeOvnInstaller) LabelNodes(ctx context.Context, server string, nodes Nodes) error {
+	kube := k.kube.WithServer(server)
+
+	for _, nodeName := range NodeNames(k.logger, nodes.Names, nodes.ControlPlanes) {
+		if err := kube.LabelNode(ctx, nodeName, map[string]string{"kube-ovn/role": "master"}); err != nil {
+			return fmt.Errorf("failed to label control plane node %s: %w", nodeName, err)
+		}
+	}
+
+	for _, nodeName := range NodeNames(k.logger, nodes.Names, nodes.Workers) {
+		if err := kube.LabelNode(ctx, nodeName, map[string]string{"node-role.kubernetes.io/worker": ""}); err != nil {
+			return fmt.Errorf("failed to label worker node %s: %w", nodeName, err)
+		}
+	}
+
+	return nil
+}
+
+// Install renders the Kube-OVN values and performs a Helm install of the Kube-OVN chart.
+func (k *KubeOvnInstaller) Install(ctx context.Context, config *models.BootstrapConfig, nodes Nodes) error {
+	k.logger.Info("Rendering and applying Kube-OVN values.yaml")
+
+	renderedIPs, err := k.masterNodes(ctx, nodes.ControlPlanes, config)
+	if err != nil {
+		return err
+	}
+
+	k.logger.Info("Final rendered control plane IP list for Kube-OVN",
+		zap.Strings("renderedIPs", renderedIPs))
+
+	networkConfig := network.WithDefaults(config.ManagementCluster.Network)
+	podGateway, err := network.Gateway(networkConfig.PodCIDR)
+	if err != nil {
+		return err
+	}
+
+	image := networkConfig.Image
+	if image.Registry == "" {
+		image.Registry = defaultKubeOvnRegistry
+	}
+	if image.Repository == "" {
+		image.Repository = defaultKubeOvnRepository
+	}
+	if image.Tag == "" {
+		image.Tag = defaultKubeOvnTag
+	}
+
+	values, err := renderValues("kube-ovn", kubeOvnValues, map[string]interface{}{
+		"MASTER_NODES": strings.Join(renderedIPs, ","),
+		"NODE_IPS":     strings.Join(renderedIPs, ","),
+		"REGISTRY":     image.Registry,
+		"REPOSITORY":   image.Repository,
+		"TAG":          image.Tag,
+		"IFACE":        networkConfig.Interface,
+		"TUNNEL_TYPE":  networkConfig.TunnelType,
+		"MTU":          networkConfig.MTU,
+		"POD_CIDR":     networkConfig.PodCIDR,
+		"POD_GATEWAY":  podGateway,
+		"SVC_CIDR":     networkConfig.ServiceCIDR,
+		"JOIN_CIDR":    networkConfig.JoinCIDR,
+	})
+	if err != nil {
+		return err
+	}
+
+	k.logger.Info("Installing Kube-OVN via Helm")
+	if err := helmInstall(ctx, k.helm, k.kube.Kubeconfig(), "kube-ovn", helm.Chart{
+		Name:    "kube-ovn",
+		Repo:    kubeOvnChartRepo,
+		Version: kubeOvnChartVersion,
+	}, values, networkConfig.Values); err != nil {
+		return fmt.Errorf("failed to install Kube-OVN: %w", err)
+	}
+
+	k.logger.Info("Kube-OVN installed successfully")
+	return nil
+}
+
+// WaitForReady waits until the Kube-OVN control plane and per-node agents are rolled out.
+func (k *KubeOvnInstaller) WaitForReady(ctx context.Context, server string) error {
+	return readiness.NewWaiter(k.kube.WithServer(server), k.logger).Wait(ctx, kubeOvnWorkloads...)
+}
+
+// masterNodes returns the control plane addresses Kube-OVN's central components are reached on.
+// When a single node holds the VIP, the VIP stands in for that node's own IP; with BGP or an
+// external load balancer no node owns the endpoint and the node IPs are used as they are.
+func (k *KubeOvnInstaller) masterNodes(ctx context.Context, controlPlaneIPs []string, config *models.BootstrapConfig) ([]string, error) {
+	if !controlplane.NodeBound(config.ManagementCluster.Talos) {
+		return controlPlaneIPs, nil
+	}
+
+	vip := config.ManagementCluster.Talos.ControlPlaneVIP
+
+	// Detect VIP holder if not explicitly set
+	detected, err := FindVipHolder(ctx, k.talos, cluster.TalosconfigPath(config.ManagementCluster.Talos), vip, controlPlaneIPs, k.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect VIP holder: %w", err)
+	}
+	k.logger.Info("Detected VIP holder node", zap.String("boundNodeIP", detected))
+	config.ManagementCluster.Talos.BoundNodeIP = detected
+	boundIP := detected
+
+	k.logger.Info("Starting Kube-OVN control plane IP rendering",
+		zap.String("vip", vip),
+		zap.String("boundNodeIP", boundIP),
+		zap.Strings("controlPlaneIPs", controlPlaneIPs),
+	)
+
+	renderedIPs := []string{vip}
+	seen := map[string]bool{vip: true}
+
+	for _, ip := range controlPlaneIPs {
+		if ip == boundIP {
+			k.logger.Info("Skipping bound control plane IP", zap.String("ip", ip))
+			continue
+		}
+		if !seen[ip] {
+			k.logger.Info("Adding control plane IP", zap.String("ip", ip))
+			renderedIPs = append(renderedIPs, ip)
+			seen[ip] = true
+		}
+	}
+	return renderedIPs, nil
+}
+
+// FindVipHolder checks which control plane node is holding the VIP.
+// This uses the /proc/net/fib_trie file to determine the node holding the VIP.
+// Most reliable way to check for VIP holder using the network stack directly.
+func FindVipHolder(ctx context.Context, talos *talos.TalosAdapter, talosconfig, vip string, nodes []string, logger *zap.Logger) (string, error) {
+	for _, node := range nodes {
+		logger.Info("Checking for VIP on node", zap.String("node", node))
+		out, err := talos.ExecuteCommand(ctx,
+			"read", "/proc/net/fib_trie",
+			"--nodes", node,
+			"--talosconfig", talosconfig,
+		)
+		if err != nil {
+			logger.Warn("Failed to read /proc/net/fib_trie", zap.String("node", node), zap.Error(err))
+			continue
+		}
+		if strings.Contains(out, vip) {
+			logger.Info("VIP found on node", zap.String("vip", vip), zap.String("node", node))
+			return node, nil
+		}
+	}
+	return "", fmt.Errorf("VIP %s not found on any control plane nodes", vip)
+}
@@ -0,0 +1,264 @@
+// Package cni installs the management cluster's container network.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/butlerdotdev/butler/internal/services/readiness"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+)
+
+// kubeOvnAPIVersion is the API version of Kube-OVN's custom resources.
+const kubeOvnAPIVersion = "kubeovn.io/v1"
+
+// Configure applies the ProviderNetworks, Vlans, Vpcs and Subnets declared in network.kubeOvn
+// and waits for the subnets to be Ready. Objects are server-side applied, so running it again
+// reconciles them to the configuration.
+func (k *KubeOvnInstaller) Configure(ctx context.Context, server string, config *models.BootstrapConfig) error {
+	resources := config.ManagementCluster.Network.KubeOvn
+	manifest, subnets, err := renderKubeOvnResources(resources)
+	if err != nil {
+		return err
+	}
+	if len(manifest) == 0 {
+		k.logger.Info("No Kube-OVN networks configured")
+		return nil
+	}
+
+	k.logger.Info("Applying Kube-OVN networks",
+		zap.Int("providerNetworks", len(resources.ProviderNetworks)),
+		zap.Int("vlans", len(resources.Vlans)),
+		zap.Int("vpcs", len(resources.Vpcs)),
+		zap.Int("subnets", len(resources.Subnets)),
+	)
+	kube := k.kube.WithServer(server)
+	if err := kube.Apply(ctx, manifest); err != nil {
+		return fmt.Errorf("failed to apply Kube-OVN networks: %w", err)
+	}
+
+	if err := readiness.NewWaiter(kube, k.logger).Wait(ctx, subnets...); err != nil {
+		return fmt.Errorf("Kube-OVN subnets did not become ready: %w", err)
+	}
+
+	k.logger.Info("Kube-OVN networks configured")
+	return nil
+}
+
+// validateKubeOvnResources checks the declared Kube-OVN networks before anything is installed.
+func validateKubeOvnResources(resources models.KubeOvnConfig) error {
+	for _, pn := range resources.ProviderNetworks {
+		if pn.Name == "" || pn.DefaultInterface == "" {
+			return fmt.Errorf("network.kubeOvn.providerNetworks entries need a name and a defaultInterface")
+		}
+	}
+	for _, vlan := range resources.Vlans {
+		if vlan.Name == "" || vlan.Provider == "" {
+			return fmt.Errorf("network.kubeOvn.vlans entries need a name and a provider")
+		}
+		if vlan.ID < 0 || vlan.ID > 4094 {
+			return fmt.Errorf("VLAN %s has invalid id %d; expected 0-4094", vlan.Name, vlan.ID)
+		}
+	}
+	for _, vpc := range resources.Vpcs {
+		if vpc.Name == "" {
+			return fmt.Errorf("network.kubeOvn.vpcs entries need a name")
+		}
+		for _, route := range vpc.StaticRoutes {
+			if _, _, err := net.ParseCIDR(route.CIDR); err != nil {
+				return fmt.Errorf("VPC %s has an invalid static route CIDR %q: %w", vpc.Name, route.CIDR, err)
+			}
+			if net.ParseIP(route.NextHopIP) == nil {
+				return fmt.Errorf("VPC %s has an invalid static route next hop %q", vpc.Name, route.NextHopIP)
+			}
+		}
+	}
+	for _, subnet := range resources.Subnets {
+		if subnet.Name == "" {
+			return fmt.Errorf("network.kubeOvn.subnets entries need a name")
+		}
+		_, cidr, err := net.ParseCIDR(subnet.CIDR)
+		if err != nil {
+			return fmt.Errorf("subnet %s has an invalid cidr %q: %w", subnet.Name, subnet.CIDR, err)
+		}
+		if subnet.Gateway != "" && !cidr.Contains(net.ParseIP(subnet.Gateway)) {
+			return fmt.Errorf("subnet %s gateway %s is outside %s", subnet.Name, subnet.Gateway, subnet.CIDR)
+		}
+	}
+	return nil
+}
+
+// renderKubeOvnResources renders the declared Kube-OVN networks as a multi-document manifest in
+// dependency order and returns the subnets to wait for.
+func renderKubeOvnResources(resources models.KubeOvnConfig) ([]byte, []readiness.Resource, error) {
+	var objects []map[string]interface{}
+
+	for _, pn := range resources.ProviderNetworks {
+		spec := map[string]interface{}{"defaultInterface": pn.DefaultInterface}
+		if len(pn.ExcludeNodes) > 0 {
+			spec["excludeNodes"] = pn.ExcludeNodes
+		}
+		if pn.ExchangeLinkName {
+			spec["exchangeLinkName"] = true
+		}
+		objects = append(objects, kubeOvnObject("ProviderNetwork", pn.Name, spec))
+	}
+
+	for _, vlan := range resources.Vlans {
+		objects = append(objects, kubeOvnObject("Vlan", vlan.Name, map[string]interface{}{
+			"id":       vlan.ID,
+			"provider": vlan.Provider,
+		}))
+	}
+
+	for _, vpc := range resources.Vpcs {
+		spec := map[string]interface{}{}
+		if len(vpc.Namespaces) > 0 {
+			spec["namespaces"] = vpc.Namespaces
+		}
+		if len(vpc.StaticRoutes) > 0 {
+			routes := make([]map[string]interface{}, 0, len(vpc.StaticRoutes))
+			for _, route := range vpc.StaticRoutes {
+				routes = append(routes, map[string]interface{}{
+					"cidr":      route.CIDR,
+					"nextHopIP": route.NextHopIP,
+					"policy":    "policyDst",
+				})
+			}
+			spec["staticRoutes"] = routes
+		}
+		if vpc.EnableExternal {
+			spec["enableExternal"] = true
+		}
+		objects = append(objects, kubeOvnObject("Vpc", vpc.Name, spec))
+	}
+
+	var subnets []readiness.Resource
+	for _, subnet := range resources.Subnets {
+		objects = append(objects, kubeOvnObject("Subnet", subnet.Name, kubeOvnSubnetSpec(subnet)))
+		subnets = append(subnets, subnetReady(subnet.Name))
+	}
+
+	manifest, err := kubernetes.RenderManifest(objects)
+	if err != nil {
+		return nil, nil, err
+	}
+	return manifest, subnets, nil
+}
+
+// RenderKubeOvnAttachments renders a Multus NetworkAttachmentDefinition per network, delegating
+// to Kube-OVN, and the subnet serving it. The subnet's provider ties it to the attachment.
+func RenderKubeOvnAttachments(networks []models.VirtualMachineNetwork) ([]byte, []readiness.Resource, error) {
+	var objects []map[string]interface{}
+	var subnets []readiness.Resource
+	for _, network := range networks {
+		namespace := network.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		provider := fmt.Sprintf("%s.%s.ovn", network.Name, namespace)
+
+		config, err := json.Marshal(map[string]interface{}{
+			"cniVersion":    "0.3.1",
+			"type":          "kube-ovn",
+			"server_socket": "/run/openvswitch/kube-ovn-daemon.sock",
+			"provider":      provider,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to render attachment %s: %w", network.Name, err)
+		}
+		objects = append(objects, map[string]interface{}{
+			"apiVersion": "k8s.cni.cncf.io/v1",
+			"kind":       "NetworkAttachmentDefinition",
+			"metadata":   map[string]interface{}{"name": network.Name, "namespace": namespace},
+			"spec":       map[string]interface{}{"config": string(config)},
+		})
+
+		spec := kubeOvnSubnetSpec(models.KubeOvnSubnet{
+			CIDR:       network.CIDR,
+			Gateway:    network.Gateway,
+			ExcludeIPs: network.ExcludeIPs,
+			Vpc:        network.Vpc,
+			Vlan:       network.Vlan,
+		})
+		spec["provider"] = provider
+		objects = append(objects, kubeOvnObject("Subnet", network.Name, spec))
+		subnets = append(subnets, subnetReady(network.Name))
+	}
+
+	manifest, err := kubernetes.RenderManifest(objects)
+	if err != nil {
+		return nil, nil, err
+	}
+	return manifest, subnets, nil
+}
+
+// kubeOvnSubnetSpec builds a Subnet's spec. The protocol follows the CIDR's address family.
+func kubeOvnSubnetSpec(subnet models.KubeOvnSubnet) map[string]interface{} {
+	protocol := "IPv4"
+	if ip, _, err := net.ParseCIDR(subnet.CIDR); err == nil && ip.To4() == nil {
+		protocol = "IPv6"
+	}
+	spec := map[string]interface{}{
+		"protocol":    protocol,
+		"cidrBlock":   subnet.CIDR,
+		"natOutgoing": subnet.NatOutgoing,
+		"private":     subnet.Private,
+	}
+	if subnet.Gateway != "" {
+		spec["gateway"] = subnet.Gateway
+	}
+	if len(subnet.ExcludeIPs) > 0 {
+		spec["excludeIps"] = subnet.ExcludeIPs
+	}
+	if subnet.Vpc != "" {
+		spec["vpc"] = subnet.Vpc
+	}
+	if subnet.Vlan != "" {
+		spec["vlan"] = subnet.Vlan
+	}
+	if len(subnet.Namespaces) > 0 {
+		spec["namespaces"] = subnet.Namespaces
+	}
+	return spec
+}
+
+// subnetReady waits for a Subnet's Ready condition.
+func subnetReady(name string) readiness.Resource {
+	return readiness.Resource{
+		APIVersion: kubeOvnAPIVersion,
+		Kind:       "Subnet",
+		Name:       name,
+		Condition:  "Ready",
+	}
+}
+
+// kubeOvnObject builds a cluster-scoped Kube-OVN object.
+func kubeOvnObject(kind, name string, spec map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": kubeOvnAPIVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name},
+		"spec":       spec,
+	}
+}
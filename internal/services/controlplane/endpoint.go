@@ -0,0 +1,117 @@
+// Package controlplane resolves how the management cluster's Kubernetes API endpoint is made highly available.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/butlerdotdev/butler/pkg/models"
+)
+
+const (
+	// ModeKubeVipARP has kube-vip announce the VIP with gratuitous ARP from the elected leader.
+	ModeKubeVipARP = "kube-vip-arp"
+	// ModeKubeVipBGP has kube-vip advertise the VIP to BGP peers, which works across L3 boundaries.
+	ModeKubeVipBGP = "kube-vip-bgp"
+	// ModeTalosVIP uses the shared VIP built into Talos, held by one control plane node at a time.
+	ModeTalosVIP = "talos-vip"
+	// ModeExternal relies on a load balancer outside the cluster at ControlPlaneEndpoint.
+	ModeExternal = "external"
+
+	// apiServerPort is the port the Kubernetes API server listens on.
+	apiServerPort = "6443"
+)
+
+// Mode returns the configured HA mode, defaulting to kube-vip in ARP mode.
+func Mode(config models.TalosConfig) string {
+	if config.ControlPlaneHA.Mode == "" {
+		return ModeKubeVipARP
+	}
+	return config.ControlPlaneHA.Mode
+}
+
+// Validate checks that the settings the configured mode depends on are present.
+func Validate(config models.TalosConfig) error {
+	switch mode := Mode(config); mode {
+	case ModeKubeVipARP, ModeTalosVIP:
+		if net.ParseIP(config.ControlPlaneVIP) == nil {
+			return fmt.Errorf("controlPlaneHA mode %s requires talos.controlPlaneVIP to be an IP address", mode)
+		}
+	case ModeKubeVipBGP:
+		if net.ParseIP(config.ControlPlaneVIP) == nil {
+			return fmt.Errorf("controlPlaneHA mode %s requires talos.controlPlaneVIP to be an IP address", mode)
+		}
+		bgp := config.ControlPlaneHA.BGP
+		if bgp.LocalASN == 0 {
+			return fmt.Errorf("controlPlaneHA mode %s requires talos.controlPlaneHA.bgp.localASN", mode)
+		}
+		if len(bgp.Peers) == 0 {
+			return fmt.Errorf("controlPlaneHA mode %s requires at least one entry in talos.controlPlaneHA.bgp.peers", mode)
+		}
+		for _, peer := range bgp.Peers {
+			if net.ParseIP(peer.Address) == nil || peer.ASN == 0 {
+				return fmt.Errorf("BGP peer %q needs an IP address and an ASN", peer.Address)
+			}
+		}
+	case ModeExternal:
+		if config.ControlPlaneEndpoint == "" {
+			return fmt.Errorf("controlPlaneHA mode %s requires talos.controlPlaneEndpoint to be the load balancer address", mode)
+		}
+	default:
+		return fmt.Errorf("unknown controlPlaneHA mode %q; expected %s, %s, %s or %s",
+			mode, ModeKubeVipARP, ModeKubeVipBGP, ModeTalosVIP, ModeExternal)
+	}
+	return nil
+}
+
+// UsesKubeVip reports whether kube-vip has to be deployed to serve the endpoint.
+func UsesKubeVip(config models.TalosConfig) bool {
+	mode := Mode(config)
+	return mode == ModeKubeVipARP || mode == ModeKubeVipBGP
+}
+
+// NodeBound reports whether the VIP is an address held by a single control plane node at a time,
+// as opposed to being advertised by several nodes or served from outside the cluster.
+func NodeBound(config models.TalosConfig) bool {
+	mode := Mode(config)
+	return mode == ModeKubeVipARP || mode == ModeTalosVIP
+}
+
+// Host returns the address clients use to reach the Kubernetes API once the endpoint is up.
+func Host(config models.TalosConfig) string {
+	if Mode(config) == ModeExternal {
+		if host, _, err := net.SplitHostPort(config.ControlPlaneEndpoint); err == nil {
+			return host
+		}
+		return config.ControlPlaneEndpoint
+	}
+	return config.ControlPlaneVIP
+}
+
+// Endpoint returns the host:port the cluster is generated with. An explicit ControlPlaneEndpoint
+// wins, e.g. a DNS name pointing at the VIP; otherwise it is the VIP on the API server port.
+func Endpoint(config models.TalosConfig) string {
+	endpoint := config.ControlPlaneEndpoint
+	if endpoint == "" {
+		endpoint = config.ControlPlaneVIP
+	}
+	if _, _, err := net.SplitHostPort(endpoint); err != nil {
+		endpoint = net.JoinHostPort(endpoint, apiServerPort)
+	}
+	return endpoint
+}
@@ -0,0 +1,144 @@
+// Package etcd provides services for backing up and restoring the management cluster's etcd.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"net/url"
+	"text/template"
+
+	"github.com/butlerdotdev/butler/internal/services/machineconfig"
+	"github.com/butlerdotdev/butler/internal/templates"
+
+	"go.uber.org/zap"
+)
+
+//go:embed assets/etcd-backup.yaml.tmpl
+var etcdBackupManifest string
+
+const (
+	// backupNamespace is where the scheduled backup CronJob runs.
+	backupNamespace = "butler-system"
+
+	// defaultUploaderImage is the MinIO client image that uploads and prunes snapshots in the CronJob.
+	defaultUploaderImage = "quay.io/minio/mc:RELEASE.2024-11-21T17-21-54Z"
+)
+
+// Schedule installs (or updates) a CronJob in the management cluster that snapshots etcd on the
+// given cron schedule and uploads the snapshots to the configured S3 bucket with retention.
+func (e *EtcdService) Schedule(ctx context.Context, schedule string) error {
+	backup := e.config.ManagementCluster.EtcdBackup
+	if schedule == "" {
+		schedule = backup.Schedule
+	}
+	if schedule == "" {
+		return fmt.Errorf("no schedule given; pass --schedule or set etcdBackup.schedule")
+	}
+	if backup.S3.Bucket == "" {
+		return fmt.Errorf("scheduled snapshots run in the cluster and require etcdBackup.s3.bucket")
+	}
+
+	nodes, err := e.nodes.ListNodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The CronJob talks to the Talos API from inside the cluster, which has to be allowed per node.
+	accessPatch, err := machineconfig.TalosAPIAccess([]string{"os:etcd:backup"}, []string{backupNamespace}).String()
+	if err != nil {
+		return err
+	}
+	var talosVersion string
+	for _, node := range nodes {
+		if !node.ControlPlane {
+			continue
+		}
+		if err := e.talos.PatchMachineConfig(ctx, node.InternalIP, accessPatch); err != nil {
+			return err
+		}
+		if talosVersion == "" {
+			if talosVersion, err = e.talos.Version(ctx, node.InternalIP); err != nil {
+				return err
+			}
+		}
+	}
+	if talosVersion == "" {
+		return fmt.Errorf("no control plane nodes found in the cluster")
+	}
+
+	manifest, err := e.renderSchedule(schedule, talosVersion)
+	if err != nil {
+		return err
+	}
+
+	e.logger.Info("Applying etcd backup CronJob",
+		zap.String("namespace", backupNamespace),
+		zap.String("schedule", schedule),
+		zap.String("bucket", backup.S3.Bucket),
+	)
+	if err := e.kube.Apply(ctx, manifest); err != nil {
+		return fmt.Errorf("failed to apply etcd backup CronJob: %w", err)
+	}
+
+	e.logger.Info("etcd backup CronJob installed")
+	return nil
+}
+
+// renderSchedule renders the Namespace, Talos ServiceAccount, S3 Secret and CronJob manifests.
+func (e *EtcdService) renderSchedule(schedule, talosVersion string) ([]byte, error) {
+	backup := e.config.ManagementCluster.EtcdBackup
+
+	scheme := "https"
+	if backup.S3.Insecure {
+		scheme = "http"
+	}
+	mcHost := url.URL{
+		Scheme: scheme,
+		User:   url.UserPassword(backup.S3.AccessKeyID, backup.S3.SecretAccessKey),
+		Host:   backup.S3.Endpoint,
+	}
+
+	uploaderImage := backup.UploaderImage
+	if uploaderImage == "" {
+		uploaderImage = defaultUploaderImage
+	}
+
+	data := map[string]interface{}{
+		"Namespace":     backupNamespace,
+		"Schedule":      schedule,
+		"TalosVersion":  talosVersion,
+		"UploaderImage": uploaderImage,
+		"MCHost":        mcHost.String(),
+		"Bucket":        backup.S3.Bucket,
+		"Prefix":        SnapshotPrefix(backup.S3.Prefix),
+		"Retention":     e.retention(),
+	}
+
+	tmpl, err := template.New("etcd-backup").Funcs(templates.FuncMap).Parse(etcdBackupManifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse etcd backup template: %w", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return nil, fmt.Errorf("failed to render etcd backup template: %w", err)
+	}
+	return rendered.Bytes(), nil
+}
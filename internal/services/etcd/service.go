@@ -0,0 +1,310 @@
+// Package etcd provides services for backing up and restoring the management cluster's etcd.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/cluster"
+	"github.com/butlerdotdev/butler/pkg/adapters/exec"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubectl"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/talos"
+	"github.com/butlerdotdev/butler/pkg/adapters/s3"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultLocalPath is where snapshots are kept when no S3 bucket is configured.
+	defaultLocalPath = "etcd-snapshots"
+
+	// defaultRetention is the number of snapshots kept when none is configured.
+	defaultRetention = 7
+)
+
+// RestoreOptions controls an etcd restore.
+type RestoreOptions struct {
+	// Snapshot is a local snapshot file or the name of a stored snapshot. Empty selects the newest stored snapshot.
+	Snapshot string
+	// Nodes are the control plane node IPs. Empty falls back to talos.controlPlaneNodes.
+	Nodes []string
+	// WipeEphemeral resets the EPHEMERAL partition on every control plane before restoring.
+	WipeEphemeral bool
+}
+
+// EtcdService takes, prunes, schedules and restores etcd snapshots of the management cluster.
+type EtcdService struct {
+	logger *zap.Logger
+	nodes  *cluster.NodeOperator
+	talos  *cluster.TalosOperator
+	kube   *kubernetes.KubernetesAdapter
+	store  snapshotStore
+	config *models.BootstrapConfig
+}
+
+// NewEtcdService initializes an EtcdService from the bootstrap configuration.
+func NewEtcdService(ctx context.Context, config *models.BootstrapConfig, logger *zap.Logger) (*EtcdService, error) {
+	logger.Info("Initializing EtcdService")
+
+	execAdapter := exec.NewClient(logger)
+
+	talosAdapter, err := platforms.GetPlatformAdapter("talos", execAdapter, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Talos adapter: %w", err)
+	}
+	talosConcrete, ok := talosAdapter.(*talos.TalosAdapter)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert TalosAdapter type")
+	}
+
+	kubectlAdapter, err := platforms.GetPlatformAdapter("kubectl", execAdapter, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Kubectl adapter: %w", err)
+	}
+	kubectlConcrete, ok := kubectlAdapter.(*kubectl.KubectlAdapter)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert KubectlAdapter type")
+	}
+
+	store, err := newSnapshotStore(config.ManagementCluster.EtcdBackup, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfig := cluster.KubeconfigPath(config.ManagementCluster.Talos)
+
+	return &EtcdService{
+		logger: logger,
+		nodes:  cluster.NewNodeOperator(kubectlConcrete, kubeconfig, logger),
+		talos:  cluster.NewTalosOperator(talosConcrete, cluster.TalosconfigPath(config.ManagementCluster.Talos), logger),
+		kube:   kubernetes.NewKubernetesAdapter(kubeconfig, logger),
+		store:  store,
+		config: config,
+	}, nil
+}
+
+// newSnapshotStore selects the S3 store when a bucket is configured, otherwise a local directory.
+func newSnapshotStore(cfg models.EtcdBackupConfig, logger *zap.Logger) (snapshotStore, error) {
+	if cfg.S3.Bucket == "" {
+		dir := cfg.LocalPath
+		if dir == "" {
+			dir = defaultLocalPath
+		}
+		return &localStore{dir: dir}, nil
+	}
+
+	client, err := s3.NewS3Client(cfg.S3.Endpoint, cfg.S3.Region, cfg.S3.Bucket, cfg.S3.AccessKeyID, cfg.S3.SecretAccessKey, cfg.S3.Insecure, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &s3Store{client: client, bucket: cfg.S3.Bucket, prefix: SnapshotPrefix(cfg.S3.Prefix)}, nil
+}
+
+// SnapshotPrefix returns the S3 key prefix snapshots are stored under. Snapshots are listed as
+// the direct children of the prefix, so a non-empty prefix is treated as a directory.
+func SnapshotPrefix(prefix string) string {
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return prefix
+}
+
+// Snapshot takes an etcd snapshot from a control plane node, stores it and prunes old snapshots
+// beyond the configured retention. It returns the name of the new snapshot.
+func (e *EtcdService) Snapshot(ctx context.Context, node string) (string, error) {
+	if node == "" {
+		var err error
+		node, err = e.firstReadyControlPlane(ctx)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "butler-etcd-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	name := fmt.Sprintf("etcd-%s.snapshot", time.Now().UTC().Format("20060102T150405Z"))
+	localPath := filepath.Join(tmpDir, name)
+
+	if err := e.talos.EtcdSnapshot(ctx, node, localPath); err != nil {
+		return "", err
+	}
+	if err := e.store.Save(ctx, localPath, name); err != nil {
+		return "", fmt.Errorf("failed to store snapshot: %w", err)
+	}
+	e.logger.Info("etcd snapshot stored", zap.String("snapshot", name), zap.String("store", e.store.String()))
+
+	if err := e.prune(ctx); err != nil {
+		return name, err
+	}
+	return name, nil
+}
+
+// prune deletes stored snapshots beyond the configured retention, oldest first.
+func (e *EtcdService) prune(ctx context.Context) error {
+	retention := e.retention()
+
+	names, err := e.store.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots for pruning: %w", err)
+	}
+	if len(names) <= retention {
+		return nil
+	}
+
+	for _, name := range names[retention:] {
+		e.logger.Info("Pruning etcd snapshot", zap.String("snapshot", name), zap.Int("retention", retention))
+		if err := e.store.Delete(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore recovers etcd on the control plane from a snapshot. etcd must be stopped and waiting for
+// bootstrap on every control plane node; WipeEphemeral gets them into that state.
+func (e *EtcdService) Restore(ctx context.Context, opts RestoreOptions) error {
+	nodes := opts.Nodes
+	if len(nodes) == 0 {
+		nodes = e.config.ManagementCluster.Talos.ControlPlaneNodes
+	}
+	if len(nodes) == 0 {
+		return fmt.Errorf("no control plane nodes given; pass --nodes or set talos.controlPlaneNodes")
+	}
+
+	snapshotPath, cleanup, err := e.resolveSnapshot(ctx, opts.Snapshot)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	// Nodes that were just wiped need time to reboot before etcd reports its state.
+	stateTimeout := 30 * time.Second
+	if opts.WipeEphemeral {
+		for _, node := range nodes {
+			if err := e.talos.WipeEphemeral(ctx, node); err != nil {
+				return err
+			}
+		}
+		stateTimeout = 10 * time.Minute
+	}
+
+	// Talos only accepts a recovery while etcd is waiting to be bootstrapped on every control plane.
+	for _, node := range nodes {
+		if err := e.waitForEtcdState(ctx, node, "Preparing", stateTimeout); err != nil {
+			return fmt.Errorf("%w; etcd must be stopped on all control planes (use --wipe-ephemeral to reset them)", err)
+		}
+	}
+
+	if err := e.talos.RecoverEtcd(ctx, nodes[0], snapshotPath); err != nil {
+		return err
+	}
+
+	if err := e.talos.WaitForEtcdQuorum(ctx, nodes, 15*time.Minute); err != nil {
+		return fmt.Errorf("etcd did not regain quorum after restore: %w", err)
+	}
+
+	e.logger.Info("etcd restored from snapshot", zap.String("snapshot", snapshotPath), zap.Strings("nodes", nodes))
+	return nil
+}
+
+// resolveSnapshot returns a local path for the requested snapshot, fetching it from the store
+// when needed, and a cleanup function for any temporary files.
+func (e *EtcdService) resolveSnapshot(ctx context.Context, snapshot string) (string, func(), error) {
+	noop := func() {}
+
+	if snapshot != "" {
+		if _, err := os.Stat(snapshot); err == nil {
+			return snapshot, noop, nil
+		}
+	}
+
+	if snapshot == "" {
+		names, err := e.store.List(ctx)
+		if err != nil {
+			return "", noop, err
+		}
+		if len(names) == 0 {
+			return "", noop, fmt.Errorf("no snapshots found in %s", e.store)
+		}
+		snapshot = names[0]
+		e.logger.Info("Using newest stored snapshot", zap.String("snapshot", snapshot))
+	}
+
+	tmpDir, err := os.MkdirTemp("", "butler-etcd-*")
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(tmpDir) }
+
+	localPath := filepath.Join(tmpDir, filepath.Base(snapshot))
+	if err := e.store.Fetch(ctx, snapshot, localPath); err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("failed to fetch snapshot %s from %s: %w", snapshot, e.store, err)
+	}
+	return localPath, cleanup, nil
+}
+
+// waitForEtcdState polls the etcd service on a node until it reports the wanted state.
+func (e *EtcdService) waitForEtcdState(ctx context.Context, node, state string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	current := ""
+	for time.Now().Before(deadline) {
+		var err error
+		current, err = e.talos.ServiceState(ctx, node, "etcd")
+		if err != nil {
+			e.logger.Debug("etcd service state not available yet", zap.String("node", node), zap.Error(err))
+		} else if current == state {
+			return nil
+		}
+		time.Sleep(10 * time.Second)
+	}
+	return fmt.Errorf("etcd on node %s is in state %q, expected %q", node, current, state)
+}
+
+// firstReadyControlPlane returns the internal IP of the first Ready control plane node.
+func (e *EtcdService) firstReadyControlPlane(ctx context.Context) (string, error) {
+	nodes, err := e.nodes.ListNodes(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, node := range nodes {
+		if node.ControlPlane && node.Ready && node.InternalIP != "" {
+			return node.InternalIP, nil
+		}
+	}
+	return "", fmt.Errorf("no Ready control plane node found")
+}
+
+// retention returns the configured number of snapshots to keep.
+func (e *EtcdService) retention() int {
+	if e.config.ManagementCluster.EtcdBackup.Retention > 0 {
+		return e.config.ManagementCluster.EtcdBackup.Retention
+	}
+	return defaultRetention
+}
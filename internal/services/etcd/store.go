@@ -0,0 +1,152 @@
+// Package etcd provides services for backing up and restoring the management cluster's etcd.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/butlerdotdev/butler/pkg/adapters/s3"
+)
+
+// snapshotName matches the names Butler gives etcd snapshots, both from the CLI and the CronJob.
+var snapshotName = regexp.MustCompile(`^etcd-\d{8}T\d{6}Z\.snapshot$`)
+
+// snapshotStore is a destination that holds etcd snapshots.
+type snapshotStore interface {
+	// Save copies a local snapshot file into the store under name.
+	Save(ctx context.Context, localPath, name string) error
+	// Fetch copies the named snapshot to a local file.
+	Fetch(ctx context.Context, name, localPath string) error
+	// List returns the names of all stored snapshots, newest first.
+	List(ctx context.Context) ([]string, error)
+	// Delete removes the named snapshot.
+	Delete(ctx context.Context, name string) error
+	// String describes the store for logs.
+	String() string
+}
+
+// localStore keeps snapshots in a directory on the operator's machine.
+type localStore struct {
+	dir string
+}
+
+func (l *localStore) Save(ctx context.Context, localPath, name string) error {
+	if err := os.MkdirAll(l.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create snapshot directory %s: %w", l.dir, err)
+	}
+	return copyFile(localPath, filepath.Join(l.dir, name))
+}
+
+func (l *localStore) Fetch(ctx context.Context, name, localPath string) error {
+	return copyFile(filepath.Join(l.dir, name), localPath)
+}
+
+func (l *localStore) List(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(l.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot directory %s: %w", l.dir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && snapshotName.MatchString(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	return names, nil
+}
+
+func (l *localStore) Delete(ctx context.Context, name string) error {
+	if err := os.Remove(filepath.Join(l.dir, name)); err != nil {
+		return fmt.Errorf("failed to delete snapshot %s: %w", name, err)
+	}
+	return nil
+}
+
+func (l *localStore) String() string {
+	return l.dir
+}
+
+// s3Store keeps snapshots under a prefix of an S3-compatible bucket.
+type s3Store struct {
+	client s3.S3Adapter
+	bucket string
+	prefix string
+}
+
+func (s *s3Store) Save(ctx context.Context, localPath, name string) error {
+	return s.client.Upload(ctx, localPath, s.prefix+name)
+}
+
+func (s *s3Store) Fetch(ctx context.Context, name, localPath string) error {
+	return s.client.Download(ctx, s.prefix+name, localPath)
+}
+
+func (s *s3Store) List(ctx context.Context) ([]string, error) {
+	keys, err := s.client.List(ctx, s.prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, key := range keys {
+		name := path.Base(key)
+		if s.prefix+name == key && snapshotName.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	return names, nil
+}
+
+func (s *s3Store) Delete(ctx context.Context, name string) error {
+	return s.client.Delete(ctx, s.prefix+name)
+}
+
+func (s *s3Store) String() string {
+	return fmt.Sprintf("s3://%s/%s", s.bucket, s.prefix)
+}
+
+// copyFile copies src to dst, replacing dst if it exists.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dst, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to copy %s to %s: %w", src, dst, err)
+	}
+	return out.Close()
+}
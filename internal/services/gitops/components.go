@@ -0,0 +1,388 @@
+// Package gitops bootstraps Flux on the management cluster and manages its GitOps repository.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/templates"
+	"github.com/butlerdotdev/butler/pkg/adapters/git"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// componentsDir is the directory under flux.gitPath that Butler owns. Component directories in it
+// that are no longer in the catalog are removed.
+const componentsDir = "components"
+
+// defaultBranchPrefix prefixes the branches Butler opens pull requests from.
+const defaultBranchPrefix = "butler/"
+
+// componentTemplates maps each file of a component directory to the template it is rendered from.
+var componentTemplates = map[string]string{
+	"namespace.yaml":       "namespace.yaml.tmpl",
+	"repository.yaml":      "repository.yaml.tmpl",
+	"release.yaml":         "release.yaml.tmpl",
+	"kustomization.yaml":   "kustomization.yaml.tmpl",
+	"kustomizeconfig.yaml": "kustomizeconfig.yaml.tmpl",
+}
+
+// defaultHostnames are the public hosts of providers that have one.
+var defaultHostnames = map[string]string{
+	ProviderGitHub: "github.com",
+	ProviderGitLab: "gitlab.com",
+}
+
+// reservedNamespaces are namespaces a component must not own: each component renders its own
+// Namespace object, which Flux would prune along with the component.
+var reservedNamespaces = map[string]bool{
+	"flux-system":     true,
+	"kube-system":     true,
+	"kube-public":     true,
+	"kube-node-lease": true,
+	"default":         true,
+}
+
+// componentNamespace returns the namespace a component is installed into.
+func componentNamespace(component models.ComponentConfig) string {
+	if component.Namespace != "" {
+		return component.Namespace
+	}
+	return component.Name
+}
+
+// ValidateComponents checks that every component is complete and that names and namespaces are
+// unique, since every component renders the Namespace object it owns.
+func ValidateComponents(components []models.ComponentConfig) error {
+	seen := map[string]bool{}
+	namespaces := map[string]string{}
+	for i, c := range components {
+		if errs := validation.IsDNS1123Label(c.Name); len(errs) > 0 {
+			return fmt.Errorf("flux.components[%d].name %q is invalid: %s", i, c.Name, strings.Join(errs, "; "))
+		}
+		if seen[c.Name] {
+			return fmt.Errorf("flux.components[%d]: duplicate component %q", i, c.Name)
+		}
+		seen[c.Name] = true
+
+		if c.Namespace != "" {
+			if errs := validation.IsDNS1123Label(c.Namespace); len(errs) > 0 {
+				return fmt.Errorf("flux.components[%d].namespace %q is invalid: %s", i, c.Namespace, strings.Join(errs, "; "))
+			}
+		}
+		namespace := componentNamespace(c)
+		if reservedNamespaces[namespace] {
+			return fmt.Errorf("flux.components[%d] (%s): namespace %q is reserved", i, c.Name, namespace)
+		}
+		if other, ok := namespaces[namespace]; ok {
+			return fmt.Errorf("flux.components[%d] (%s): namespace %q is already used by component %q", i, c.Name, namespace, other)
+		}
+		namespaces[namespace] = c.Name
+		if c.Chart == "" || c.Version == "" || c.RepoURL == "" {
+			return fmt.Errorf("flux.components[%d] (%s): chart, version and repoURL are required", i, c.Name)
+		}
+		if !strings.HasPrefix(c.RepoURL, "https://") && !strings.HasPrefix(c.RepoURL, "http://") && !strings.HasPrefix(c.RepoURL, "oci://") {
+			return fmt.Errorf("flux.components[%d] (%s): repoURL must be an http(s):// or oci:// URL", i, c.Name)
+		}
+	}
+	return nil
+}
+
+// RenderComponent renders the Flux manifests of a component, keyed by file name.
+func RenderComponent(component models.ComponentConfig) (map[string][]byte, error) {
+	namespace := componentNamespace(component)
+	repoType := "default"
+	if strings.HasPrefix(component.RepoURL, "oci://") {
+		repoType = "oci"
+	}
+	data := map[string]interface{}{
+		"Component": component.Name,
+		"Namespace": namespace,
+		"Chart":     component.Chart,
+		"Version":   component.Version,
+		"RepoURL":   component.RepoURL,
+		"RepoType":  repoType,
+	}
+
+	files := map[string][]byte{}
+	for file, name := range componentTemplates {
+		tmpl, err := template.New(name).Funcs(templates.FuncMap).ParseFS(templates.FS, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s template: %w", name, err)
+		}
+		var rendered bytes.Buffer
+		if err := tmpl.Execute(&rendered, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s for component %s: %w", file, component.Name, err)
+		}
+		files[file] = rendered.Bytes()
+	}
+
+	values := component.Values
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	out, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal values for component %s: %w", component.Name, err)
+	}
+	files["values.yaml"] = out
+	return files, nil
+}
+
+// ComponentRenderer commits the component catalog to the Flux repository.
+type ComponentRenderer struct {
+	git          git.GitAdapter
+	forge        git.Forge
+	branchPrefix string
+	gitPath      string
+	logger       *zap.Logger
+}
+
+// NewComponentRenderer creates a ComponentRenderer that writes below gitPath in the repository.
+func NewComponentRenderer(gitAdapter git.GitAdapter, gitPath string, logger *zap.Logger) *ComponentRenderer {
+	return &ComponentRenderer{
+		git:     gitAdapter,
+		gitPath: gitPath,
+		logger:  logger,
+	}
+}
+
+// WithPullRequests returns a copy of the renderer that pushes changes to a new branch named with
+// branchPrefix and opens a pull request for them, instead of pushing to the cloned branch.
+func (c *ComponentRenderer) WithPullRequests(forge git.Forge, branchPrefix string) *ComponentRenderer {
+	r := *c
+	r.forge = forge
+	r.branchPrefix = branchPrefix
+	return &r
+}
+
+// NewComponentRendererForConfig creates a ComponentRenderer for the repository Flux was
+// bootstrapped from.
+func NewComponentRendererForConfig(config models.FluxConfig, logger *zap.Logger) (*ComponentRenderer, error) {
+	repoURL, err := RepoURL(config)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := git.Options{
+		RepoURL:        repoURL,
+		Branch:         config.GitBranch,
+		Username:       config.GitUsername,
+		KnownHostsFile: config.KnownHostsFile,
+		AuthorName:     config.Commits.AuthorName,
+		AuthorEmail:    config.Commits.AuthorEmail,
+		SigningFormat:  config.Commits.SigningFormat,
+		SigningKey:     config.Commits.SigningKey,
+	}
+	if config.CAFile != "" {
+		if opts.CABundle, err = os.ReadFile(config.CAFile); err != nil {
+			return nil, fmt.Errorf("failed to read flux.caFile: %w", err)
+		}
+	}
+
+	// The token authenticates HTTPS and the forge API; SSH only needs it for pull requests.
+	var token string
+	if Auth(config) == AuthToken || config.Commits.PullRequest {
+		if token, err = resolveToken(config); err != nil {
+			return nil, err
+		}
+	}
+	if Auth(config) == AuthSSH {
+		if config.SSHKeyFile == "" {
+			return nil, fmt.Errorf("committing components over SSH requires flux.sshKeyFile")
+		}
+		opts.SSHKeyFile = config.SSHKeyFile
+	} else {
+		opts.Token = token
+	}
+
+	gitClient, err := git.NewGitClient(opts, logger)
+	if err != nil {
+		return nil, err
+	}
+	renderer := NewComponentRenderer(gitClient, config.GitPath, logger)
+	if !config.Commits.PullRequest {
+		return renderer, nil
+	}
+
+	provider := Provider(config)
+	apiURL, err := git.ForgeAPIURL(provider, config.GitHostname)
+	if err != nil {
+		return nil, err
+	}
+	forge, err := git.NewForgeClient(provider, apiURL, config.GitOwner, config.GitRepository, token, opts.CABundle, logger)
+	if err != nil {
+		return nil, err
+	}
+	branchPrefix := config.Commits.BranchPrefix
+	if branchPrefix == "" {
+		branchPrefix = defaultBranchPrefix
+	}
+	return renderer.WithPullRequests(forge, branchPrefix), nil
+}
+
+// RepoURL returns the clone URL of the Flux repository: HTTPS for token auth, SSH for ssh auth.
+func RepoURL(config models.FluxConfig) (string, error) {
+	provider := Provider(config)
+	if provider == ProviderGit {
+		return config.GitURL, nil
+	}
+
+	host := config.GitHostname
+	if host == "" {
+		host = defaultHostnames[provider]
+	}
+	if host == "" {
+		return "", fmt.Errorf("flux.gitHostname is required for the %s provider", provider)
+	}
+
+	repoPath := fmt.Sprintf("%s/%s.git", config.GitOwner, config.GitRepository)
+	if Auth(config) == AuthSSH {
+		return fmt.Sprintf("ssh://git@%s/%s", host, repoPath), nil
+	}
+	if provider == ProviderBitbucketServer {
+		return fmt.Sprintf("https://%s/scm/%s", host, repoPath), nil
+	}
+	return fmt.Sprintf("https://%s/%s", host, repoPath), nil
+}
+
+// Sync clones the repository, replaces the components directory with the rendered catalog and
+// pushes the result, through a pull request when configured. Nothing is committed when the
+// catalog is unchanged.
+func (c *ComponentRenderer) Sync(ctx context.Context, components []models.ComponentConfig) error {
+	if err := ValidateComponents(components); err != nil {
+		return err
+	}
+
+	workDir, err := os.MkdirTemp("", "butler-gitops-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	if err := c.git.CloneRepo(ctx, workDir); err != nil {
+		return err
+	}
+
+	dir := filepath.Join(workDir, filepath.FromSlash(path.Join(c.gitPath, componentsDir)))
+	if err := c.Render(components, dir); err != nil {
+		return err
+	}
+
+	changed, err := c.git.HasChanges(ctx, workDir)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		c.logger.Info("Components are up to date in the repository")
+		return nil
+	}
+
+	names := make([]string, 0, len(components))
+	for _, component := range components {
+		names = append(names, component.Name)
+	}
+	message := "Remove Butler components"
+	if len(names) > 0 {
+		message = "Update Butler components: " + strings.Join(names, ", ")
+	}
+
+	if c.forge == nil {
+		return c.git.CommitAndPush(ctx, workDir, message)
+	}
+
+	base, err := c.git.CurrentBranch(ctx, workDir)
+	if err != nil {
+		return err
+	}
+	branch := c.branchPrefix + "components-" + time.Now().UTC().Format("20060102T150405Z")
+	if err := c.git.CreateBranch(ctx, workDir, branch); err != nil {
+		return err
+	}
+	if err := c.git.CommitAndPush(ctx, workDir, message); err != nil {
+		return err
+	}
+
+	pr, err := c.forge.OpenPullRequest(ctx, git.PullRequestOptions{
+		Title: message,
+		Body:  "Rendered by Butler from flux.components. Flux installs the changes once this is merged.",
+		Head:  branch,
+		Base:  base,
+	})
+	if err != nil {
+		return err
+	}
+	c.logger.Info("Component changes are waiting for review", zap.String("pullRequest", pr.URL))
+	return nil
+}
+
+// Render writes one directory per component into dir, removing directories of components that
+// are no longer in the catalog.
+func (c *ComponentRenderer) Render(components []models.ComponentConfig, dir string) error {
+	wanted := map[string]bool{}
+	for _, component := range components {
+		wanted[component.Name] = true
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && !wanted[entry.Name()] {
+			c.logger.Info("Removing component no longer in the catalog", zap.String("component", entry.Name()))
+			if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+				return fmt.Errorf("failed to remove component %s: %w", entry.Name(), err)
+			}
+		}
+	}
+
+	for _, component := range components {
+		files, err := RenderComponent(component)
+		if err != nil {
+			return err
+		}
+
+		componentDir := filepath.Join(dir, component.Name)
+		if err := os.MkdirAll(componentDir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", componentDir, err)
+		}
+
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(componentDir, name), content, 0644); err != nil {
+				return fmt.Errorf("failed to write %s for component %s: %w", name, component.Name, err)
+			}
+		}
+		c.logger.Info("Rendered component",
+			zap.String("component", component.Name),
+			zap.String("chart", component.Chart),
+			zap.String("version", component.Version),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+// Package gitops bootstraps Flux on the management cluster and manages its GitOps repository.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/secrets"
+	"github.com/butlerdotdev/butler/pkg/adapters/git"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/flux"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+)
+
+// Git providers supported by flux bootstrap.
+const (
+	ProviderGitHub          = "github"
+	ProviderGitLab          = "gitlab"
+	ProviderGitea           = "gitea"
+	ProviderBitbucketServer = "bitbucket-server"
+	ProviderGit             = "git"
+)
+
+// Authentication methods Flux uses to pull from the repository.
+const (
+	AuthToken = "token"
+	AuthSSH   = "ssh"
+)
+
+const (
+	// bootstrapTimeout bounds a single flux bootstrap attempt, which waits for the controllers.
+	bootstrapTimeout = 10 * time.Minute
+
+	// maxRetries is the number of bootstrap attempts before giving up.
+	maxRetries = 3
+)
+
+// tokenEnv is the environment variable the token is read from, per provider. flux reads the
+// forge providers' variables itself; the git provider's password is passed with --password.
+var tokenEnv = map[string]string{
+	ProviderGitHub:          "GITHUB_TOKEN",
+	ProviderGitLab:          "GITLAB_TOKEN",
+	ProviderGitea:           "GITEA_TOKEN",
+	ProviderBitbucketServer: "BITBUCKET_TOKEN",
+	ProviderGit:             "GIT_PASSWORD",
+}
+
+// Provider returns the configured Git provider, defaulting to GitLab.
+func Provider(config models.FluxConfig) string {
+	if config.Provider == "" {
+		return ProviderGitLab
+	}
+	return config.Provider
+}
+
+// Auth returns the configured authentication method, defaulting to a token.
+func Auth(config models.FluxConfig) string {
+	if config.Auth == "" {
+		return AuthToken
+	}
+	return config.Auth
+}
+
+// Validate checks that the Flux settings are complete for the selected provider and auth.
+func Validate(config models.FluxConfig) error {
+	provider := Provider(config)
+	if _, ok := tokenEnv[provider]; !ok {
+		return fmt.Errorf("unsupported flux.provider %q (must be %s, %s, %s, %s or %s)",
+			provider, ProviderGitHub, ProviderGitLab, ProviderGitea, ProviderBitbucketServer, ProviderGit)
+	}
+
+	auth := Auth(config)
+	if auth != AuthToken && auth != AuthSSH {
+		return fmt.Errorf("unsupported flux.auth %q (must be %s or %s)", auth, AuthToken, AuthSSH)
+	}
+	switch config.Commits.SigningFormat {
+	case "", git.SigningGPG:
+	case git.SigningSSH:
+		if config.Commits.SigningKey == "" {
+			return fmt.Errorf("flux.commits.signingKey is required for %s signing", git.SigningSSH)
+		}
+	default:
+		return fmt.Errorf("unsupported flux.commits.signingFormat %q (must be %s or %s)", config.Commits.SigningFormat, git.SigningGPG, git.SigningSSH)
+	}
+	if config.Commits.PullRequest && provider != ProviderGitHub && provider != ProviderGitLab && provider != ProviderGitea {
+		return fmt.Errorf("flux.commits.pullRequest is not supported for the %s provider", provider)
+	}
+	if config.SSHKeyFile != "" && auth != AuthSSH {
+		return fmt.Errorf("flux.sshKeyFile requires flux.auth %s", AuthSSH)
+	}
+	for _, file := range []string{config.SSHKeyFile, config.CAFile} {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+	}
+
+	if provider == ProviderGit {
+		if config.GitURL == "" {
+			return fmt.Errorf("flux.gitURL is required for the %s provider", ProviderGit)
+		}
+		u, err := url.Parse(config.GitURL)
+		if err != nil {
+			return fmt.Errorf("invalid flux.gitURL %q: %w", config.GitURL, err)
+		}
+		switch {
+		case auth == AuthSSH && u.Scheme != "ssh":
+			return fmt.Errorf("flux.gitURL must be an ssh:// URL for flux.auth %s", AuthSSH)
+		case auth == AuthSSH && config.SSHKeyFile == "":
+			return fmt.Errorf("flux.sshKeyFile is required for the %s provider over SSH", ProviderGit)
+		case auth == AuthToken && u.Scheme != "https":
+			return fmt.Errorf("flux.gitURL must be an https:// URL for flux.auth %s", AuthToken)
+		}
+		return ValidateComponents(config.Components)
+	}
+
+	if config.GitOwner == "" || config.GitRepository == "" {
+		return fmt.Errorf("flux.gitOwner and flux.gitRepository are required for the %s provider", provider)
+	}
+	if provider == ProviderBitbucketServer && config.GitUsername == "" {
+		return fmt.Errorf("flux.gitUsername is required for the %s provider", provider)
+	}
+	return ValidateComponents(config.Components)
+}
+
+// FluxBootstrapper installs Flux into the management cluster and connects it to the Git repository.
+type FluxBootstrapper struct {
+	fluxAdapter *flux.FluxAdapter
+	kubeconfig  string
+	logger      *zap.Logger
+}
+
+// NewFluxBootstrapper creates a new FluxBootstrapper for the cluster in the given kubeconfig.
+func NewFluxBootstrapper(fluxAdapter *flux.FluxAdapter, kubeconfig string, logger *zap.Logger) *FluxBootstrapper {
+	return &FluxBootstrapper{
+		fluxAdapter: fluxAdapter,
+		kubeconfig:  kubeconfig,
+		logger:      logger,
+	}
+}
+
+// Bootstrap runs flux bootstrap for the configured provider, with retries.
+func (f *FluxBootstrapper) Bootstrap(ctx context.Context, config *models.BootstrapConfig) error {
+	fluxConfig := config.ManagementCluster.Flux
+	if err := Validate(fluxConfig); err != nil {
+		return err
+	}
+	provider := Provider(fluxConfig)
+	clusterName := config.ManagementCluster.Name
+
+	f.logger.Info("Starting Flux bootstrap for the management cluster",
+		zap.String("provider", provider),
+		zap.String("auth", Auth(fluxConfig)),
+	)
+
+	token, err := f.token(fluxConfig)
+	if err != nil {
+		return err
+	}
+	args := bootstrapArgs(fluxConfig, token, f.kubeconfig)
+
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		f.logger.Info("Executing Flux bootstrap",
+			zap.String("clusterName", clusterName),
+			zap.Int("attempt", attempt),
+		)
+
+		attemptCtx, cancel := context.WithTimeout(ctx, bootstrapTimeout)
+		_, err = f.fluxAdapter.ExecuteCommand(attemptCtx, args...)
+		cancel()
+		if err == nil {
+			f.logger.Info("Flux bootstrap completed successfully",
+				zap.String("clusterName", clusterName),
+			)
+			return nil
+		}
+
+		f.logger.Warn("Flux bootstrap failed, retrying...",
+			zap.String("clusterName", clusterName),
+			zap.Int("attempt", attempt),
+			zap.Error(err),
+		)
+
+		// Linear backoff
+		backoff := time.Duration(attempt*10) * time.Second
+		f.logger.Info("Waiting before retrying...", zap.Duration("backoff", backoff))
+		time.Sleep(backoff)
+	}
+
+	f.logger.Error("Flux bootstrap failed after multiple attempts",
+		zap.String("clusterName", clusterName),
+		zap.Error(err),
+	)
+	return fmt.Errorf("failed to bootstrap Flux after %d attempts: %w", maxRetries, err)
+}
+
+// token returns the provider API token (or HTTPS password for the git provider) and exports forge
+// tokens to the variable flux reads them from. Generic Git over SSH needs no token.
+func (f *FluxBootstrapper) token(config models.FluxConfig) (string, error) {
+	provider := Provider(config)
+	if provider == ProviderGit && Auth(config) == AuthSSH {
+		return "", nil
+	}
+
+	token, err := resolveToken(config)
+	if err != nil {
+		return "", err
+	}
+	if provider == ProviderGit {
+		// flux has no variable for the password; bootstrapArgs passes it with --password, which
+		// the exec adapter redacts from its logs.
+		return token, nil
+	}
+	envVar := tokenEnv[provider]
+	if err := os.Setenv(envVar, token); err != nil {
+		return "", fmt.Errorf("failed to set %s environment variable: %w", envVar, err)
+	}
+	return token, nil
+}
+
+// resolveToken returns the Git token from flux.gitPAT, then the provider's environment variable,
+// and only then from a prompt.
+func resolveToken(config models.FluxConfig) (string, error) {
+	provider := Provider(config)
+	envVar := tokenEnv[provider]
+
+	if config.GitPAT != "" {
+		return config.GitPAT, nil
+	}
+	if token := os.Getenv(envVar); token != "" {
+		return token, nil
+	}
+	token, err := secrets.Prompt(provider + " token")
+	if errors.Is(err, secrets.ErrNoTerminal) {
+		return "", fmt.Errorf("no %s token given; set flux.gitPAT or %s", provider, envVar)
+	}
+	return token, err
+}
+
+// bootstrapArgs builds the flux bootstrap command line for the configured provider.
+func bootstrapArgs(config models.FluxConfig, token, kubeconfig string) []string {
+	provider := Provider(config)
+	auth := Auth(config)
+
+	args := []string{"bootstrap", provider}
+	if provider == ProviderGit {
+		args = append(args, "--url", config.GitURL)
+		if auth == AuthToken {
+			args = append(args, "--token-auth=true", "--password", token)
+			if config.GitUsername != "" {
+				args = append(args, "--username", config.GitUsername)
+			}
+		} else {
+			// The deploy key is already registered with the server, so skip the confirmation prompt.
+			args = append(args, "--private-key-file", config.SSHKeyFile, "--silent")
+		}
+	} else {
+		args = append(args,
+			"--owner", config.GitOwner,
+			"--repository", config.GitRepository,
+		)
+		if config.GitHostname != "" {
+			args = append(args, "--hostname", config.GitHostname)
+		}
+		if config.Personal {
+			args = append(args, "--personal")
+		}
+		if provider == ProviderBitbucketServer {
+			args = append(args, "--username", config.GitUsername)
+		}
+		if auth == AuthToken {
+			args = append(args, "--token-auth=true")
+		} else {
+			// Flux registers a deploy key through the provider API, or uses the given one.
+			args = append(args, "--read-write-key=true")
+			if config.SSHKeyFile != "" {
+				args = append(args, "--private-key-file", config.SSHKeyFile)
+			}
+		}
+	}
+
+	if config.GitBranch != "" {
+		args = append(args, "--branch", config.GitBranch)
+	}
+	if config.GitPath != "" {
+		args = append(args, "--path", config.GitPath)
+	}
+	if config.CAFile != "" {
+		args = append(args, "--ca-file", config.CAFile)
+	}
+
+	return append(args,
+		"--components-extra", "image-reflector-controller,image-automation-controller",
+		"--kubeconfig", kubeconfig,
+	)
+}
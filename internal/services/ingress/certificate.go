@@ -0,0 +1,114 @@
+// Package ingress installs Traefik as the management cluster's ingress controller.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// selfSignedValidity is how long a generated default certificate is valid.
+const selfSignedValidity = 365 * 24 * time.Hour
+
+// defaultCertificate returns the PEM certificate and key Traefik serves when no other
+// certificate matches. The supplied files are used when set, otherwise a self-signed
+// certificate is generated for the configured DNS names and the dashboard host.
+func defaultCertificate(config models.IngressConfig) ([]byte, []byte, error) {
+	cert := config.DefaultCertificate
+	if cert.CertFile != "" {
+		certPEM, err := os.ReadFile(cert.CertFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read ingress certificate: %w", err)
+		}
+		keyPEM, err := os.ReadFile(cert.KeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read ingress certificate key: %w", err)
+		}
+		if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+			return nil, nil, fmt.Errorf("invalid ingress certificate: %w", err)
+		}
+		return certPEM, keyPEM, nil
+	}
+
+	dnsNames := append([]string{}, cert.DNSNames...)
+	if config.Dashboard.Enabled && !contains(dnsNames, config.Dashboard.Host) {
+		dnsNames = append(dnsNames, config.Dashboard.Host)
+	}
+	return selfSignedCertificate(dnsNames)
+}
+
+// selfSignedCertificate generates an ECDSA P-256 certificate for dnsNames.
+func selfSignedCertificate(dnsNames []string) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate certificate key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate certificate serial: %w", err)
+	}
+
+	commonName := "Butler Ingress Default Certificate"
+	if len(dnsNames) > 0 {
+		commonName = dnsNames[0]
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"Butler"}},
+		DNSNames:              dnsNames,
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create self-signed certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode certificate key: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// htpasswd returns a bcrypt htpasswd line for Traefik's basicAuth middleware.
+func htpasswd(username, password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash dashboard password: %w", err)
+	}
+	return username + ":" + string(hash), nil
+}
@@ -0,0 +1,235 @@
+// Package ingress installs Traefik as the management cluster's ingress controller.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/loadbalancer"
+	"github.com/butlerdotdev/butler/internal/services/readiness"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/helm"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// Namespace and ReleaseName locate Traefik's Deployment and LoadBalancer Service.
+	Namespace   = "traefik"
+	ReleaseName = "traefik"
+
+	// traefikChartRepo and DefaultVersion pin the Traefik Helm chart.
+	traefikChartRepo = "https://traefik.github.io/charts"
+	DefaultVersion   = "33.2.1"
+
+	// defaultCertSecret holds the default TLS certificate and dashboardAuthSecret the dashboard's htpasswd.
+	defaultCertSecret   = "traefik-default-cert"
+	dashboardAuthSecret = "traefik-dashboard-auth"
+)
+
+// TraefikInstaller installs Traefik and exposes it through MetalLB.
+type TraefikInstaller struct {
+	kube   *kubernetes.KubernetesAdapter
+	helm   *helm.HelmAdapter
+	logger *zap.Logger
+}
+
+// NewTraefikInstaller constructs a new TraefikInstaller instance.
+func NewTraefikInstaller(kube *kubernetes.KubernetesAdapter, helm *helm.HelmAdapter, logger *zap.Logger) *TraefikInstaller {
+	return &TraefikInstaller{
+		kube:   kube,
+		helm:   helm,
+		logger: logger,
+	}
+}
+
+// Install installs or upgrades Traefik with its default certificate and optional dashboard, then
+// waits for it to roll out and for MetalLB to assign its Service an address.
+func (t *TraefikInstaller) Install(ctx context.Context, server string, config models.IngressConfig) error {
+	kube := t.kube.WithServer(server)
+
+	// A self-signed certificate is only generated once, so reruns don't rotate it. Delete the
+	// Secret to generate a new one.
+	generateCert := config.DefaultCertificate.CertFile == ""
+	if generateCert {
+		if _, err := kube.Get(ctx, "v1", "Secret", Namespace, defaultCertSecret); err == nil {
+			t.logger.Info("Keeping the existing self-signed default certificate", zap.String("secret", defaultCertSecret))
+			generateCert = false
+		}
+	}
+
+	secrets, err := renderSecrets(config, config.DefaultCertificate.CertFile != "" || generateCert)
+	if err != nil {
+		return err
+	}
+	if err := kube.Apply(ctx, secrets); err != nil {
+		return fmt.Errorf("failed to apply Traefik secrets: %w", err)
+	}
+
+	version := config.Version
+	if version == "" {
+		version = DefaultVersion
+	}
+	values := []map[string]interface{}{Values(config)}
+	if len(config.Values) > 0 {
+		values = append(values, config.Values)
+	}
+
+	t.logger.Info("Installing Traefik via Helm", zap.String("version", version))
+	if _, err := t.helm.UpgradeInstall(ctx, ReleaseName, helm.Chart{
+		Name:    "traefik",
+		Repo:    traefikChartRepo,
+		Version: version,
+	}, helm.ReleaseOptions{
+		Namespace:  Namespace,
+		Kubeconfig: t.kube.Kubeconfig(),
+		Values:     values,
+		Timeout:    5 * time.Minute,
+	}); err != nil {
+		return fmt.Errorf("failed to install Traefik: %w", err)
+	}
+
+	if err := readiness.NewWaiter(kube, t.logger).Wait(ctx,
+		readiness.CRD("ingressroutes.traefik.io"),
+		readiness.Deployment(Namespace, ReleaseName).WithTimeout(5*time.Minute),
+	); err != nil {
+		return fmt.Errorf("Traefik did not become ready: %w", err)
+	}
+
+	ip, err := loadbalancer.WaitForAddress(ctx, kube, Namespace, ReleaseName, 2*time.Minute)
+	if err != nil {
+		return fmt.Errorf("Traefik was not assigned a LoadBalancer address: %w", err)
+	}
+	if config.LoadBalancerIP != "" && ip != config.LoadBalancerIP {
+		return fmt.Errorf("Traefik was assigned %s instead of ingress.loadBalancerIP %s", ip, config.LoadBalancerIP)
+	}
+
+	t.logger.Info("Traefik installed successfully", zap.String("address", ip))
+	return nil
+}
+
+// Values returns the chart values for the entrypoints, the MetalLB address, the default
+// certificate and the dashboard. The chart's default web and websecure ports are removed when
+// they are not configured.
+func Values(config models.IngressConfig) map[string]interface{} {
+	entryPoints := EntryPoints(config)
+
+	ports := map[string]interface{}{}
+	for _, ep := range defaultEntryPoints {
+		ports[ep.Name] = nil
+	}
+	for _, ep := range entryPoints {
+		port := map[string]interface{}{
+			"port":        ep.Port,
+			"exposedPort": exposedPort(ep),
+			"protocol":    protocol(ep),
+			"expose":      map[string]interface{}{"default": true},
+			"tls":         map[string]interface{}{"enabled": ep.TLS},
+		}
+		if ep.RedirectTo != "" {
+			port["redirectTo"] = map[string]interface{}{"port": ep.RedirectTo}
+		}
+		ports[ep.Name] = port
+	}
+
+	annotations := map[string]interface{}{}
+	switch {
+	case config.LoadBalancerIP != "":
+		annotations["metallb.universe.tf/loadBalancerIPs"] = config.LoadBalancerIP
+	case config.Pool != "":
+		annotations["metallb.universe.tf/address-pool"] = config.Pool
+	}
+
+	dashboard := map[string]interface{}{"enabled": false}
+	if config.Dashboard.Enabled {
+		dashboard = map[string]interface{}{
+			"enabled":     true,
+			"matchRule":   fmt.Sprintf("Host(`%s`)", config.Dashboard.Host),
+			"entryPoints": []string{tlsEntryPoint(entryPoints)},
+			"middlewares": []interface{}{map[string]interface{}{"name": dashboardAuthSecret}},
+			"tls":         map[string]interface{}{},
+		}
+	}
+
+	values := map[string]interface{}{
+		"ports": ports,
+		"service": map[string]interface{}{
+			"type":        "LoadBalancer",
+			"annotations": annotations,
+		},
+		"tlsStore": map[string]interface{}{
+			"default": map[string]interface{}{
+				"defaultCertificate": map[string]interface{}{"secretName": defaultCertSecret},
+			},
+		},
+		"ingressRoute": map[string]interface{}{"dashboard": dashboard},
+	}
+	if config.Dashboard.Enabled {
+		values["extraObjects"] = []interface{}{map[string]interface{}{
+			"apiVersion": "traefik.io/v1alpha1",
+			"kind":       "Middleware",
+			"metadata": map[string]interface{}{
+				"name":      dashboardAuthSecret,
+				"namespace": Namespace,
+			},
+			"spec": map[string]interface{}{
+				"basicAuth": map[string]interface{}{"secret": dashboardAuthSecret},
+			},
+		}}
+	}
+	return values
+}
+
+// renderSecrets renders the Traefik namespace, the default certificate Secret when withCert is
+// set and, when the dashboard is enabled, its basic auth Secret.
+func renderSecrets(config models.IngressConfig, withCert bool) ([]byte, error) {
+	objects := []map[string]interface{}{{
+		"apiVersion": "v1",
+		"kind":       "Namespace",
+		"metadata":   map[string]interface{}{"name": Namespace},
+	}}
+	if withCert {
+		certPEM, keyPEM, err := defaultCertificate(config)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"type":       "kubernetes.io/tls",
+			"metadata":   map[string]interface{}{"name": defaultCertSecret, "namespace": Namespace},
+			"stringData": map[string]interface{}{"tls.crt": string(certPEM), "tls.key": string(keyPEM)},
+		})
+	}
+	if config.Dashboard.Enabled {
+		users, err := htpasswd(config.Dashboard.Username, config.Dashboard.Password)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]interface{}{"name": dashboardAuthSecret, "namespace": Namespace},
+			"stringData": map[string]interface{}{"users": users},
+		})
+	}
+
+	return kubernetes.RenderManifest(objects)
+}
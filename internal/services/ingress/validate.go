@@ -0,0 +1,180 @@
+// Package ingress installs Traefik as the management cluster's ingress controller.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+
+	"github.com/butlerdotdev/butler/internal/services/loadbalancer"
+	"github.com/butlerdotdev/butler/pkg/models"
+)
+
+// entryPointName matches the port names the Traefik chart accepts.
+var entryPointName = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// reservedEntryPoints are the chart's internal ports, which are not exposed.
+var reservedEntryPoints = []string{"traefik", "metrics"}
+
+// defaultEntryPoints serve plain HTTP, redirected to HTTPS, on 80 and HTTPS on 443.
+var defaultEntryPoints = []models.IngressEntryPoint{
+	{Name: "web", Port: 8000, ExposedPort: 80, RedirectTo: "websecure"},
+	{Name: "websecure", Port: 8443, ExposedPort: 443, TLS: true},
+}
+
+// EntryPoints returns the configured entrypoints, or the defaults when none are set.
+func EntryPoints(config models.IngressConfig) []models.IngressEntryPoint {
+	if len(config.EntryPoints) == 0 {
+		return defaultEntryPoints
+	}
+	return config.EntryPoints
+}
+
+// Validate checks the ingress settings against the MetalLB pools Traefik takes its address from.
+func Validate(config models.IngressConfig, lb models.LoadBalancerConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+	if len(lb.Pools) == 0 {
+		return fmt.Errorf("ingress needs loadBalancer.pools to assign Traefik an address")
+	}
+
+	if config.Pool != "" && !hasPool(lb, config.Pool) {
+		return fmt.Errorf("ingress.pool %q is not a loadBalancer pool", config.Pool)
+	}
+	if config.LoadBalancerIP != "" {
+		if _, err := netip.ParseAddr(config.LoadBalancerIP); err != nil {
+			return fmt.Errorf("invalid ingress.loadBalancerIP %q: %w", config.LoadBalancerIP, err)
+		}
+		pool := loadbalancer.PoolFor(lb, config.LoadBalancerIP)
+		if pool == "" {
+			return fmt.Errorf("ingress.loadBalancerIP %s is not in any loadBalancer pool", config.LoadBalancerIP)
+		}
+		if config.Pool != "" && config.Pool != pool {
+			return fmt.Errorf("ingress.loadBalancerIP %s is in pool %s, not ingress.pool %s", config.LoadBalancerIP, pool, config.Pool)
+		}
+	}
+	if config.LoadBalancerIP == "" && config.Pool == "" && !autoAssigns(lb) {
+		return fmt.Errorf("no loadBalancer pool auto-assigns addresses; set ingress.pool or ingress.loadBalancerIP")
+	}
+
+	entryPoints := EntryPoints(config)
+	names := make(map[string]bool, len(entryPoints))
+	exposed := make(map[string]string, len(entryPoints))
+	for _, ep := range entryPoints {
+		if !entryPointName.MatchString(ep.Name) {
+			return fmt.Errorf("invalid ingress entrypoint name %q", ep.Name)
+		}
+		if contains(reservedEntryPoints, ep.Name) {
+			return fmt.Errorf("ingress entrypoint name %q is reserved by Traefik", ep.Name)
+		}
+		if names[ep.Name] {
+			return fmt.Errorf("duplicate ingress entrypoint %q", ep.Name)
+		}
+		names[ep.Name] = true
+
+		if ep.Port < 1 || ep.Port > 65535 {
+			return fmt.Errorf("ingress entrypoint %s has invalid port %d", ep.Name, ep.Port)
+		}
+		if ep.ExposedPort < 0 || ep.ExposedPort > 65535 {
+			return fmt.Errorf("ingress entrypoint %s has invalid exposedPort %d", ep.Name, ep.ExposedPort)
+		}
+		if ep.Protocol != "" && ep.Protocol != "TCP" && ep.Protocol != "UDP" {
+			return fmt.Errorf("ingress entrypoint %s has unsupported protocol %q; expected TCP or UDP", ep.Name, ep.Protocol)
+		}
+		key := fmt.Sprintf("%s/%d", protocol(ep), exposedPort(ep))
+		if other, ok := exposed[key]; ok {
+			return fmt.Errorf("ingress entrypoints %s and %s both expose %s", other, ep.Name, key)
+		}
+		exposed[key] = ep.Name
+	}
+	for _, ep := range entryPoints {
+		if ep.RedirectTo != "" && (!names[ep.RedirectTo] || ep.RedirectTo == ep.Name) {
+			return fmt.Errorf("ingress entrypoint %s redirects to unknown entrypoint %q", ep.Name, ep.RedirectTo)
+		}
+	}
+
+	cert := config.DefaultCertificate
+	if (cert.CertFile == "") != (cert.KeyFile == "") {
+		return fmt.Errorf("ingress.defaultCertificate needs both certFile and keyFile")
+	}
+
+	if config.Dashboard.Enabled {
+		if config.Dashboard.Host == "" {
+			return fmt.Errorf("ingress.dashboard.host is required when the dashboard is enabled")
+		}
+		if config.Dashboard.Username == "" || config.Dashboard.Password == "" {
+			return fmt.Errorf("ingress.dashboard.username and password are required when the dashboard is enabled")
+		}
+		if tlsEntryPoint(entryPoints) == "" {
+			return fmt.Errorf("the ingress dashboard needs an entrypoint with tls enabled")
+		}
+	}
+	return nil
+}
+
+// tlsEntryPoint returns the first entrypoint that terminates TLS.
+func tlsEntryPoint(entryPoints []models.IngressEntryPoint) string {
+	for _, ep := range entryPoints {
+		if ep.TLS {
+			return ep.Name
+		}
+	}
+	return ""
+}
+
+func protocol(ep models.IngressEntryPoint) string {
+	if ep.Protocol == "" {
+		return "TCP"
+	}
+	return ep.Protocol
+}
+
+func exposedPort(ep models.IngressEntryPoint) int {
+	if ep.ExposedPort == 0 {
+		return ep.Port
+	}
+	return ep.ExposedPort
+}
+
+func hasPool(lb models.LoadBalancerConfig, name string) bool {
+	for _, pool := range lb.Pools {
+		if pool.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func autoAssigns(lb models.LoadBalancerConfig) bool {
+	for _, pool := range lb.Pools {
+		if pool.AutoAssign == nil || *pool.AutoAssign {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+// Package kubevip renders the kube-vip manifests that announce the management cluster's control plane VIP.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubevip
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/butlerdotdev/butler/internal/services/controlplane"
+	"github.com/butlerdotdev/butler/internal/templates"
+	"github.com/butlerdotdev/butler/pkg/models"
+)
+
+//go:embed assets/rbac.yaml.tmpl
+var rbacManifest string
+
+//go:embed assets/daemonset.yaml.tmpl
+var daemonSetManifest string
+
+const (
+	// DefaultVersion is the kube-vip release the embedded manifests are written against.
+	DefaultVersion = "v0.8.9"
+
+	// DefaultImage is the kube-vip image repository; the tag is the configured version.
+	DefaultImage = "ghcr.io/kube-vip/kube-vip"
+
+	// Namespace is where kube-vip runs alongside the control plane.
+	Namespace = "kube-system"
+
+	// DaemonSetName is the name of the kube-vip DaemonSet.
+	DaemonSetName = "kube-vip-ds"
+
+	// BGPSecretName is the Secret holding the BGP peers, whose passwords stay out of the DaemonSet.
+	BGPSecretName = "kube-vip-bgp"
+)
+
+// Options are the values rendered into the kube-vip DaemonSet.
+type Options struct {
+	// Address is the control plane VIP.
+	Address string
+	// Interface is the host interface the VIP is announced on.
+	Interface string
+	// Version is the kube-vip release. Empty selects DefaultVersion.
+	Version string
+	// Image is the image repository, for mirrors in air-gapped installs. Empty selects DefaultImage.
+	Image string
+	// BGP advertises the VIP to BGP peers instead of announcing it with ARP when set.
+	BGP *models.BGPConfig
+	// Services has kube-vip also serve LoadBalancer Services. Off when MetalLB owns them.
+	Services bool
+}
+
+// OptionsFromConfig returns the Options for the configured VIP and kube-vip settings. The
+// interface is left as configured, which may be empty until it is detected.
+func OptionsFromConfig(config *models.BootstrapConfig) Options {
+	opts := Options{
+		Address:   config.ManagementCluster.Talos.ControlPlaneVIP,
+		Interface: config.ManagementCluster.KubeVip.Interface,
+		Version:   config.ManagementCluster.KubeVip.Version,
+		Image:     config.ManagementCluster.KubeVip.Image,
+		Services:  len(config.ManagementCluster.LoadBalancer.Pools) == 0,
+	}
+	if controlplane.Mode(config.ManagementCluster.Talos) == controlplane.ModeKubeVipBGP {
+		opts.BGP = &config.ManagementCluster.Talos.ControlPlaneHA.BGP
+	}
+	return opts
+}
+
+// RBAC renders the ServiceAccount, ClusterRole and ClusterRoleBinding kube-vip runs with.
+func RBAC() ([]byte, error) {
+	return render("kube-vip-rbac", rbacManifest, map[string]interface{}{
+		"Namespace": Namespace,
+	})
+}
+
+// DaemonSet renders the kube-vip DaemonSet, running on control plane nodes with leader election
+// for the control plane VIP and, unless disabled, LoadBalancer services. The VIP is announced with ARP on the
+// interface, or in BGP mode bound to the loopback and advertised with the interface's address as
+// router ID. In BGP mode the manifest starts with a Secret holding the peers and their passwords.
+func DaemonSet(opts Options) ([]byte, error) {
+	if net.ParseIP(opts.Address) == nil {
+		return nil, fmt.Errorf("invalid control plane VIP %q", opts.Address)
+	}
+	if opts.Interface == "" {
+		return nil, fmt.Errorf("no interface given for the control plane VIP")
+	}
+
+	version := opts.Version
+	if version == "" {
+		version = DefaultVersion
+	}
+	image := opts.Image
+	if image == "" {
+		image = DefaultImage
+	}
+
+	data := map[string]interface{}{
+		"Name":      DaemonSetName,
+		"Namespace": Namespace,
+		"Version":   version,
+		"Image":     fmt.Sprintf("%s:%s", image, version),
+		"Interface": opts.Interface,
+		"Address":   opts.Address,
+		"Services":  opts.Services,
+		"BGP":       nil,
+	}
+	if opts.BGP != nil {
+		// kube-vip binds the VIP to the loopback in BGP mode so the interface does not answer ARP for it.
+		data["Interface"] = "lo"
+		data["BGP"] = map[string]interface{}{
+			"LocalAS":         strconv.FormatUint(uint64(opts.BGP.LocalASN), 10),
+			"RouterID":        opts.BGP.RouterID,
+			"RouterInterface": opts.Interface,
+			"SecretName":      BGPSecretName,
+			"Peers":           bgpPeers(opts.BGP.Peers),
+		}
+	}
+
+	return render("kube-vip-daemonset", daemonSetManifest, data)
+}
+
+// bgpPeers formats peers the way kube-vip's bgp_peers expects: address:asn:password:multihop,...
+func bgpPeers(peers []models.BGPPeer) string {
+	formatted := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		formatted = append(formatted, fmt.Sprintf("%s:%d:%s:%t", peer.Address, peer.ASN, peer.Password, peer.Multihop))
+	}
+	return strings.Join(formatted, ",")
+}
+
+// render executes a manifest template with the given data.
+func render(name, manifest string, data map[string]interface{}) ([]byte, error) {
+	tmpl, err := template.New(name).Funcs(templates.FuncMap).Parse(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	return rendered.Bytes(), nil
+}
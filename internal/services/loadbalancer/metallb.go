@@ -0,0 +1,277 @@
+// Package loadbalancer installs MetalLB and the address pools it assigns to LoadBalancer Services.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/readiness"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/helm"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Namespace is where MetalLB runs. Its speakers need the privileged Pod Security level.
+	Namespace = "metallb-system"
+
+	// metalLBChartRepo and DefaultVersion pin the MetalLB Helm chart.
+	metalLBChartRepo = "https://metallb.github.io/metallb"
+	DefaultVersion   = "0.14.9"
+
+	// metalLBAPIVersion is the API version of MetalLB's custom resources.
+	metalLBAPIVersion = "metallb.io/v1beta1"
+
+	// testServiceName is the Service used to check that MetalLB assigns addresses.
+	testServiceName = "butler-loadbalancer-test"
+)
+
+// metalLBWorkloads must be rolled out before MetalLB's webhook accepts pools.
+var metalLBWorkloads = []readiness.Resource{
+	readiness.CRD("ipaddresspools.metallb.io"),
+	readiness.Deployment(Namespace, "metallb-controller").WithTimeout(5 * time.Minute),
+	readiness.DaemonSet(Namespace, "metallb-speaker").WithTimeout(5 * time.Minute),
+}
+
+// MetalLBInstaller installs MetalLB and configures its address pools.
+type MetalLBInstaller struct {
+	kube   *kubernetes.KubernetesAdapter
+	helm   *helm.HelmAdapter
+	logger *zap.Logger
+}
+
+// NewMetalLBInstaller constructs a new MetalLBInstaller instance.
+func NewMetalLBInstaller(kube *kubernetes.KubernetesAdapter, helm *helm.HelmAdapter, logger *zap.Logger) *MetalLBInstaller {
+	return &MetalLBInstaller{
+		kube:   kube,
+		helm:   helm,
+		logger: logger,
+	}
+}
+
+// Install installs or upgrades MetalLB, applies the pools and advertisements from config and waits
+// until a test Service is assigned an address.
+func (m *MetalLBInstaller) Install(ctx context.Context, server string, config models.LoadBalancerConfig) error {
+	kube := m.kube.WithServer(server)
+
+	namespace, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Namespace",
+		"metadata": map[string]interface{}{
+			"name": Namespace,
+			"labels": map[string]interface{}{
+				"pod-security.kubernetes.io/enforce": "privileged",
+				"pod-security.kubernetes.io/audit":   "privileged",
+				"pod-security.kubernetes.io/warn":    "privileged",
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render MetalLB namespace: %w", err)
+	}
+	if err := kube.Apply(ctx, namespace); err != nil {
+		return fmt.Errorf("failed to create namespace %s: %w", Namespace, err)
+	}
+
+	version := config.Version
+	if version == "" {
+		version = DefaultVersion
+	}
+	m.logger.Info("Installing MetalLB via Helm", zap.String("version", version))
+	if _, err := m.helm.UpgradeInstall(ctx, "metallb", helm.Chart{
+		Name:    "metallb",
+		Repo:    metalLBChartRepo,
+		Version: version,
+	}, helm.ReleaseOptions{
+		Namespace:  Namespace,
+		Kubeconfig: m.kube.Kubeconfig(),
+		Timeout:    5 * time.Minute,
+	}); err != nil {
+		return fmt.Errorf("failed to install MetalLB: %w", err)
+	}
+
+	if err := readiness.NewWaiter(kube, m.logger).Wait(ctx, metalLBWorkloads...); err != nil {
+		return fmt.Errorf("MetalLB did not become ready: %w", err)
+	}
+
+	manifest, err := renderResources(config)
+	if err != nil {
+		return err
+	}
+	if err := m.applyResources(ctx, kube, manifest); err != nil {
+		return err
+	}
+
+	if err := m.verify(ctx, kube, config); err != nil {
+		return err
+	}
+
+	m.logger.Info("MetalLB installed successfully", zap.Int("pools", len(config.Pools)))
+	return nil
+}
+
+// applyResources applies the pools and advertisements, retrying while MetalLB's validating
+// webhook is still starting to serve.
+func (m *MetalLBInstaller) applyResources(ctx context.Context, kube *kubernetes.KubernetesAdapter, manifest []byte) error {
+	deadline := time.Now().Add(2 * time.Minute)
+	for {
+		err := kube.Apply(ctx, manifest)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("failed to apply MetalLB address pools: %w", err)
+		}
+		m.logger.Debug("MetalLB webhook not ready yet, retrying", zap.Error(err))
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// verify creates a LoadBalancer Service and waits for MetalLB to assign it an address. The
+// Service has no endpoints and is deleted afterwards.
+func (m *MetalLBInstaller) verify(ctx context.Context, kube *kubernetes.KubernetesAdapter, config models.LoadBalancerConfig) error {
+	annotations := map[string]interface{}{}
+	if pool := testPool(config); pool != "" {
+		annotations["metallb.universe.tf/address-pool"] = pool
+	}
+	service, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata": map[string]interface{}{
+			"name":        testServiceName,
+			"namespace":   Namespace,
+			"annotations": annotations,
+		},
+		"spec": map[string]interface{}{
+			"type":  "LoadBalancer",
+			"ports": []interface{}{map[string]interface{}{"port": 80, "protocol": "TCP"}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render MetalLB test Service: %w", err)
+	}
+
+	m.logger.Info("Checking that MetalLB assigns LoadBalancer addresses")
+	if err := kube.Apply(ctx, service); err != nil {
+		return fmt.Errorf("failed to create MetalLB test Service: %w", err)
+	}
+	defer func() {
+		if err := kube.Delete(ctx, "v1", "Service", Namespace, testServiceName); err != nil {
+			m.logger.Warn("Failed to delete MetalLB test Service", zap.Error(err))
+		}
+	}()
+
+	ip, err := WaitForAddress(ctx, kube, Namespace, testServiceName, 2*time.Minute)
+	if err != nil {
+		return fmt.Errorf("%w; check the pools and the metallb-controller logs", err)
+	}
+	m.logger.Info("MetalLB assigned an address to the test Service", zap.String("ip", ip))
+	return nil
+}
+
+// WaitForAddress waits until MetalLB assigns an address to a LoadBalancer Service and returns it.
+func WaitForAddress(ctx context.Context, kube *kubernetes.KubernetesAdapter, namespace, name string, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		obj, err := kube.Get(ctx, "v1", "Service", namespace, name)
+		if err != nil {
+			return "", fmt.Errorf("failed to read Service %s/%s: %w", namespace, name, err)
+		}
+		ingress, _, _ := unstructured.NestedSlice(obj.Object, "status", "loadBalancer", "ingress")
+		for _, entry := range ingress {
+			if m, ok := entry.(map[string]interface{}); ok {
+				if ip, ok := m["ip"].(string); ok && ip != "" {
+					return ip, nil
+				}
+			}
+		}
+		time.Sleep(5 * time.Second)
+	}
+	return "", fmt.Errorf("no address was assigned to Service %s/%s within %s", namespace, name, timeout)
+}
+
+// testPool returns the pool the test Service must request, or "" when some pool auto-assigns.
+func testPool(config models.LoadBalancerConfig) string {
+	for _, pool := range config.Pools {
+		if pool.AutoAssign == nil || *pool.AutoAssign {
+			return ""
+		}
+	}
+	if len(config.Pools) > 0 {
+		return config.Pools[0].Name
+	}
+	return ""
+}
+
+// renderResources renders the IPAddressPools, their L2 or BGP advertisements and the BGP peers.
+func renderResources(config models.LoadBalancerConfig) ([]byte, error) {
+	var objects []map[string]interface{}
+
+	for _, peer := range config.BGPPeers {
+		spec := map[string]interface{}{
+			"peerAddress": peer.Address,
+			"peerASN":     peer.ASN,
+			"myASN":       peer.LocalASN,
+		}
+		if peer.Password != "" {
+			spec["password"] = peer.Password
+		}
+		if peer.EBGPMultiHop {
+			spec["ebgpMultiHop"] = true
+		}
+		objects = append(objects, metalLBObject("metallb.io/v1beta2", "BGPPeer", peer.Name, spec))
+	}
+
+	for _, pool := range config.Pools {
+		spec := map[string]interface{}{"addresses": pool.Addresses}
+		if pool.AutoAssign != nil {
+			spec["autoAssign"] = *pool.AutoAssign
+		}
+		objects = append(objects, metalLBObject(metalLBAPIVersion, "IPAddressPool", pool.Name, spec))
+
+		advertisement := map[string]interface{}{"ipAddressPools": []string{pool.Name}}
+		if Advertisement(pool) == AdvertisementBGP {
+			objects = append(objects, metalLBObject(metalLBAPIVersion, "BGPAdvertisement", pool.Name, advertisement))
+			continue
+		}
+		if len(pool.Interfaces) > 0 {
+			advertisement["interfaces"] = pool.Interfaces
+		}
+		objects = append(objects, metalLBObject(metalLBAPIVersion, "L2Advertisement", pool.Name, advertisement))
+	}
+
+	return kubernetes.RenderManifest(objects)
+}
+
+// metalLBObject builds a MetalLB custom resource in the MetalLB namespace.
+func metalLBObject(apiVersion, kind, name string, spec map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": Namespace,
+		},
+		"spec": spec,
+	}
+}
@@ -0,0 +1,191 @@
+// Package loadbalancer installs MetalLB and the address pools it assigns to LoadBalancer Services.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancer
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/butlerdotdev/butler/pkg/models"
+)
+
+// Advertisement modes of a pool.
+const (
+	AdvertisementL2  = "l2"
+	AdvertisementBGP = "bgp"
+)
+
+// addressRange is an inclusive range of addresses of one family.
+type addressRange struct {
+	first, last netip.Addr
+}
+
+func (r addressRange) overlaps(o addressRange) bool {
+	return r.first.Is4() == o.first.Is4() && r.first.Compare(o.last) <= 0 && o.first.Compare(r.last) <= 0
+}
+
+// Validate checks the pools and peers, and that no pool overlaps another or any of the reserved
+// networks. Reserved entries are CIDRs or single addresses, such as the pod and service CIDRs,
+// the control plane VIP and the node IPs.
+func Validate(config models.LoadBalancerConfig, reserved ...string) error {
+	reservedRanges := make([]addressRange, 0, len(reserved))
+	reservedNames := make([]string, 0, len(reserved))
+	for _, value := range reserved {
+		if value == "" {
+			continue
+		}
+		r, err := parseRange(value)
+		if err != nil {
+			return fmt.Errorf("invalid network %q: %w", value, err)
+		}
+		reservedRanges = append(reservedRanges, r)
+		reservedNames = append(reservedNames, value)
+	}
+
+	type poolRange struct {
+		pool    string
+		address string
+		addressRange
+	}
+	var pools []poolRange
+	names := map[string]bool{}
+	usesBGP := false
+
+	for i, pool := range config.Pools {
+		if pool.Name == "" {
+			return fmt.Errorf("loadBalancer.pools[%d] needs a name", i)
+		}
+		if names[pool.Name] {
+			return fmt.Errorf("duplicate loadBalancer pool %q", pool.Name)
+		}
+		names[pool.Name] = true
+
+		switch Advertisement(pool) {
+		case AdvertisementL2:
+		case AdvertisementBGP:
+			usesBGP = true
+			if len(pool.Interfaces) > 0 {
+				return fmt.Errorf("loadBalancer pool %s: interfaces only apply to %s pools", pool.Name, AdvertisementL2)
+			}
+		default:
+			return fmt.Errorf("loadBalancer pool %s: unsupported advertisement %q (must be %s or %s)", pool.Name, pool.Advertisement, AdvertisementL2, AdvertisementBGP)
+		}
+
+		if len(pool.Addresses) == 0 {
+			return fmt.Errorf("loadBalancer pool %s has no addresses", pool.Name)
+		}
+		for _, address := range pool.Addresses {
+			r, err := parseRange(address)
+			if err != nil {
+				return fmt.Errorf("loadBalancer pool %s: invalid address %q: %w", pool.Name, address, err)
+			}
+			for j, res := range reservedRanges {
+				if r.overlaps(res) {
+					return fmt.Errorf("loadBalancer pool %s address %s overlaps %s", pool.Name, address, reservedNames[j])
+				}
+			}
+			for _, other := range pools {
+				if r.overlaps(other.addressRange) {
+					return fmt.Errorf("loadBalancer pool %s address %s overlaps pool %s address %s", pool.Name, address, other.pool, other.address)
+				}
+			}
+			pools = append(pools, poolRange{pool: pool.Name, address: address, addressRange: r})
+		}
+	}
+
+	for i, peer := range config.BGPPeers {
+		if peer.Name == "" || peer.ASN == 0 || peer.LocalASN == 0 {
+			return fmt.Errorf("loadBalancer.bgpPeers[%d] needs a name, asn and localASN", i)
+		}
+		if _, err := netip.ParseAddr(peer.Address); err != nil {
+			return fmt.Errorf("loadBalancer BGP peer %s: invalid address %q", peer.Name, peer.Address)
+		}
+	}
+	if usesBGP && len(config.BGPPeers) == 0 {
+		return fmt.Errorf("loadBalancer pools with %s advertisement need loadBalancer.bgpPeers", AdvertisementBGP)
+	}
+	return nil
+}
+
+// Advertisement returns a pool's advertisement mode, defaulting to L2.
+func Advertisement(pool models.LoadBalancerPool) string {
+	if pool.Advertisement == "" {
+		return AdvertisementL2
+	}
+	return pool.Advertisement
+}
+
+// PoolFor returns the name of the pool containing address, or "" when no pool does.
+func PoolFor(config models.LoadBalancerConfig, address string) string {
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return ""
+	}
+	for _, pool := range config.Pools {
+		for _, value := range pool.Addresses {
+			r, err := parseRange(value)
+			if err == nil && r.overlaps(addressRange{first: addr, last: addr}) {
+				return pool.Name
+			}
+		}
+	}
+	return ""
+}
+
+// parseRange parses a CIDR, an "a-b" range or a single address.
+func parseRange(value string) (addressRange, error) {
+	if first, last, ok := strings.Cut(value, "-"); ok {
+		start, err := netip.ParseAddr(strings.TrimSpace(first))
+		if err != nil {
+			return addressRange{}, err
+		}
+		end, err := netip.ParseAddr(strings.TrimSpace(last))
+		if err != nil {
+			return addressRange{}, err
+		}
+		if start.Is4() != end.Is4() || end.Less(start) {
+			return addressRange{}, fmt.Errorf("range end must not be before its start")
+		}
+		return addressRange{first: start, last: end}, nil
+	}
+
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return addressRange{}, err
+		}
+		prefix = prefix.Masked()
+		return addressRange{first: prefix.Addr(), last: lastAddr(prefix)}, nil
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return addressRange{}, err
+	}
+	return addressRange{first: addr, last: addr}, nil
+}
+
+// lastAddr returns the highest address in a prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 0x80 >> (bit % 8)
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
@@ -0,0 +1,253 @@
+// Package machineconfig builds the Talos machine config patches Butler applies to its nodes.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package machineconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/butlerdotdev/butler/pkg/models"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ExtraDiskModeVolume has Talos partition, format and mount each extra disk.
+	ExtraDiskModeVolume = "volume"
+	// ExtraDiskModeRaw leaves extra disks unformatted so LINSTOR can build LVM or ZFS pools on them.
+	ExtraDiskModeRaw = "raw"
+
+	// maxExtraDisks bounds the extra disks per node to what the providers can attach.
+	maxExtraDisks = 25
+)
+
+// extraDiskProviders lists the providers that attach a node pool's extraDisks to its VMs.
+var extraDiskProviders = map[string]bool{
+	"nutanix": true,
+}
+
+// Documents is a list of Talos machine config documents, such as UserVolumeConfig, passed to
+// talosctl as a multi-document --config-patch alongside the v1alpha1 config.
+type Documents []map[string]interface{}
+
+// String renders the documents as a YAML stream.
+func (d Documents) String() (string, error) {
+	parts := make([]string, 0, len(d))
+	for _, doc := range d {
+		out, err := yaml.Marshal(doc)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal Talos config document: %w", err)
+		}
+		parts = append(parts, string(out))
+	}
+	return strings.Join(parts, "---\n"), nil
+}
+
+// InstallDisk returns a patch selecting the disk Talos installs to and whether it is wiped first.
+// Without an explicit disk or selector the disk chosen by talosctl gen config is kept.
+func InstallDisk(cfg models.InstallDiskConfig) Patch {
+	patch := Patch{
+		{Op: "replace", Path: "/machine/install/wipe", Value: cfg.Wipe},
+	}
+
+	if cfg.Disk != "" {
+		return append(patch, Operation{Op: "replace", Path: "/machine/install/disk", Value: cfg.Disk})
+	}
+
+	selector := map[string]string{}
+	if cfg.Size != "" {
+		selector["size"] = cfg.Size
+	}
+	if cfg.Model != "" {
+		selector["model"] = cfg.Model
+	}
+	if cfg.BusPath != "" {
+		selector["busPath"] = cfg.BusPath
+	}
+	if len(selector) == 0 {
+		return patch
+	}
+
+	// Talos prefers an explicit disk over a selector, so drop the generated default.
+	return append(patch,
+		Operation{Op: "remove", Path: "/machine/install/disk"},
+		Operation{Op: "add", Path: "/machine/install/diskSelector", Value: selector},
+	)
+}
+
+// ExtraDisks returns the volume documents declaring a node's extra disks, given their sizes such
+// as "100GB". Each disk becomes volume pool<n>, placed on a non-system disk of exactly that size
+// rather than on a guessed device name. In volume mode it is a UserVolumeConfig mounted at
+// /var/mnt/pool<n>; in raw mode a RawVolumeConfig partition left unformatted for LINSTOR.
+// Both need Talos v1.11 or later.
+func ExtraDisks(sizes []string, mode string) (Documents, error) {
+	kind := "UserVolumeConfig"
+	switch mode {
+	case "", ExtraDiskModeVolume:
+	case ExtraDiskModeRaw:
+		kind = "RawVolumeConfig"
+	default:
+		return nil, fmt.Errorf("unsupported extraDiskMode %q, expected %q or %q", mode, ExtraDiskModeVolume, ExtraDiskModeRaw)
+	}
+	if len(sizes) > maxExtraDisks {
+		return nil, fmt.Errorf("too many extra disks: %d", len(sizes))
+	}
+
+	docs := make(Documents, 0, len(sizes))
+	for i, size := range sizes {
+		gib, err := diskGiB(size)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, map[string]interface{}{
+			"apiVersion": "v1alpha1",
+			"kind":       kind,
+			"name":       extraDiskVolume(i),
+			"provisioning": map[string]interface{}{
+				"diskSelector": map[string]string{
+					"match": fmt.Sprintf("!system_disk && disk.size == %du * GiB", gib),
+				},
+				// Requiring more than half the disk keeps two equally sized pools off the same disk.
+				"minSize": fmt.Sprintf("%dMiB", gib*1024*3/4),
+				"grow":    true,
+			},
+		})
+	}
+	return docs, nil
+}
+
+// ExtraDiskDevices returns the stable device paths of a node's raw extra disk partitions, which
+// Talos labels r-pool<n>.
+func ExtraDiskDevices(count int) []string {
+	devices := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		devices = append(devices, "/dev/disk/by-partlabel/r-"+extraDiskVolume(i))
+	}
+	return devices
+}
+
+// extraDiskVolume names the Talos volume of the i-th extra disk.
+func extraDiskVolume(i int) string {
+	return fmt.Sprintf("pool%d", i+1)
+}
+
+// diskGiB parses an extra disk size such as "100GB". Providers allocate GB as GiB.
+func diskGiB(size string) (int, error) {
+	gib, err := strconv.Atoi(strings.TrimSuffix(size, "GB"))
+	if err != nil || gib <= 0 || !strings.HasSuffix(size, "GB") {
+		return 0, fmt.Errorf("invalid extra disk size %q, expected e.g. \"100GB\"", size)
+	}
+	return gib, nil
+}
+
+// Validate checks the node pools' disk configuration: extraDisks are only attached by some
+// providers, and pools sharing a role share one role patch so their disks must match.
+func Validate(nodes []models.NodeConfig, provider string) error {
+	byRole := make(map[string]models.NodeConfig, len(nodes))
+	for _, node := range nodes {
+		if _, ok := rolePatchFlags[node.Role]; !ok {
+			return fmt.Errorf("unsupported node role %q", node.Role)
+		}
+		if len(node.ExtraDisks) > 0 && !extraDiskProviders[provider] {
+			return fmt.Errorf("extraDisks on %s nodes are not supported with the %s provider", node.Role, provider)
+		}
+		if _, err := ForNode(node); err != nil {
+			return err
+		}
+		if first, ok := byRole[node.Role]; ok && !sameDisks(first, node) {
+			return fmt.Errorf("%s node pools have differing installDisk, extraDisks or extraDiskMode; Talos config is generated per role so they must match", node.Role)
+		}
+		byRole[node.Role] = node
+	}
+	return nil
+}
+
+// sameDisks reports whether two node pools render the same role patch.
+func sameDisks(a, b models.NodeConfig) bool {
+	return a.InstallDisk == b.InstallDisk &&
+		reflect.DeepEqual(a.ExtraDisks, b.ExtraDisks) &&
+		extraDiskMode(a.ExtraDiskMode) == extraDiskMode(b.ExtraDiskMode)
+}
+
+// extraDiskMode returns mode with the default applied.
+func extraDiskMode(mode string) string {
+	if mode == "" {
+		return ExtraDiskModeVolume
+	}
+	return mode
+}
+
+// RolePatch is the role-specific part of a node pool's Talos config.
+type RolePatch struct {
+	Patch     Patch
+	Documents Documents
+}
+
+// ForNode returns the role-specific patch for a node pool: its install disk and extra disk volumes.
+func ForNode(node models.NodeConfig) (RolePatch, error) {
+	docs, err := ExtraDisks(node.ExtraDisks, node.ExtraDiskMode)
+	if err != nil {
+		return RolePatch{}, fmt.Errorf("invalid disk configuration for role %s: %w", node.Role, err)
+	}
+	return RolePatch{Patch: InstallDisk(node.InstallDisk), Documents: docs}, nil
+}
+
+// rolePatchFlags maps node roles to the talosctl gen config flag that patches only that role.
+var rolePatchFlags = map[string]string{
+	"control-plane": "--config-patch-control-plane",
+	"worker":        "--config-patch-worker",
+}
+
+// RolePatchArgs returns the talosctl gen config arguments applying ForNode to each role. Every
+// role gets a single patch, so pools sharing a role must have the same disks.
+func RolePatchArgs(nodes []models.NodeConfig) ([]string, error) {
+	var args []string
+	seen := make(map[string]models.NodeConfig, len(rolePatchFlags))
+	for _, node := range nodes {
+		flag, ok := rolePatchFlags[node.Role]
+		if !ok {
+			return nil, fmt.Errorf("unsupported node role %q", node.Role)
+		}
+		if first, ok := seen[node.Role]; ok {
+			if !sameDisks(first, node) {
+				return nil, fmt.Errorf("%s node pools have differing installDisk, extraDisks or extraDiskMode", node.Role)
+			}
+			continue
+		}
+		seen[node.Role] = node
+
+		patch, err := ForNode(node)
+		if err != nil {
+			return nil, err
+		}
+		rendered, err := patch.Patch.String()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, flag, rendered)
+
+		if len(patch.Documents) > 0 {
+			docs, err := patch.Documents.String()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, flag, docs)
+		}
+	}
+	return args, nil
+}
@@ -0,0 +1,87 @@
+// Package machineconfig builds the Talos machine config patches Butler applies to its nodes.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package machineconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/talos"
+	talosModels "github.com/butlerdotdev/butler/pkg/adapters/platforms/talos/models"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+)
+
+// Customized reports whether the Talos config asks for extensions or kernel args, i.e. whether
+// the nodes need an Image Factory image rather than the stock one.
+func Customized(config models.TalosConfig) bool {
+	return len(config.Extensions) > 0 || len(config.KernelArgs) > 0
+}
+
+// ValidateImages checks that the Talos version the installer image is pinned to is set. Without
+// it the nodes would install the stock image and silently lose the configured extensions.
+func ValidateImages(config models.TalosConfig) error {
+	if config.Version == "" {
+		return fmt.Errorf("talos.version is required to pin the Talos installer image")
+	}
+	return nil
+}
+
+// ResolveImages returns the installer image and ISO references for the given Talos version.
+// When extensions or kernel args are configured, the matching schematic is registered with the
+// Image Factory; otherwise the stock Sidero Labs artifacts are returned without any network call.
+func ResolveImages(ctx context.Context, config models.TalosConfig, version string, logger *zap.Logger) (talosModels.ImageReferences, error) {
+	if version == "" {
+		return talosModels.ImageReferences{}, fmt.Errorf("talos.version is required to resolve Talos images")
+	}
+
+	if !Customized(config) {
+		return talosModels.ImageReferences{
+			Version:        version,
+			InstallerImage: fmt.Sprintf("ghcr.io/siderolabs/installer:%s", version),
+			ISOURL:         fmt.Sprintf("https://github.com/siderolabs/talos/releases/download/%s/metal-amd64.iso", version),
+		}, nil
+	}
+
+	factory := talos.NewImageFactoryClient(config.ImageFactoryURL, logger)
+	schematic := talosModels.Schematic{
+		Customization: talosModels.SchematicCustomization{
+			ExtraKernelArgs: config.KernelArgs,
+			SystemExtensions: talosModels.SystemExtensions{
+				OfficialExtensions: config.Extensions,
+			},
+		},
+	}
+
+	id, err := factory.CreateSchematic(ctx, schematic)
+	if err != nil {
+		return talosModels.ImageReferences{}, fmt.Errorf("failed to register Talos schematic: %w", err)
+	}
+
+	refs, err := factory.References(id, version)
+	if err != nil {
+		return talosModels.ImageReferences{}, err
+	}
+
+	logger.Info("Resolved Talos images",
+		zap.String("schematicID", refs.SchematicID),
+		zap.String("installerImage", refs.InstallerImage),
+		zap.String("isoURL", refs.ISOURL),
+	)
+	return refs, nil
+}
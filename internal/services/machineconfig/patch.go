@@ -0,0 +1,91 @@
+// Package machineconfig builds the Talos machine config patches Butler applies to its nodes.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package machineconfig
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Operation is a single RFC 6902 JSON patch operation against a Talos machine config.
+type Operation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Patch is an ordered list of operations, passed to talosctl via --config-patch.
+type Patch []Operation
+
+// String renders the patch as the JSON document talosctl expects.
+func (p Patch) String() (string, error) {
+	out, err := json.Marshal(p)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal Talos config patch: %w", err)
+	}
+	return string(out), nil
+}
+
+// InstallImage returns a patch pinning the installer image Talos installs and upgrades from.
+func InstallImage(image string) Patch {
+	return Patch{
+		{Op: "replace", Path: "/machine/install/image", Value: image},
+	}
+}
+
+// TalosAPIAccess returns a patch allowing pods in the given namespaces to obtain Talos API
+// credentials limited to the given roles through talos.dev/v1alpha1 ServiceAccount resources.
+func TalosAPIAccess(roles, namespaces []string) Patch {
+	return Patch{
+		{
+			Op:   "add",
+			Path: "/machine/features/kubernetesTalosAPIAccess",
+			Value: map[string]interface{}{
+				"enabled":                     true,
+				"allowedRoles":                roles,
+				"allowedKubernetesNamespaces": namespaces,
+			},
+		},
+	}
+}
+
+// SharedVIP returns a control plane patch that has Talos announce vip from whichever control
+// plane node is the etcd leader. Without an interface name the VIP goes on the node's physical
+// NIC, which keeps its DHCP lease as before.
+func SharedVIP(vip, iface string) Patch {
+	device := map[string]interface{}{
+		"dhcp": true,
+		"vip":  map[string]interface{}{"ip": vip},
+	}
+	if iface != "" {
+		device["interface"] = iface
+	} else {
+		device["deviceSelector"] = map[string]interface{}{"physical": true}
+	}
+
+	return Patch{
+		{Op: "add", Path: "/machine/network/interfaces", Value: []interface{}{device}},
+	}
+}
+
+// ClusterNetwork returns a patch setting the pod and service networks the cluster is created with.
+func ClusterNetwork(podCIDR, serviceCIDR string) Patch {
+	return Patch{
+		{Op: "replace", Path: "/cluster/network/podSubnets", Value: []string{podCIDR}},
+		{Op: "replace", Path: "/cluster/network/serviceSubnets", Value: []string{serviceCIDR}},
+	}
+}
@@ -0,0 +1,155 @@
+// Package network resolves and validates the management cluster's pod network settings.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/butlerdotdev/butler/pkg/models"
+)
+
+const (
+	// DefaultPodCIDR is the pod network used when none is configured.
+	DefaultPodCIDR = "10.16.0.0/16"
+	// DefaultServiceCIDR is the service network used when none is configured.
+	DefaultServiceCIDR = "10.96.0.0/12"
+	// DefaultJoinCIDR is the network connecting nodes to the overlay when none is configured.
+	DefaultJoinCIDR = "100.64.0.0/16"
+	// DefaultTunnelType is the overlay encapsulation used when none is configured.
+	DefaultTunnelType = "geneve"
+)
+
+// tunnelTypes are the overlay encapsulations Kube-OVN supports.
+var tunnelTypes = []string{"geneve", "vxlan", "stt"}
+
+// WithDefaults returns the configuration with Butler's defaults filled in for empty fields.
+func WithDefaults(config models.NetworkConfig) models.NetworkConfig {
+	if config.PodCIDR == "" {
+		config.PodCIDR = DefaultPodCIDR
+	}
+	if config.ServiceCIDR == "" {
+		config.ServiceCIDR = DefaultServiceCIDR
+	}
+	if config.JoinCIDR == "" {
+		config.JoinCIDR = DefaultJoinCIDR
+	}
+	if config.TunnelType == "" {
+		config.TunnelType = DefaultTunnelType
+	}
+	return config
+}
+
+// Validate checks the network settings and that the pod, service and join networks neither
+// overlap each other nor the node networks, given as CIDRs or single addresses.
+func Validate(config models.NetworkConfig, nodeNetworks ...string) error {
+	config = WithDefaults(config)
+
+	if !contains(tunnelTypes, config.TunnelType) {
+		return fmt.Errorf("unsupported network.tunnelType %q; expected one of %s", config.TunnelType, strings.Join(tunnelTypes, ", "))
+	}
+	if config.MTU < 0 {
+		return fmt.Errorf("network.mtu must not be negative")
+	}
+
+	clusterNetworks := []struct {
+		name string
+		cidr string
+	}{
+		{"network.podCIDR", config.PodCIDR},
+		{"network.serviceCIDR", config.ServiceCIDR},
+		{"network.joinCIDR", config.JoinCIDR},
+	}
+
+	parsed := make([]*net.IPNet, len(clusterNetworks))
+	for i, network := range clusterNetworks {
+		_, ipNet, err := net.ParseCIDR(network.cidr)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", network.name, network.cidr, err)
+		}
+		parsed[i] = ipNet
+	}
+
+	for i := range parsed {
+		for j := i + 1; j < len(parsed); j++ {
+			if overlaps(parsed[i], parsed[j]) {
+				return fmt.Errorf("%s %s overlaps %s %s", clusterNetworks[i].name, parsed[i], clusterNetworks[j].name, parsed[j])
+			}
+		}
+	}
+
+	for _, node := range nodeNetworks {
+		if node == "" {
+			continue
+		}
+		nodeNet, err := parseNetwork(node)
+		if err != nil {
+			return err
+		}
+		for i, ipNet := range parsed {
+			if overlaps(ipNet, nodeNet) {
+				return fmt.Errorf("%s %s overlaps the node network %s", clusterNetworks[i].name, ipNet, node)
+			}
+		}
+	}
+	return nil
+}
+
+// Gateway returns the first usable address of a CIDR, which Kube-OVN uses as the subnet gateway.
+func Gateway(cidr string) (string, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+	}
+	gateway := make(net.IP, len(ipNet.IP))
+	copy(gateway, ipNet.IP)
+	gateway[len(gateway)-1]++
+	return gateway.String(), nil
+}
+
+// parseNetwork parses a CIDR or a single address, treated as a host network.
+func parseNetwork(value string) (*net.IPNet, error) {
+	if _, ipNet, err := net.ParseCIDR(value); err == nil {
+		return ipNet, nil
+	}
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid node network %q", value)
+	}
+	bits := 8 * net.IPv6len
+	if ip.To4() != nil {
+		ip = ip.To4()
+		bits = 8 * net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+// overlaps reports whether two networks share any address.
+func overlaps(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// contains reports whether values contains value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+// Package readiness waits for Kubernetes workloads and custom resources to become ready.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// maxEvents bounds how many of the most recent events are reported for a resource.
+const maxEvents = 10
+
+// fail logs the events and failing pods of a resource that did not become ready, and returns err
+// with a short summary of what was found appended.
+func (w *Waiter) fail(resource Resource, obj *unstructured.Unstructured, err error) error {
+	// The wait context is already done; diagnostics get their own short budget.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var summary []string
+
+	events, evErr := w.kube.ListEvents(ctx, resource.Namespace, resource.Kind, resource.Name)
+	if evErr != nil {
+		w.logger.Warn("Failed to collect events", zap.Stringer("resource", resource), zap.Error(evErr))
+	}
+	if len(events) > maxEvents {
+		events = events[len(events)-maxEvents:]
+	}
+	for _, event := range events {
+		w.logger.Warn("Resource event",
+			zap.Stringer("resource", resource),
+			zap.String("type", event.Type),
+			zap.String("reason", event.Reason),
+			zap.String("message", event.Message),
+			zap.Int32("count", event.Count),
+		)
+		if event.Type == corev1.EventTypeWarning {
+			summary = append(summary, fmt.Sprintf("event %s: %s", event.Reason, event.Message))
+		}
+	}
+
+	for _, pod := range w.failingPods(ctx, resource, obj) {
+		w.logger.Warn("Pod not ready", zap.Stringer("resource", resource), zap.String("pod", pod))
+		summary = append(summary, "pod "+pod)
+	}
+
+	if len(summary) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w (%s)", err, strings.Join(summary, "; "))
+}
+
+// failingPods describes the pods selected by a workload that are not ready.
+func (w *Waiter) failingPods(ctx context.Context, resource Resource, obj *unstructured.Unstructured) []string {
+	if obj == nil {
+		return nil
+	}
+	rawSelector, found, _ := unstructured.NestedMap(obj.Object, "spec", "selector")
+	if !found {
+		return nil
+	}
+
+	var labelSelector metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawSelector, &labelSelector); err != nil {
+		return nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+	if err != nil {
+		return nil
+	}
+
+	pods, err := w.kube.ListPods(ctx, resource.Namespace, selector.String())
+	if err != nil {
+		w.logger.Warn("Failed to collect pods", zap.Stringer("resource", resource), zap.Error(err))
+		return nil
+	}
+
+	var failing []string
+	for _, pod := range pods {
+		if reason := podProblem(pod); reason != "" {
+			failing = append(failing, fmt.Sprintf("%s on %s: %s", pod.Name, pod.Spec.NodeName, reason))
+		}
+	}
+	return failing
+}
+
+// podProblem explains why a pod is not ready, or returns an empty string if it is.
+func podProblem(pod corev1.Pod) string {
+	if pod.Status.Phase == corev1.PodSucceeded {
+		return ""
+	}
+	if pod.Status.Phase == corev1.PodPending && pod.Spec.NodeName == "" {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+				return fmt.Sprintf("unschedulable: %s", condition.Message)
+			}
+		}
+	}
+
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		if cs.Ready {
+			continue
+		}
+		switch {
+		case cs.State.Waiting != nil && cs.State.Waiting.Reason != "":
+			return fmt.Sprintf("container %s %s: %s (restarts: %d)", cs.Name, cs.State.Waiting.Reason, cs.State.Waiting.Message, cs.RestartCount)
+		case cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0:
+			return fmt.Sprintf("container %s terminated with %s (exit code %d)", cs.Name, cs.State.Terminated.Reason, cs.State.Terminated.ExitCode)
+		}
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status != corev1.ConditionTrue {
+			return fmt.Sprintf("phase %s, not ready", pod.Status.Phase)
+		}
+	}
+	return ""
+}
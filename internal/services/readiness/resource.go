@@ -0,0 +1,79 @@
+// Package readiness waits for Kubernetes workloads and custom resources to become ready.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readiness
+
+import (
+	"fmt"
+	"time"
+)
+
+// Resource identifies an object to wait for and what "ready" means for it.
+type Resource struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+	// Condition, when set, waits for this status condition to be True. Otherwise the object must
+	// reach the kstatus Current status, which covers rollouts, observedGeneration and Ready conditions.
+	Condition string
+	// Timeout overrides the waiter's default timeout for this resource.
+	Timeout time.Duration
+}
+
+// WithTimeout returns a copy of the resource with its own timeout.
+func (r Resource) WithTimeout(timeout time.Duration) Resource {
+	r.Timeout = timeout
+	return r
+}
+
+// String describes the resource for logs and errors, e.g. "Deployment kube-system/coredns".
+func (r Resource) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s %s", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+// Deployment waits for a Deployment rollout to complete.
+func Deployment(namespace, name string) Resource {
+	return Resource{APIVersion: "apps/v1", Kind: "Deployment", Namespace: namespace, Name: name}
+}
+
+// DaemonSet waits for a DaemonSet rollout to complete on every scheduled node.
+func DaemonSet(namespace, name string) Resource {
+	return Resource{APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: namespace, Name: name}
+}
+
+// CRD waits for a CustomResourceDefinition, e.g. "helmreleases.helm.toolkit.fluxcd.io", to be Established.
+func CRD(name string) Resource {
+	return Resource{
+		APIVersion: "apiextensions.k8s.io/v1",
+		Kind:       "CustomResourceDefinition",
+		Name:       name,
+		Condition:  "Established",
+	}
+}
+
+// Kustomization waits for a Flux Kustomization to be Ready.
+func Kustomization(namespace, name string) Resource {
+	return Resource{APIVersion: "kustomize.toolkit.fluxcd.io/v1", Kind: "Kustomization", Namespace: namespace, Name: name}
+}
+
+// HelmRelease waits for a Flux HelmRelease to be Ready.
+func HelmRelease(namespace, name string) Resource {
+	return Resource{APIVersion: "helm.toolkit.fluxcd.io/v2", Kind: "HelmRelease", Namespace: namespace, Name: name}
+}
@@ -0,0 +1,136 @@
+// Package readiness waits for Kubernetes workloads and custom resources to become ready.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+)
+
+// DefaultTimeout is used for resources that do not set their own timeout.
+const DefaultTimeout = 5 * time.Minute
+
+// pollInterval is how often resources are re-checked.
+const pollInterval = 5 * time.Second
+
+// Waiter polls resources until they are ready, and collects diagnostics when they are not.
+type Waiter struct {
+	kube   *kubernetes.KubernetesAdapter
+	logger *zap.Logger
+}
+
+// NewWaiter creates a new Waiter.
+func NewWaiter(kube *kubernetes.KubernetesAdapter, logger *zap.Logger) *Waiter {
+	return &Waiter{kube: kube, logger: logger}
+}
+
+// Wait waits for each resource in turn and stops at the first one that fails or times out.
+func (w *Waiter) Wait(ctx context.Context, resources ...Resource) error {
+	for _, resource := range resources {
+		if err := w.waitFor(ctx, resource); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitFor waits for a single resource within its timeout.
+func (w *Waiter) waitFor(ctx context.Context, resource Resource) error {
+	timeout := resource.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	w.logger.Info("Waiting for resource to become ready", zap.Stringer("resource", resource), zap.Duration("timeout", timeout))
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var obj *unstructured.Unstructured
+	lastReason := "not checked yet"
+	for {
+		var ready, failed bool
+		var err error
+		obj, err = w.kube.Get(ctx, resource.APIVersion, resource.Kind, resource.Namespace, resource.Name)
+		switch {
+		case apierrors.IsNotFound(err):
+			lastReason = "not found"
+		case err != nil:
+			lastReason = err.Error()
+		default:
+			ready, failed, lastReason = evaluate(resource, obj)
+		}
+
+		if ready {
+			w.logger.Info("Resource is ready", zap.Stringer("resource", resource))
+			return nil
+		}
+		if failed {
+			return w.fail(resource, obj, fmt.Errorf("%s failed: %s", resource, lastReason))
+		}
+
+		w.logger.Debug("Resource not ready yet", zap.Stringer("resource", resource), zap.String("reason", lastReason))
+		select {
+		case <-ctx.Done():
+			return w.fail(resource, obj, fmt.Errorf("timed out after %s waiting for %s: %s", timeout, resource, lastReason))
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// evaluate reports whether an object is ready, has failed, and why it is not ready yet.
+func evaluate(resource Resource, obj *unstructured.Unstructured) (ready, failed bool, reason string) {
+	if resource.Condition != "" {
+		return evaluateCondition(obj, resource.Condition)
+	}
+
+	result, err := status.Compute(obj)
+	if err != nil {
+		return false, false, fmt.Sprintf("failed to compute status: %v", err)
+	}
+	switch result.Status {
+	case status.CurrentStatus:
+		return true, false, ""
+	case status.FailedStatus:
+		return false, true, result.Message
+	default:
+		return false, false, fmt.Sprintf("%s: %s", result.Status, result.Message)
+	}
+}
+
+// evaluateCondition checks a single status condition on an object.
+func evaluateCondition(obj *unstructured.Unstructured, conditionType string) (ready, failed bool, reason string) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != conditionType {
+			continue
+		}
+		if condition["status"] == "True" {
+			return true, false, ""
+		}
+		return false, false, fmt.Sprintf("condition %s is %v: %v", conditionType, condition["status"], condition["message"])
+	}
+	return false, false, fmt.Sprintf("condition %s not reported yet", conditionType)
+}
@@ -0,0 +1,399 @@
+// Package scale provides services for adding and removing management cluster nodes after bootstrap.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/mappers"
+	"github.com/butlerdotdev/butler/internal/services/cluster"
+	"github.com/butlerdotdev/butler/pkg/adapters/exec"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubectl"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/talos"
+	"github.com/butlerdotdev/butler/pkg/adapters/providers"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+)
+
+const (
+	roleControlPlane = "control-plane"
+	roleWorker       = "worker"
+)
+
+// roleLabels are the role labels bootstrap applies in cni.KubeOvnInstaller.LabelNodes. They are
+// harmless on clusters running another CNI.
+var roleLabels = map[string]map[string]string{
+	roleControlPlane: {"kube-ovn/role": "master"},
+	roleWorker:       {"node-role.kubernetes.io/worker": ""},
+}
+
+// machineConfigs are the machine config files talosctl gen config wrote for each role.
+var machineConfigs = map[string]string{
+	roleControlPlane: "controlplane.yaml",
+	roleWorker:       "worker.yaml",
+}
+
+// Options controls a scale operation.
+type Options struct {
+	// Role is the node role to scale, "control-plane" or "worker".
+	Role string
+	// Count is the desired number of nodes with that role.
+	Count int
+	// VMTimeout bounds how long a new VM may take to become healthy with an IP, and how long a
+	// removed VM may take to power off.
+	VMTimeout time.Duration
+	// NodeTimeout bounds how long a new node may take to join the cluster and become Ready.
+	NodeTimeout time.Duration
+	// DrainTimeout bounds how long a single node drain may take when scaling down.
+	DrainTimeout time.Duration
+}
+
+// ScaleService adds and removes management cluster nodes of a role.
+type ScaleService struct {
+	logger    *zap.Logger
+	provider  providers.ProviderInterface
+	nodes     *cluster.NodeOperator
+	talos     *cluster.TalosOperator
+	outputDir string
+	config    *models.BootstrapConfig
+}
+
+// NewScaleService initializes a ScaleService from the bootstrap configuration.
+func NewScaleService(ctx context.Context, config *models.BootstrapConfig, logger *zap.Logger) (*ScaleService, error) {
+	logger.Info("Initializing ScaleService")
+
+	provider, err := providers.NewProviderFactory(
+		ctx,
+		config.ManagementCluster.Provider,
+		mappers.NewMapping(config.ManagementCluster.Provider, config.ManagementCluster),
+		logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize provider: %w", err)
+	}
+
+	execAdapter := exec.NewClient(logger)
+
+	talosAdapter, err := platforms.GetPlatformAdapter("talos", execAdapter, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Talos adapter: %w", err)
+	}
+	talosConcrete, ok := talosAdapter.(*talos.TalosAdapter)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert TalosAdapter type")
+	}
+
+	kubectlAdapter, err := platforms.GetPlatformAdapter("kubectl", execAdapter, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Kubectl adapter: %w", err)
+	}
+	kubectlConcrete, ok := kubectlAdapter.(*kubectl.KubectlAdapter)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert KubectlAdapter type")
+	}
+
+	return &ScaleService{
+		logger:    logger,
+		provider:  provider,
+		nodes:     cluster.NewNodeOperator(kubectlConcrete, cluster.KubeconfigPath(config.ManagementCluster.Talos), logger),
+		talos:     cluster.NewTalosOperator(talosConcrete, cluster.TalosconfigPath(config.ManagementCluster.Talos), logger),
+		outputDir: cluster.OutputDir(config.ManagementCluster.Talos),
+		config:    config,
+	}, nil
+}
+
+// Scale brings the number of nodes with the given role to opts.Count, creating or removing
+// VMs one at a time. Nodes are added and removed at the end of the `<cluster>-<role>-<n>` sequence.
+func (s *ScaleService) Scale(ctx context.Context, opts Options) error {
+	nodeConfig, err := s.nodeConfig(opts.Role)
+	if err != nil {
+		return err
+	}
+	if opts.Count < 0 {
+		return fmt.Errorf("count must not be negative")
+	}
+	if opts.Role == roleControlPlane && opts.Count < 1 {
+		return fmt.Errorf("the management cluster needs at least one control plane node")
+	}
+
+	current, err := s.countVMs(opts.Role)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Scaling management cluster nodes",
+		zap.String("role", opts.Role),
+		zap.Int("current", current),
+		zap.Int("desired", opts.Count),
+	)
+
+	if opts.Role == roleControlPlane && opts.Count%2 == 0 {
+		s.logger.Warn("An even number of control plane nodes does not improve etcd fault tolerance", zap.Int("count", opts.Count))
+	}
+
+	for i := current + 1; i <= opts.Count; i++ {
+		if err := s.addNode(ctx, nodeConfig, i, opts); err != nil {
+			return err
+		}
+	}
+	for i := current; i > opts.Count; i-- {
+		if err := s.removeNode(ctx, opts.Role, i, opts); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("Scaling complete", zap.String("role", opts.Role), zap.Int("count", opts.Count))
+	return nil
+}
+
+// nodeConfig returns the sizing configured for a role.
+func (s *ScaleService) nodeConfig(role string) (models.NodeConfig, error) {
+	if _, ok := machineConfigs[role]; !ok {
+		return models.NodeConfig{}, fmt.Errorf("unsupported role %q, expected %q or %q", role, roleControlPlane, roleWorker)
+	}
+	for _, node := range s.config.ManagementCluster.Nodes {
+		if node.Role == role {
+			return node, nil
+		}
+	}
+	return models.NodeConfig{}, fmt.Errorf("no managementCluster.nodes entry for role %q", role)
+}
+
+// vmName returns the name bootstrap gives the n-th VM of a role.
+func (s *ScaleService) vmName(role string, n int) string {
+	return fmt.Sprintf("%s-%s-%d", s.config.ManagementCluster.Name, role, n)
+}
+
+// countVMs counts the existing VMs of a role by probing the provider for consecutive names.
+func (s *ScaleService) countVMs(role string) (int, error) {
+	count := 0
+	for {
+		_, err := s.provider.GetVMStatus(s.vmName(role, count+1))
+		if errors.Is(err, models.ErrVMNotFound) {
+			return count, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to look up existing %s VMs: %w", role, err)
+		}
+		count++
+	}
+}
+
+// addNode creates the n-th VM of a role, applies the role's machine config and waits for the
+// node to join the cluster.
+func (s *ScaleService) addNode(ctx context.Context, node models.NodeConfig, n int, opts Options) error {
+	name := s.vmName(node.Role, n)
+	s.logger.Info("Adding node", zap.String("name", name), zap.String("role", node.Role))
+
+	vmConfig := models.VMConfig{
+		Name:               name,
+		Role:               node.Role,
+		CPU:                node.CPU,
+		RAM:                node.RAM,
+		Disk:               node.Disk,
+		ExtraDisks:         node.ExtraDisks,
+		IsoUUID:            node.IsoUUID,
+		SubnetUUID:         s.config.ManagementCluster.Nutanix.SubnetUUID,
+		ClusterUUID:        s.config.ManagementCluster.Nutanix.ClusterUUID,
+		StorageLocation:    s.config.ManagementCluster.Proxmox.StorageLocation,
+		AvailableVMIdStart: s.config.ManagementCluster.Proxmox.AvailableVMIdStart,
+		AvailableVMIdEnd:   s.config.ManagementCluster.Proxmox.AvailableVMIdEnd,
+	}
+	if _, err := s.provider.CreateVM(vmConfig); err != nil {
+		return fmt.Errorf("failed to create VM %s: %w", name, err)
+	}
+
+	ip, err := s.waitForVM(name, opts.VMTimeout)
+	if err != nil {
+		return err
+	}
+
+	// The new VM boots the Talos ISO into maintenance mode and has no PKI yet.
+	configFile := filepath.Join(s.outputDir, machineConfigs[node.Role])
+	if err := s.talos.ApplyConfig(ctx, ip, configFile, true); err != nil {
+		return err
+	}
+
+	joined, err := s.nodes.WaitForNodeByIP(ctx, ip, opts.NodeTimeout)
+	if err != nil {
+		return err
+	}
+	if err := s.nodes.Label(ctx, joined.Name, roleLabels[node.Role]); err != nil {
+		return err
+	}
+
+	if node.Role == roleControlPlane {
+		controlPlanes, err := s.controlPlaneIPs(ctx, "")
+		if err != nil {
+			return err
+		}
+		if err := s.talos.WaitForEtcdQuorum(ctx, controlPlanes, opts.NodeTimeout); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("Node added", zap.String("name", name), zap.String("node", joined.Name), zap.String("ip", ip))
+	return nil
+}
+
+// removeNode drains the n-th VM of a role, removes it from etcd and the cluster, and stops and
+// deletes the VM.
+func (s *ScaleService) removeNode(ctx context.Context, role string, n int, opts Options) error {
+	name := s.vmName(role, n)
+	s.logger.Info("Removing node", zap.String("name", name), zap.String("role", role))
+
+	status, err := s.provider.GetVMStatus(name)
+	if err != nil {
+		return fmt.Errorf("failed to get status of VM %s: %w", name, err)
+	}
+
+	node, found, err := s.findNode(ctx, status.IP)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		if err := s.nodes.Cordon(ctx, node.Name); err != nil {
+			return err
+		}
+		if err := s.nodes.Drain(ctx, node.Name, opts.DrainTimeout); err != nil {
+			return err
+		}
+	} else {
+		s.logger.Warn("VM has no matching Kubernetes node, skipping drain", zap.String("name", name), zap.String("ip", status.IP))
+	}
+
+	var remaining []string
+	if role == roleControlPlane {
+		remaining, err = s.controlPlaneIPs(ctx, status.IP)
+		if err != nil {
+			return err
+		}
+		if len(remaining) == 0 {
+			return fmt.Errorf("refusing to remove the last control plane node %s", name)
+		}
+		if err := s.leaveEtcd(ctx, status.IP, node.Name, remaining[0]); err != nil {
+			return err
+		}
+	}
+
+	// Providers refuse to delete a running VM.
+	if err := s.provider.StopVM(status.ID); err != nil {
+		return fmt.Errorf("failed to stop VM %s: %w", name, err)
+	}
+	if err := s.waitForVMStopped(name, opts.VMTimeout); err != nil {
+		return err
+	}
+	if err := s.provider.DeleteVM(status.ID); err != nil {
+		return fmt.Errorf("failed to delete VM %s: %w", name, err)
+	}
+	if found {
+		if err := s.nodes.Delete(ctx, node.Name); err != nil {
+			return err
+		}
+	}
+
+	if role == roleControlPlane {
+		if err := s.talos.WaitForEtcdQuorum(ctx, remaining, opts.NodeTimeout); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("Node removed", zap.String("name", name))
+	return nil
+}
+
+// leaveEtcd removes a control plane from etcd, falling back to removing its member through
+// another control plane when the node cannot leave by itself.
+func (s *ScaleService) leaveEtcd(ctx context.Context, ip, hostname, peer string) error {
+	err := s.talos.LeaveEtcd(ctx, ip)
+	if err == nil {
+		return nil
+	}
+	if hostname == "" {
+		return err
+	}
+	s.logger.Warn("Node could not leave etcd, removing its member instead", zap.String("node", hostname), zap.Error(err))
+	return s.talos.RemoveEtcdMember(ctx, peer, hostname)
+}
+
+// waitForVM polls the provider until the VM is healthy and has an IP.
+func (s *ScaleService) waitForVM(name string, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		status, err := s.provider.GetVMStatus(name)
+		if err != nil {
+			s.logger.Warn("Failed to get VM status", zap.String("vm_name", name), zap.Error(err))
+		} else if status.Healthy && status.IP != "" {
+			s.logger.Info("VM is healthy and has an allocated IP", zap.String("vm_name", name), zap.String("ip", status.IP))
+			return status.IP, nil
+		}
+		time.Sleep(10 * time.Second)
+	}
+	return "", fmt.Errorf("timeout: VM %s did not become healthy with an allocated IP", name)
+}
+
+// waitForVMStopped polls the provider until the VM is powered off.
+func (s *ScaleService) waitForVMStopped(name string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		status, err := s.provider.GetVMStatus(name)
+		if err != nil {
+			s.logger.Warn("Failed to get VM status", zap.String("vm_name", name), zap.Error(err))
+		} else if !status.Running {
+			s.logger.Info("VM is powered off", zap.String("vm_name", name))
+			return nil
+		}
+		time.Sleep(10 * time.Second)
+	}
+	return fmt.Errorf("timeout: VM %s did not power off", name)
+}
+
+// findNode returns the Kubernetes node with the given internal IP.
+func (s *ScaleService) findNode(ctx context.Context, ip string) (cluster.Node, bool, error) {
+	nodes, err := s.nodes.ListNodes(ctx)
+	if err != nil {
+		return cluster.Node{}, false, err
+	}
+	for _, node := range nodes {
+		if ip != "" && node.InternalIP == ip {
+			return node, true, nil
+		}
+	}
+	return cluster.Node{}, false, nil
+}
+
+// controlPlaneIPs returns the internal IPs of all control plane nodes except the excluded one.
+func (s *ScaleService) controlPlaneIPs(ctx context.Context, exclude string) ([]string, error) {
+	nodes, err := s.nodes.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ips []string
+	for _, node := range nodes {
+		if node.ControlPlane && node.InternalIP != "" && node.InternalIP != exclude {
+			ips = append(ips, node.InternalIP)
+		}
+	}
+	return ips, nil
+}
@@ -0,0 +1,45 @@
+// Package secrets resolves credentials in the bootstrap configuration from secret references.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ErrNoTerminal is returned by Prompt when stdin is not a terminal, so automation fails fast
+// instead of blocking on input that will never come.
+var ErrNoTerminal = errors.New("no terminal attached to prompt for a secret")
+
+// Prompt asks the operator for a secret without echoing it. It only prompts when stdin is a terminal.
+func Prompt(label string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", ErrNoTerminal
+	}
+
+	fmt.Fprintf(os.Stderr, "Enter %s (will not be stored): ", label)
+	out, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", label, err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,178 @@
+// Package secrets resolves credentials in the bootstrap configuration from secret references.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+)
+
+// Reference schemes. A value without one of these prefixes is used literally.
+const (
+	// SchemeEnv reads an environment variable: "env:NUTANIX_PASSWORD".
+	SchemeEnv = "env"
+	// SchemeFile reads a file, without its trailing newline: "file:/run/secrets/nutanix".
+	SchemeFile = "file"
+	// SchemeCommand runs a shell command and uses its output: "cmd:pass show butler/nutanix".
+	SchemeCommand = "cmd"
+	// SchemeSOPS decrypts a SOPS file and extracts a dotted key: "sops:secrets.enc.yaml#nutanix.password".
+	SchemeSOPS = "sops"
+	// SchemeVault reads a key from a Vault KV secret: "vault:secret/data/butler#nutanix".
+	SchemeVault = "vault"
+)
+
+// commandTimeout bounds cmd and sops references, which may wait on a GPG agent or a password manager.
+const commandTimeout = 2 * time.Minute
+
+// Resolver turns secret references into their values.
+type Resolver struct {
+	vault  *vaultClient
+	logger *zap.Logger
+}
+
+// NewResolver creates a Resolver. Vault is configured from VAULT_ADDR, VAULT_TOKEN (or
+// ~/.vault-token), VAULT_NAMESPACE and VAULT_CACERT when a vault reference is resolved.
+func NewResolver(logger *zap.Logger) *Resolver {
+	return &Resolver{
+		vault:  &vaultClient{},
+		logger: logger,
+	}
+}
+
+// ResolveConfig replaces every credential in the bootstrap configuration that holds a secret
+// reference with the referenced value.
+func (r *Resolver) ResolveConfig(ctx context.Context, config *models.BootstrapConfig) error {
+	mc := &config.ManagementCluster
+
+	fields := map[string]*string{
+		"nutanix.password":              &mc.Nutanix.Password,
+		"proxmox.password":              &mc.Proxmox.Password,
+		"flux.gitPAT":                   &mc.Flux.GitPAT,
+		"etcdBackup.s3.accessKeyID":     &mc.EtcdBackup.S3.AccessKeyID,
+		"etcdBackup.s3.secretAccessKey": &mc.EtcdBackup.S3.SecretAccessKey,
+		"ingress.dashboard.password":    &mc.Ingress.Dashboard.Password,
+		"storage.nutanixCSI.password":   &mc.Storage.NutanixCSI.Password,
+	}
+	for i := range mc.Talos.ControlPlaneHA.BGP.Peers {
+		fields[fmt.Sprintf("talos.controlPlaneHA.bgp.peers[%d].password", i)] = &mc.Talos.ControlPlaneHA.BGP.Peers[i].Password
+	}
+
+	for i := range mc.LoadBalancer.BGPPeers {
+		fields[fmt.Sprintf("loadBalancer.bgpPeers[%d].password", i)] = &mc.LoadBalancer.BGPPeers[i].Password
+	}
+
+	for name, field := range fields {
+		value, err := r.Resolve(ctx, *field)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", name, err)
+		}
+		*field = value
+	}
+
+	for key, ref := range mc.ClusterAPI.Variables {
+		value, err := r.Resolve(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("failed to resolve clusterAPI.variables.%s: %w", key, err)
+		}
+		mc.ClusterAPI.Variables[key] = value
+	}
+	return nil
+}
+
+// Resolve returns the value a reference points to, or the reference itself when it is a literal.
+func (r *Resolver) Resolve(ctx context.Context, ref string) (string, error) {
+	scheme, target, ok := strings.Cut(ref, ":")
+	if !ok {
+		return ref, nil
+	}
+
+	switch scheme {
+	case SchemeEnv:
+		value, ok := os.LookupEnv(target)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", target)
+		}
+		return value, nil
+	case SchemeFile:
+		out, err := os.ReadFile(target)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return strings.TrimRight(string(out), "\r\n"), nil
+	case SchemeCommand:
+		r.logger.Debug("Resolving secret from command", zap.String("command", target))
+		return run(ctx, "sh", "-c", target)
+	case SchemeSOPS:
+		file, key, _ := strings.Cut(target, "#")
+		args := []string{"--decrypt"}
+		if key != "" {
+			args = append(args, "--extract", sopsPath(key))
+		}
+		r.logger.Debug("Resolving secret from SOPS file", zap.String("file", file), zap.String("key", key))
+		return run(ctx, "sops", append(args, file)...)
+	case SchemeVault:
+		path, key, ok := strings.Cut(target, "#")
+		if !ok || key == "" {
+			return "", fmt.Errorf("vault reference %q needs a key after '#'", ref)
+		}
+		r.logger.Debug("Resolving secret from Vault", zap.String("path", path), zap.String("key", key))
+		return r.vault.read(ctx, path, key)
+	default:
+		// Not a known scheme, e.g. a password that happens to contain a colon.
+		return ref, nil
+	}
+}
+
+// run executes a command and returns its trimmed output. It deliberately bypasses the exec adapter,
+// which logs command output.
+func run(ctx context.Context, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
+
+// sopsPath converts a dotted key such as "nutanix.password" or "peers.0.password" into the
+// bracketed path sops --extract expects.
+func sopsPath(key string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(key, ".") {
+		if _, err := strconv.Atoi(part); err == nil {
+			fmt.Fprintf(&b, "[%s]", part)
+		} else {
+			fmt.Fprintf(&b, "[%q]", part)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,136 @@
+// Package secrets resolves credentials in the bootstrap configuration from secret references.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// vaultClient reads KV secrets over the Vault HTTP API, configured from the standard VAULT_*
+// environment variables.
+type vaultClient struct {
+	client *http.Client
+}
+
+// vaultResponse is the part of a KV read response Butler uses. KV version 2 nests the secret
+// under data.data next to data.metadata; version 1 returns it as data.
+type vaultResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []string               `json:"errors"`
+}
+
+// read returns one key of the secret at path, e.g. "secret/data/butler" for a KV v2 mount.
+func (v *vaultClient) read(ctx context.Context, path, key string) (string, error) {
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		return "", fmt.Errorf("VAULT_ADDR is not set")
+	}
+	token, err := vaultToken()
+	if err != nil {
+		return "", err
+	}
+	client, err := v.httpClient()
+	if err != nil {
+		return "", err
+	}
+
+	url := strings.TrimSuffix(addr, "/") + "/v1/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build Vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if ns := os.Getenv("VAULT_NAMESPACE"); ns != "" {
+		req.Header.Set("X-Vault-Namespace", ns)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s from Vault: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	var body vaultResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil && resp.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("failed to decode Vault response for %s: %w", path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Vault returned %s for %s: %s", resp.Status, path, strings.Join(body.Errors, "; "))
+	}
+
+	data := body.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, v2 := data["metadata"]; v2 {
+			data = nested
+		}
+	}
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in Vault secret %s", key, path)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %q in Vault secret %s is not a string", key, path)
+	}
+	return s, nil
+}
+
+// httpClient builds the HTTP client once, trusting VAULT_CACERT when set.
+func (v *vaultClient) httpClient() (*http.Client, error) {
+	if v.client != nil {
+		return v.client, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caFile := os.Getenv("VAULT_CACERT"); caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read VAULT_CACERT: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in VAULT_CACERT %s", caFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	v.client = &http.Client{Transport: transport, Timeout: 30 * time.Second}
+	return v.client, nil
+}
+
+// vaultToken returns VAULT_TOKEN, or the token the vault CLI stored at login.
+func vaultToken() (string, error) {
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token, nil
+	}
+	home, err := os.UserHomeDir()
+	if err == nil {
+		if out, err := os.ReadFile(filepath.Join(home, ".vault-token")); err == nil {
+			return strings.TrimSpace(string(out)), nil
+		}
+	}
+	return "", fmt.Errorf("no Vault token; set VAULT_TOKEN or run 'vault login'")
+}
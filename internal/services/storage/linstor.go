@@ -0,0 +1,315 @@
+// Package storage installs the management cluster's storage providers and StorageClasses.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/machineconfig"
+	"github.com/butlerdotdev/butler/internal/services/readiness"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+)
+
+// LINSTOR storage pool types.
+const (
+	PoolTypeLVMThin = "lvm-thin"
+	PoolTypeZFS     = "zfs"
+)
+
+const (
+	// PiraeusNamespace is where the Piraeus operator and the LINSTOR components run.
+	PiraeusNamespace = "piraeus-datastore"
+
+	// DefaultPiraeusVersion pins the Piraeus operator release manifest.
+	DefaultPiraeusVersion = "v2.7.1"
+	piraeusManifestURL    = "https://github.com/piraeusdatastore/piraeus-operator/releases/download/%s/manifest.yaml"
+
+	piraeusAPIVersion   = "piraeus.io/v1"
+	linstorProvisioner  = "linstor.csi.linbit.com"
+	defaultPoolName     = "butler-pool"
+	defaultStorageClass = "linstor"
+)
+
+// piraeusOperator must be rolled out before LINSTOR resources are accepted.
+var piraeusOperator = []readiness.Resource{
+	readiness.CRD("linstorclusters.piraeus.io"),
+	readiness.CRD("linstorsatelliteconfigurations.piraeus.io"),
+	readiness.Deployment(PiraeusNamespace, "piraeus-operator-controller-manager").WithTimeout(5 * time.Minute),
+}
+
+// linstorCluster is ready once the operator reports the LINSTOR controller and CSI driver available.
+var linstorCluster = []readiness.Resource{
+	{APIVersion: piraeusAPIVersion, Kind: "LinstorCluster", Name: "linstorcluster", Condition: "Available", Timeout: 10 * time.Minute},
+	readiness.Deployment(PiraeusNamespace, "linstor-controller").WithTimeout(5 * time.Minute),
+	readiness.Deployment(PiraeusNamespace, "linstor-csi-controller").WithTimeout(5 * time.Minute),
+}
+
+// talosOverride removes the parts of the satellite Pod that assume a general purpose distribution.
+// Talos loads DRBD from the siderolabs/drbd extension and keeps /etc read-only.
+var talosOverride = map[string]interface{}{
+	"podTemplate": map[string]interface{}{
+		"spec": map[string]interface{}{
+			"initContainers": []interface{}{
+				map[string]interface{}{"name": "drbd-shutdown-guard", "$patch": "delete"},
+				map[string]interface{}{"name": "drbd-module-loader", "$patch": "delete"},
+			},
+			"volumes": []interface{}{
+				map[string]interface{}{"name": "run-systemd-system", "$patch": "delete"},
+				map[string]interface{}{"name": "run-drbd-shutdown-guard", "$patch": "delete"},
+				map[string]interface{}{"name": "systemd-bus-socket", "$patch": "delete"},
+				map[string]interface{}{"name": "lib-modules", "$patch": "delete"},
+				map[string]interface{}{"name": "usr-src", "$patch": "delete"},
+				map[string]interface{}{"name": "etc-lvm-backup", "hostPath": map[string]interface{}{"path": "/var/etc/lvm/backup", "type": "DirectoryOrCreate"}},
+				map[string]interface{}{"name": "etc-lvm-archive", "hostPath": map[string]interface{}{"path": "/var/etc/lvm/archive", "type": "DirectoryOrCreate"}},
+			},
+		},
+	},
+}
+
+// LinstorInstaller installs the Piraeus operator and configures LINSTOR storage pools.
+type LinstorInstaller struct {
+	kube   *kubernetes.KubernetesAdapter
+	logger *zap.Logger
+}
+
+// NewLinstorInstaller constructs a new LinstorInstaller instance.
+func NewLinstorInstaller(kube *kubernetes.KubernetesAdapter, logger *zap.Logger) *LinstorInstaller {
+	return &LinstorInstaller{
+		kube:   kube,
+		logger: logger,
+	}
+}
+
+// ValidateLinstor checks the LINSTOR settings against the provider, node pools and Talos extensions.
+func ValidateLinstor(config models.LinstorConfig, provider string, nodes []models.NodeConfig, talos models.TalosConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+	// The storage pools are built on the workers' extraDisks, which only Nutanix attaches.
+	if provider != "nutanix" {
+		return fmt.Errorf("storage.linstor is only supported with the nutanix provider")
+	}
+	switch config.PoolType {
+	case "", PoolTypeLVMThin:
+	case PoolTypeZFS:
+		if !contains(talos.Extensions, "siderolabs/zfs") {
+			return fmt.Errorf("storage.linstor.poolType %s needs the siderolabs/zfs Talos extension", PoolTypeZFS)
+		}
+	default:
+		return fmt.Errorf("unsupported storage.linstor.poolType %q; expected %s or %s", config.PoolType, PoolTypeLVMThin, PoolTypeZFS)
+	}
+	if !contains(talos.Extensions, "siderolabs/drbd") {
+		return fmt.Errorf("LINSTOR needs the siderolabs/drbd Talos extension")
+	}
+
+	devices, workers, err := workerDisks(nodes)
+	if err != nil {
+		return err
+	}
+	if len(devices) == 0 {
+		return fmt.Errorf("LINSTOR needs worker nodes with extraDisks")
+	}
+	if config.Replicas < 0 || config.Replicas > workers {
+		return fmt.Errorf("storage.linstor.replicas must be between 1 and the %d workers with extra disks", workers)
+	}
+	return nil
+}
+
+// workerDisks returns the stable by-partlabel paths of the workers' raw extra disk partitions and
+// how many workers have them. The disks must be left raw, and every worker pool must have the same
+// number of them since one LinstorSatelliteConfiguration covers all workers.
+func workerDisks(nodes []models.NodeConfig) ([]string, int, error) {
+	var devices []string
+	workers := 0
+	for _, node := range nodes {
+		if node.Role != "worker" || len(node.ExtraDisks) == 0 {
+			continue
+		}
+		if node.ExtraDiskMode != machineconfig.ExtraDiskModeRaw {
+			return nil, 0, fmt.Errorf("LINSTOR needs worker extraDiskMode %q so it can use the extra disks", machineconfig.ExtraDiskModeRaw)
+		}
+		if devices != nil && len(devices) != len(node.ExtraDisks) {
+			return nil, 0, fmt.Errorf("all worker pools need the same number of extraDisks for LINSTOR")
+		}
+		devices = machineconfig.ExtraDiskDevices(len(node.ExtraDisks))
+		workers += node.Count
+	}
+	return devices, workers, nil
+}
+
+// Install applies the Piraeus operator manifest, creates the LinstorCluster, the worker storage
+// pools and the default StorageClass, then waits for LINSTOR to become available.
+func (l *LinstorInstaller) Install(ctx context.Context, server string, config models.LinstorConfig, nodes []models.NodeConfig) error {
+	kube := l.kube.WithServer(server)
+
+	version := config.Version
+	if version == "" {
+		version = DefaultPiraeusVersion
+	}
+	l.logger.Info("Installing Piraeus operator", zap.String("version", version))
+	if err := kube.ApplyURL(ctx, fmt.Sprintf(piraeusManifestURL, version)); err != nil {
+		return fmt.Errorf("failed to apply Piraeus operator manifest: %w", err)
+	}
+
+	waiter := readiness.NewWaiter(kube, l.logger)
+	if err := waiter.Wait(ctx, piraeusOperator...); err != nil {
+		return fmt.Errorf("Piraeus operator did not become ready: %w", err)
+	}
+
+	devices, workers, err := workerDisks(nodes)
+	if err != nil {
+		return err
+	}
+	manifest, err := renderLinstor(config, devices, workers)
+	if err != nil {
+		return err
+	}
+	if err := l.applyResources(ctx, kube, manifest); err != nil {
+		return err
+	}
+
+	if err := waiter.Wait(ctx, linstorCluster...); err != nil {
+		return fmt.Errorf("LINSTOR did not become ready: %w", err)
+	}
+
+	l.logger.Info("LINSTOR installed successfully",
+		zap.String("storageClass", storageClassName(config)),
+		zap.Strings("devices", devices),
+		zap.Int("replicas", replicas(config, workers)),
+	)
+	return nil
+}
+
+// applyResources applies the LINSTOR resources, retrying while the operator's webhook starts.
+func (l *LinstorInstaller) applyResources(ctx context.Context, kube *kubernetes.KubernetesAdapter, manifest []byte) error {
+	deadline := time.Now().Add(2 * time.Minute)
+	for {
+		err := kube.Apply(ctx, manifest)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("failed to apply LINSTOR resources: %w", err)
+		}
+		l.logger.Debug("Piraeus webhook not ready yet, retrying", zap.Error(err))
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// renderLinstor renders the LinstorCluster, the Talos satellite override, the worker storage pool
+// and the default StorageClass.
+func renderLinstor(config models.LinstorConfig, devices []string, workers int) ([]byte, error) {
+	pool := map[string]interface{}{
+		"name":   poolName(config),
+		"source": map[string]interface{}{"hostDevices": devices},
+	}
+	if config.PoolType == PoolTypeZFS {
+		pool["zfsThinPool"] = map[string]interface{}{"zPool": poolName(config)}
+	} else {
+		pool["lvmThinPool"] = map[string]interface{}{"volumeGroup": "vg-" + poolName(config), "thinPool": "thin"}
+	}
+
+	objects := []map[string]interface{}{
+		{
+			"apiVersion": piraeusAPIVersion,
+			"kind":       "LinstorCluster",
+			"metadata":   map[string]interface{}{"name": "linstorcluster"},
+			"spec":       map[string]interface{}{},
+		},
+		{
+			"apiVersion": piraeusAPIVersion,
+			"kind":       "LinstorSatelliteConfiguration",
+			"metadata":   map[string]interface{}{"name": "talos-loader-override"},
+			"spec":       talosOverride,
+		},
+		{
+			"apiVersion": piraeusAPIVersion,
+			"kind":       "LinstorSatelliteConfiguration",
+			"metadata":   map[string]interface{}{"name": "butler-storage-pool"},
+			"spec": map[string]interface{}{
+				"nodeAffinity": map[string]interface{}{
+					"nodeSelectorTerms": []interface{}{map[string]interface{}{
+						"matchExpressions": []interface{}{map[string]interface{}{
+							"key":      "node-role.kubernetes.io/control-plane",
+							"operator": "DoesNotExist",
+						}},
+					}},
+				},
+				"storagePools": []interface{}{pool},
+			},
+		},
+		{
+			"apiVersion": "storage.k8s.io/v1",
+			"kind":       "StorageClass",
+			"metadata": map[string]interface{}{
+				"name": storageClassName(config),
+				"annotations": map[string]interface{}{
+					"storageclass.kubernetes.io/is-default-class": "true",
+				},
+			},
+			"provisioner":          linstorProvisioner,
+			"allowVolumeExpansion": true,
+			"volumeBindingMode":    "WaitForFirstConsumer",
+			"parameters": map[string]interface{}{
+				"linstor.csi.linbit.com/storagePool":    poolName(config),
+				"linstor.csi.linbit.com/placementCount": strconv.Itoa(replicas(config, workers)),
+			},
+		},
+	}
+
+	return kubernetes.RenderManifest(objects)
+}
+
+func poolName(config models.LinstorConfig) string {
+	if config.PoolName == "" {
+		return defaultPoolName
+	}
+	return config.PoolName
+}
+
+func storageClassName(config models.LinstorConfig) string {
+	if config.StorageClass == "" {
+		return defaultStorageClass
+	}
+	return config.StorageClass
+}
+
+// replicas returns the configured replica count, defaulting to 2 or to 1 with a single worker.
+func replicas(config models.LinstorConfig, workers int) int {
+	if config.Replicas > 0 {
+		return config.Replicas
+	}
+	if workers < 2 {
+		return 1
+	}
+	return 2
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
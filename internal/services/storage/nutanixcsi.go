@@ -0,0 +1,350 @@
+// Package storage installs the management cluster's storage providers and StorageClasses.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/readiness"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/helm"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// Nutanix StorageClass types.
+const (
+	NutanixVolumes = "volumes"
+	NutanixFiles   = "files"
+)
+
+const (
+	// NutanixCSINamespace is where the Nutanix CSI driver and its Prism credentials live.
+	NutanixCSINamespace = "ntnx-system"
+
+	// nutanixChartRepo and DefaultNutanixCSIVersion pin the nutanix-csi-storage chart.
+	nutanixChartRepo         = "https://nutanix.github.io/helm/"
+	DefaultNutanixCSIVersion = "2.6.10"
+
+	nutanixProvisioner = "csi.nutanix.com"
+	prismSecretName    = "ntnx-secret"
+	prismDefaultPort   = "9440"
+)
+
+// storageClassNamePattern matches the object names Kubernetes accepts for StorageClasses.
+var storageClassNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+
+// nutanixCSIDriver must be rolled out before volumes can be provisioned.
+var nutanixCSIDriver = []readiness.Resource{
+	readiness.Deployment(NutanixCSINamespace, "nutanix-csi-controller").WithTimeout(5 * time.Minute),
+	readiness.DaemonSet(NutanixCSINamespace, "nutanix-csi-node").WithTimeout(5 * time.Minute),
+}
+
+// NutanixCSIInstaller installs the Nutanix CSI driver and its StorageClasses.
+type NutanixCSIInstaller struct {
+	kube   *kubernetes.KubernetesAdapter
+	helm   *helm.HelmAdapter
+	logger *zap.Logger
+}
+
+// NewNutanixCSIInstaller constructs a new NutanixCSIInstaller instance.
+func NewNutanixCSIInstaller(kube *kubernetes.KubernetesAdapter, helm *helm.HelmAdapter, logger *zap.Logger) *NutanixCSIInstaller {
+	return &NutanixCSIInstaller{
+		kube:   kube,
+		helm:   helm,
+		logger: logger,
+	}
+}
+
+// ValidateNutanixCSI checks the CSI settings. The driver is only supported on the Nutanix provider,
+// and only one StorageClass across the storage providers may be the default.
+func ValidateNutanixCSI(config models.StorageConfig, provider string, nutanix models.NutanixConfig, talos models.TalosConfig) error {
+	csi := config.NutanixCSI
+	if !csi.Enabled {
+		return nil
+	}
+	if provider != "nutanix" {
+		return fmt.Errorf("storage.nutanixCSI is only supported with the nutanix provider")
+	}
+	if _, err := prismElement(csi); err != nil {
+		return err
+	}
+	if len(csi.StorageClasses) == 0 {
+		return fmt.Errorf("storage.nutanixCSI.storageClasses needs at least one StorageClass")
+	}
+
+	defaults := 0
+	if config.Linstor.Enabled {
+		defaults++
+	}
+	names := make(map[string]bool, len(csi.StorageClasses))
+	for _, class := range csi.StorageClasses {
+		if !storageClassNamePattern.MatchString(class.Name) {
+			return fmt.Errorf("invalid Nutanix StorageClass name %q", class.Name)
+		}
+		if names[class.Name] {
+			return fmt.Errorf("duplicate Nutanix StorageClass %q", class.Name)
+		}
+		names[class.Name] = true
+
+		switch storageType(class) {
+		case NutanixVolumes:
+			if class.StorageContainer == "" {
+				return fmt.Errorf("Nutanix StorageClass %s needs a storageContainer", class.Name)
+			}
+			if !contains(talos.Extensions, "siderolabs/iscsi-tools") {
+				return fmt.Errorf("Nutanix volumes need the siderolabs/iscsi-tools Talos extension")
+			}
+		case NutanixFiles:
+			if class.FileServer == "" {
+				return fmt.Errorf("Nutanix StorageClass %s needs a fileServer", class.Name)
+			}
+		default:
+			return fmt.Errorf("Nutanix StorageClass %s has unsupported type %q; expected %s or %s", class.Name, class.Type, NutanixVolumes, NutanixFiles)
+		}
+		if class.ReclaimPolicy != "" && class.ReclaimPolicy != "Delete" && class.ReclaimPolicy != "Retain" {
+			return fmt.Errorf("Nutanix StorageClass %s has unsupported reclaimPolicy %q; expected Delete or Retain", class.Name, class.ReclaimPolicy)
+		}
+		if class.Default {
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		return fmt.Errorf("only one default StorageClass is allowed; LINSTOR's StorageClass is the default when it is enabled")
+	}
+	return nil
+}
+
+// Install creates the Prism credentials Secret, installs or upgrades the CSI chart, creates the
+// StorageClasses and checks each one by provisioning a test PVC.
+func (n *NutanixCSIInstaller) Install(ctx context.Context, server string, config models.NutanixCSIConfig, nutanix models.NutanixConfig) error {
+	kube := n.kube.WithServer(server)
+
+	secret, err := renderPrismSecret(config, nutanix)
+	if err != nil {
+		return err
+	}
+	if err := kube.Apply(ctx, secret); err != nil {
+		return fmt.Errorf("failed to apply Prism credentials: %w", err)
+	}
+
+	version := config.Version
+	if version == "" {
+		version = DefaultNutanixCSIVersion
+	}
+	// The chart's own Secret and StorageClasses are disabled; Butler manages both.
+	values := []map[string]interface{}{{
+		"createSecret":     false,
+		"volumeClass":      false,
+		"fileClass":        false,
+		"dynamicFileClass": false,
+	}}
+	if len(config.Values) > 0 {
+		values = append(values, config.Values)
+	}
+
+	n.logger.Info("Installing Nutanix CSI driver via Helm", zap.String("version", version))
+	if _, err := n.helm.UpgradeInstall(ctx, "nutanix-csi", helm.Chart{
+		Name:    "nutanix-csi-storage",
+		Repo:    nutanixChartRepo,
+		Version: version,
+	}, helm.ReleaseOptions{
+		Namespace:  NutanixCSINamespace,
+		Kubeconfig: n.kube.Kubeconfig(),
+		Values:     values,
+		Timeout:    5 * time.Minute,
+	}); err != nil {
+		return fmt.Errorf("failed to install Nutanix CSI driver: %w", err)
+	}
+
+	if err := readiness.NewWaiter(kube, n.logger).Wait(ctx, nutanixCSIDriver...); err != nil {
+		return fmt.Errorf("Nutanix CSI driver did not become ready: %w", err)
+	}
+
+	classes, err := renderNutanixStorageClasses(config)
+	if err != nil {
+		return err
+	}
+	if err := kube.Apply(ctx, classes); err != nil {
+		return fmt.Errorf("failed to apply Nutanix StorageClasses: %w", err)
+	}
+
+	for _, class := range config.StorageClasses {
+		if err := n.verify(ctx, kube, class); err != nil {
+			return err
+		}
+	}
+
+	n.logger.Info("Nutanix CSI driver installed successfully", zap.Int("storageClasses", len(config.StorageClasses)))
+	return nil
+}
+
+// verify provisions a 1Gi PVC from class, waits for it to bind and deletes it again.
+func (n *NutanixCSIInstaller) verify(ctx context.Context, kube *kubernetes.KubernetesAdapter, class models.NutanixStorageClass) error {
+	name := "butler-csi-test-" + class.Name
+	accessMode := "ReadWriteOnce"
+	if storageType(class) == NutanixFiles {
+		accessMode = "ReadWriteMany"
+	}
+	pvc, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "PersistentVolumeClaim",
+		"metadata":   map[string]interface{}{"name": name, "namespace": NutanixCSINamespace},
+		"spec": map[string]interface{}{
+			"storageClassName": class.Name,
+			"accessModes":      []string{accessMode},
+			"resources":        map[string]interface{}{"requests": map[string]interface{}{"storage": "1Gi"}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render test PVC: %w", err)
+	}
+
+	n.logger.Info("Checking that the StorageClass provisions volumes", zap.String("storageClass", class.Name))
+	if err := kube.Apply(ctx, pvc); err != nil {
+		return fmt.Errorf("failed to create test PVC for StorageClass %s: %w", class.Name, err)
+	}
+	defer func() {
+		if err := kube.Delete(ctx, "v1", "PersistentVolumeClaim", NutanixCSINamespace, name); err != nil {
+			n.logger.Warn("Failed to delete test PVC", zap.String("pvc", name), zap.Error(err))
+		}
+	}()
+
+	deadline := time.Now().Add(3 * time.Minute)
+	for time.Now().Before(deadline) {
+		obj, err := kube.Get(ctx, "v1", "PersistentVolumeClaim", NutanixCSINamespace, name)
+		if err != nil {
+			return fmt.Errorf("failed to read test PVC %s: %w", name, err)
+		}
+		if phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase"); phase == "Bound" {
+			return nil
+		}
+		time.Sleep(5 * time.Second)
+	}
+	return fmt.Errorf("test PVC for StorageClass %s was not bound within 3m; check the nutanix-csi-controller logs and the storage container", class.Name)
+}
+
+// prismElement returns the host:port the driver reaches Prism Element on. It can't be derived
+// from nutanix.endpoint, which is Prism Central.
+func prismElement(config models.NutanixCSIConfig) (string, error) {
+	endpoint := config.PrismElement
+	if endpoint == "" {
+		return "", fmt.Errorf("storage.nutanixCSI.prismElement is required")
+	}
+	if _, _, err := net.SplitHostPort(endpoint); err != nil {
+		endpoint = net.JoinHostPort(endpoint, prismDefaultPort)
+	}
+	return endpoint, nil
+}
+
+// renderPrismSecret renders the CSI namespace and the Secret holding the Prism credentials in the
+// driver's "host:port:username:password" format.
+func renderPrismSecret(config models.NutanixCSIConfig, nutanix models.NutanixConfig) ([]byte, error) {
+	endpoint, err := prismElement(config)
+	if err != nil {
+		return nil, err
+	}
+	username, password := config.Username, config.Password
+	if username == "" {
+		username, password = nutanix.Username, nutanix.Password
+	}
+	if username == "" || password == "" {
+		return nil, fmt.Errorf("Nutanix CSI needs Prism credentials")
+	}
+
+	return kubernetes.RenderManifest([]map[string]interface{}{
+		{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata": map[string]interface{}{
+				"name": NutanixCSINamespace,
+				// The node plugin mounts host paths and runs privileged.
+				"labels": map[string]interface{}{
+					"pod-security.kubernetes.io/enforce": "privileged",
+					"pod-security.kubernetes.io/audit":   "privileged",
+					"pod-security.kubernetes.io/warn":    "privileged",
+				},
+			},
+		},
+		{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]interface{}{"name": prismSecretName, "namespace": NutanixCSINamespace},
+			"stringData": map[string]interface{}{"key": fmt.Sprintf("%s:%s:%s", endpoint, username, password)},
+		},
+	})
+}
+
+// renderNutanixStorageClasses renders a StorageClass per configured class.
+func renderNutanixStorageClasses(config models.NutanixCSIConfig) ([]byte, error) {
+	objects := make([]map[string]interface{}, 0, len(config.StorageClasses))
+	for _, class := range config.StorageClasses {
+		parameters := map[string]interface{}{}
+		for _, secret := range []string{"provisioner", "node-publish", "controller-expand"} {
+			parameters["csi.storage.k8s.io/"+secret+"-secret-name"] = prismSecretName
+			parameters["csi.storage.k8s.io/"+secret+"-secret-namespace"] = NutanixCSINamespace
+		}
+		if storageType(class) == NutanixFiles {
+			parameters["storageType"] = "NutanixFiles"
+			parameters["dynamicProv"] = "ENABLED"
+			parameters["nfsServerName"] = class.FileServer
+			parameters["squashType"] = "none"
+		} else {
+			fsType := class.FSType
+			if fsType == "" {
+				fsType = "ext4"
+			}
+			parameters["storageType"] = "NutanixVolumes"
+			parameters["storageContainer"] = class.StorageContainer
+			parameters["csi.storage.k8s.io/fstype"] = fsType
+		}
+
+		reclaimPolicy := class.ReclaimPolicy
+		if reclaimPolicy == "" {
+			reclaimPolicy = "Delete"
+		}
+		metadata := map[string]interface{}{"name": class.Name}
+		if class.Default {
+			metadata["annotations"] = map[string]interface{}{"storageclass.kubernetes.io/is-default-class": "true"}
+		}
+		objects = append(objects, map[string]interface{}{
+			"apiVersion":           "storage.k8s.io/v1",
+			"kind":                 "StorageClass",
+			"metadata":             metadata,
+			"provisioner":          nutanixProvisioner,
+			"parameters":           parameters,
+			"reclaimPolicy":        reclaimPolicy,
+			"allowVolumeExpansion": true,
+			"volumeBindingMode":    "Immediate",
+		})
+	}
+	return kubernetes.RenderManifest(objects)
+}
+
+func storageType(class models.NutanixStorageClass) string {
+	if class.Type == "" {
+		return NutanixVolumes
+	}
+	return class.Type
+}
@@ -0,0 +1,299 @@
+// Package tenant manages the tenant clusters and virtual machines butlerctl creates on the Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/readiness"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// pollInterval is how often a deleted cluster is checked for.
+const pollInterval = 5 * time.Second
+
+// ClusterService creates, inspects and deletes tenant clusters through the Cluster API objects on
+// the management cluster.
+type ClusterService struct {
+	kube   *kubernetes.KubernetesAdapter
+	config models.TenantClusters
+	logger *zap.Logger
+}
+
+// NewClusterService constructs a new ClusterService instance.
+func NewClusterService(kube *kubernetes.KubernetesAdapter, config models.TenantClusters, logger *zap.Logger) *ClusterService {
+	return &ClusterService{
+		kube:   kube,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Create applies the objects of a new tenant cluster. With a timeout, it waits until the cluster
+// is Ready and its workers have rolled out.
+func (s *ClusterService) Create(ctx context.Context, opts CreateOptions, timeout time.Duration) error {
+	if err := Validate(s.config); err != nil {
+		return err
+	}
+	if opts.Version == "" {
+		opts.Version = s.config.KubernetesVersion
+	}
+	if opts.Flavor == "" {
+		opts.Flavor = DefaultFlavor
+	}
+	if err := validateOptions(&opts); err != nil {
+		return err
+	}
+	f, err := lookupFlavor(s.config.Flavors, opts.Flavor)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.kube.Get(ctx, clusterAPIVersion, "Cluster", opts.Namespace, opts.Name)
+	switch {
+	case err == nil:
+		return fmt.Errorf("cluster %s already exists in namespace %s", opts.Name, opts.Namespace)
+	case !apierrors.IsNotFound(err):
+		return fmt.Errorf("failed to check for cluster %s: %w", opts.Name, err)
+	}
+
+	manifest, err := renderCluster(s.config, opts, f)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Creating tenant cluster",
+		zap.String("name", opts.Name),
+		zap.String("namespace", opts.Namespace),
+		zap.String("version", opts.Version),
+		zap.Int("workers", opts.Workers),
+		zap.String("flavor", f.name),
+	)
+	if err := s.kube.Apply(ctx, manifest); err != nil {
+		return fmt.Errorf("failed to create cluster %s: %w", opts.Name, err)
+	}
+
+	if timeout == 0 {
+		return nil
+	}
+	return readiness.NewWaiter(s.kube, s.logger).Wait(ctx,
+		readiness.Resource{
+			APIVersion: clusterAPIVersion,
+			Kind:       "Cluster",
+			Namespace:  opts.Namespace,
+			Name:       opts.Name,
+			Condition:  "Ready",
+			Timeout:    timeout,
+		},
+		readiness.Resource{
+			APIVersion: clusterAPIVersion,
+			Kind:       machineDeploymentKind,
+			Namespace:  opts.Namespace,
+			Name:       workersName(opts.Name),
+			Timeout:    timeout,
+		},
+	)
+}
+
+// List returns the status of every tenant cluster in a namespace, or in all namespaces when
+// namespace is empty, sorted by namespace and name.
+func (s *ClusterService) List(ctx context.Context, namespace string) ([]ClusterStatus, error) {
+	clusters, err := s.kube.List(ctx, clusterAPIVersion, "Cluster", namespace, "")
+	if meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("%w; is Cluster API installed on the management cluster?", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	controlPlanes, err := s.kube.List(ctx, controlPlaneAPIVersion, controlPlaneKind, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	deployments, err := s.kube.List(ctx, clusterAPIVersion, machineDeploymentKind, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*unstructured.Unstructured{}
+	for i := range controlPlanes {
+		byName[controlPlanes[i].GetNamespace()+"/"+controlPlanes[i].GetName()] = &controlPlanes[i]
+	}
+	byCluster := map[string][]unstructured.Unstructured{}
+	for _, md := range deployments {
+		key := md.GetNamespace() + "/" + md.GetLabels()[ClusterNameLabel]
+		byCluster[key] = append(byCluster[key], md)
+	}
+
+	statuses := make([]ClusterStatus, 0, len(clusters))
+	for i := range clusters {
+		cluster := &clusters[i]
+		key := cluster.GetNamespace() + "/" + cluster.GetName()
+		controlPlaneName, _, _ := unstructured.NestedString(cluster.Object, "spec", "controlPlaneRef", "name")
+		statuses = append(statuses, clusterStatus(cluster, byName[cluster.GetNamespace()+"/"+controlPlaneName], byCluster[key]))
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Namespace != statuses[j].Namespace {
+			return statuses[i].Namespace < statuses[j].Namespace
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses, nil
+}
+
+// Get returns the status of a single tenant cluster.
+func (s *ClusterService) Get(ctx context.Context, namespace, name string) (*ClusterStatus, error) {
+	cluster, err := s.getCluster(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var controlPlane *unstructured.Unstructured
+	if controlPlaneName, _, _ := unstructured.NestedString(cluster.Object, "spec", "controlPlaneRef", "name"); controlPlaneName != "" {
+		controlPlane, err = s.kube.Get(ctx, controlPlaneAPIVersion, controlPlaneKind, namespace, controlPlaneName)
+		switch {
+		case apierrors.IsNotFound(err):
+			controlPlane = nil
+		case err != nil:
+			return nil, fmt.Errorf("failed to get control plane of cluster %s: %w", name, err)
+		}
+	}
+	deployments, err := s.kube.List(ctx, clusterAPIVersion, machineDeploymentKind, namespace, ClusterNameLabel+"="+name)
+	if err != nil {
+		return nil, err
+	}
+
+	status := clusterStatus(cluster, controlPlane, deployments)
+	return &status, nil
+}
+
+// Delete deletes a tenant cluster. Cluster API tears down its control plane and worker VMs; with
+// a timeout, Delete waits for that to finish. The worker templates are not owned by the Cluster,
+// so Delete removes them itself by their cluster name label.
+func (s *ClusterService) Delete(ctx context.Context, namespace, name string, timeout time.Duration) error {
+	if _, err := s.getCluster(ctx, namespace, name); err != nil {
+		return err
+	}
+
+	s.logger.Info("Deleting tenant cluster", zap.String("name", name), zap.String("namespace", namespace))
+	if err := s.kube.Delete(ctx, clusterAPIVersion, "Cluster", namespace, name); err != nil {
+		return err
+	}
+	if timeout > 0 {
+		if err := s.waitForDeletion(ctx, namespace, name, timeout); err != nil {
+			return err
+		}
+	}
+
+	// Machines keep their own bootstrap configs and infrastructure machines, so the templates can
+	// go while the workers are still being torn down.
+	if err := s.deleteTemplates(ctx, namespace, name); err != nil {
+		return err
+	}
+	if timeout > 0 {
+		s.logger.Info("Tenant cluster deleted", zap.String("name", name), zap.String("namespace", namespace))
+	}
+	return nil
+}
+
+// deleteTemplates deletes the worker bootstrap and infrastructure machine templates labelled with
+// a cluster's name. Template kinds of providers that are not installed are skipped.
+func (s *ClusterService) deleteTemplates(ctx context.Context, namespace, name string) error {
+	templates := []kinds{bootstrapKind}
+	for _, infrastructure := range infrastructureKinds {
+		templates = append(templates, infrastructure)
+	}
+
+	for _, template := range templates {
+		objects, err := s.kube.List(ctx, template.apiVersion, template.template, namespace, ClusterNameLabel+"="+name)
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for _, obj := range objects {
+			if err := s.kube.Delete(ctx, template.apiVersion, template.template, namespace, obj.GetName()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Kubeconfig returns the admin kubeconfig of a tenant cluster from the secret Cluster API
+// conventionally stores it in.
+func (s *ClusterService) Kubeconfig(ctx context.Context, namespace, name string) ([]byte, error) {
+	if _, err := s.getCluster(ctx, namespace, name); err != nil {
+		return nil, err
+	}
+
+	secret, err := s.kube.Get(ctx, "v1", "Secret", namespace, name+"-kubeconfig")
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("the kubeconfig of cluster %s is not available yet; its control plane may still be provisioning", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the kubeconfig of cluster %s: %w", name, err)
+	}
+
+	encoded, _, _ := unstructured.NestedString(secret.Object, "data", "value")
+	kubeconfig, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(kubeconfig) == 0 {
+		return nil, fmt.Errorf("secret %s-kubeconfig does not hold a kubeconfig", name)
+	}
+	return kubeconfig, nil
+}
+
+// getCluster returns a Cluster, with a readable error when it does not exist.
+func (s *ClusterService) getCluster(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	cluster, err := s.kube.Get(ctx, clusterAPIVersion, "Cluster", namespace, name)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("cluster %s not found in namespace %s", name, namespace)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster %s: %w", name, err)
+	}
+	return cluster, nil
+}
+
+// waitForDeletion waits until a Cluster no longer exists.
+func (s *ClusterService) waitForDeletion(ctx context.Context, namespace, name string, timeout time.Duration) error {
+	s.logger.Info("Waiting for tenant cluster to be deleted", zap.String("name", name), zap.Duration("timeout", timeout))
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		_, err := s.kube.Get(ctx, clusterAPIVersion, "Cluster", namespace, name)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s waiting for cluster %s to be deleted", timeout, name)
+		case <-time.After(pollInterval):
+		}
+	}
+}
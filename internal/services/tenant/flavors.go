@@ -0,0 +1,84 @@
+// Package tenant manages the tenant clusters and virtual machines butlerctl creates on the Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/butlerdotdev/butler/pkg/models"
+)
+
+// DefaultFlavor is used when butlerctl cluster create or vm create is run without --flavor.
+const DefaultFlavor = "medium"
+
+// defaultFlavors are offered when the configuration does not declare any flavors.
+var defaultFlavors = []models.Flavor{
+	{Name: "small", CPU: 2, RAM: "4GB", Disk: "40GB"},
+	{Name: "medium", CPU: 4, RAM: "8GB", Disk: "80GB"},
+	{Name: "large", CPU: 8, RAM: "16GB", Disk: "120GB"},
+}
+
+// flavor is a VM size with its RAM and disk in GB.
+type flavor struct {
+	name   string
+	cpu    int
+	ramGB  int
+	diskGB int
+}
+
+// Flavors returns the configured flavors, or Butler's defaults when none are configured.
+func Flavors(configured []models.Flavor) []models.Flavor {
+	if len(configured) == 0 {
+		return defaultFlavors
+	}
+	return configured
+}
+
+// lookupFlavor finds a flavor by name among the configured flavors and parses its sizes.
+func lookupFlavor(configured []models.Flavor, name string) (flavor, error) {
+	var names []string
+	for _, f := range Flavors(configured) {
+		if f.Name != name {
+			names = append(names, f.Name)
+			continue
+		}
+		ram, err := parseGB(f.RAM)
+		if err != nil {
+			return flavor{}, fmt.Errorf("flavor %s: invalid ram: %w", f.Name, err)
+		}
+		disk, err := parseGB(f.Disk)
+		if err != nil {
+			return flavor{}, fmt.Errorf("flavor %s: invalid disk: %w", f.Name, err)
+		}
+		if f.CPU <= 0 {
+			return flavor{}, fmt.Errorf("flavor %s: cpu must be positive", f.Name)
+		}
+		return flavor{name: f.Name, cpu: f.CPU, ramGB: ram, diskGB: disk}, nil
+	}
+	return flavor{}, fmt.Errorf("unknown flavor %q; available flavors are %s", name, strings.Join(names, ", "))
+}
+
+// parseGB parses sizes in the "8GB" form used for management cluster nodes.
+func parseGB(size string) (int, error) {
+	gb, err := strconv.Atoi(strings.TrimSuffix(size, "GB"))
+	if err != nil || !strings.HasSuffix(size, "GB") || gb <= 0 {
+		return 0, fmt.Errorf("%q is not a size in GB, e.g. \"8GB\"", size)
+	}
+	return gb, nil
+}
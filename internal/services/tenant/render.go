@@ -0,0 +1,348 @@
+// Package tenant manages the tenant clusters and virtual machines butlerctl creates on the Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/butlerdotdev/butler/internal/services/capi"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// API versions of the objects a tenant cluster is made of.
+const (
+	clusterAPIVersion      = "cluster.x-k8s.io/v1beta1"
+	controlPlaneAPIVersion = "controlplane.cluster.x-k8s.io/v1alpha1"
+	controlPlaneKind       = "KamajiControlPlane"
+	machineDeploymentKind  = "MachineDeployment"
+)
+
+// Labels on tenant cluster objects. The cluster name label is the one Cluster API selects by.
+const (
+	ClusterNameLabel    = "cluster.x-k8s.io/cluster-name"
+	FlavorLabel         = "butler.dev/flavor"
+	deploymentNameLabel = "cluster.x-k8s.io/deployment-name"
+)
+
+// Defaults for the tenant cluster settings.
+const (
+	defaultControlPlaneReplicas = 2
+	defaultPodCIDR              = "10.244.0.0/16"
+	defaultServiceCIDR          = "10.96.0.0/12"
+)
+
+// kinds are the Cluster API kinds a provider contributes.
+type kinds struct {
+	apiVersion string
+	cluster    string
+	template   string
+}
+
+var infrastructureKinds = map[string]kinds{
+	capi.InfrastructureNutanix: {apiVersion: "infrastructure.cluster.x-k8s.io/v1beta1", cluster: "NutanixCluster", template: "NutanixMachineTemplate"},
+	capi.InfrastructureProxmox: {apiVersion: "infrastructure.cluster.x-k8s.io/v1alpha1", cluster: "ProxmoxCluster", template: "ProxmoxMachineTemplate"},
+}
+
+// bootstrapKind is the kubeadm worker config template. Workers join the Kamaji control plane
+// with the kubeadm join token and CA Kamaji publishes, which only kubeadm bootstrap consumes.
+var bootstrapKind = kinds{apiVersion: "bootstrap.cluster.x-k8s.io/v1beta1", template: "KubeadmConfigTemplate"}
+
+// versionPattern matches the Kubernetes versions Kamaji accepts, e.g. v1.31.4.
+var versionPattern = regexp.MustCompile(`^v\d+\.\d+\.\d+$`)
+
+// CreateOptions describes a tenant cluster to create.
+type CreateOptions struct {
+	Name      string
+	Namespace string
+	// Version is the Kubernetes version of the control plane and workers, e.g. v1.31.4.
+	Version string
+	Workers int
+	Flavor  string
+}
+
+// Validate checks the tenant cluster settings for the configured provider.
+func Validate(config models.TenantClusters) error {
+	switch config.Provider {
+	case capi.InfrastructureNutanix:
+		n := config.Nutanix
+		if n.ClusterUUID == "" || n.SubnetUUID == "" || n.Image == "" {
+			return fmt.Errorf("clusters.nutanix.clusterUUID, subnetUUID and image are required for the nutanix provider")
+		}
+	case capi.InfrastructureProxmox:
+		p := config.Proxmox
+		if p.SourceNode == "" || p.TemplateID == 0 || p.Bridge == "" {
+			return fmt.Errorf("clusters.proxmox.sourceNode, templateID and bridge are required for the proxmox provider")
+		}
+		if len(p.Addresses) == 0 || p.Prefix == 0 || p.Gateway == "" {
+			return fmt.Errorf("clusters.proxmox.addresses, prefix and gateway are required for the proxmox provider")
+		}
+	case "":
+		return fmt.Errorf("clusters.provider is required; expected %s or %s", capi.InfrastructureNutanix, capi.InfrastructureProxmox)
+	default:
+		return fmt.Errorf("unsupported clusters.provider %q; expected %s or %s", config.Provider, capi.InfrastructureNutanix, capi.InfrastructureProxmox)
+	}
+	return nil
+}
+
+// validateOptions checks the options of a cluster to create and normalizes its version.
+func validateOptions(opts *CreateOptions) error {
+	if errs := validation.IsDNS1035Label(opts.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid cluster name %q: %s", opts.Name, strings.Join(errs, "; "))
+	}
+	if opts.Version == "" {
+		return fmt.Errorf("a Kubernetes version is required; pass --version or set clusters.kubernetesVersion")
+	}
+	if !strings.HasPrefix(opts.Version, "v") {
+		opts.Version = "v" + opts.Version
+	}
+	if !versionPattern.MatchString(opts.Version) {
+		return fmt.Errorf("invalid Kubernetes version %q; expected e.g. v1.31.4", opts.Version)
+	}
+	if opts.Workers < 0 {
+		return fmt.Errorf("workers must not be negative")
+	}
+	return nil
+}
+
+// workersName names the MachineDeployment and templates of a cluster's workers.
+func workersName(cluster string) string {
+	return cluster + "-workers"
+}
+
+// renderCluster renders the Cluster, KamajiControlPlane, infrastructure cluster, worker templates
+// and MachineDeployment of a tenant cluster as a multi-document manifest.
+func renderCluster(config models.TenantClusters, opts CreateOptions, f flavor) ([]byte, error) {
+	infrastructure := infrastructureKinds[config.Provider]
+	workers := workersName(opts.Name)
+
+	labels := map[string]interface{}{
+		ClusterNameLabel: opts.Name,
+		FlavorLabel:      f.name,
+	}
+	metadata := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":      name,
+			"namespace": opts.Namespace,
+			"labels":    labels,
+		}
+	}
+
+	podCIDR := config.PodCIDR
+	if podCIDR == "" {
+		podCIDR = defaultPodCIDR
+	}
+	serviceCIDR := config.ServiceCIDR
+	if serviceCIDR == "" {
+		serviceCIDR = defaultServiceCIDR
+	}
+	replicas := config.ControlPlaneReplicas
+	if replicas == 0 {
+		replicas = defaultControlPlaneReplicas
+	}
+
+	controlPlane := map[string]interface{}{
+		"replicas": replicas,
+		"version":  opts.Version,
+		// The API server is exposed through a MetalLB address on the management cluster.
+		"network": map[string]interface{}{"serviceType": "LoadBalancer"},
+		"addons": map[string]interface{}{
+			"coreDNS":   map[string]interface{}{},
+			"kubeProxy": map[string]interface{}{},
+		},
+		"kubelet": map[string]interface{}{
+			"cgroupfs":              "systemd",
+			"preferredAddressTypes": []string{"InternalIP", "ExternalIP", "Hostname"},
+		},
+	}
+	if config.DataStore != "" {
+		controlPlane["dataStoreName"] = config.DataStore
+	}
+
+	objects := []map[string]interface{}{
+		{
+			"apiVersion": clusterAPIVersion,
+			"kind":       "Cluster",
+			"metadata":   metadata(opts.Name),
+			"spec": map[string]interface{}{
+				"clusterNetwork": map[string]interface{}{
+					"pods":     map[string]interface{}{"cidrBlocks": []string{podCIDR}},
+					"services": map[string]interface{}{"cidrBlocks": []string{serviceCIDR}},
+				},
+				"controlPlaneRef": map[string]interface{}{
+					"apiVersion": controlPlaneAPIVersion,
+					"kind":       controlPlaneKind,
+					"name":       opts.Name,
+				},
+				"infrastructureRef": map[string]interface{}{
+					"apiVersion": infrastructure.apiVersion,
+					"kind":       infrastructure.cluster,
+					"name":       opts.Name,
+				},
+			},
+		},
+		{
+			"apiVersion": controlPlaneAPIVersion,
+			"kind":       controlPlaneKind,
+			"metadata":   metadata(opts.Name),
+			"spec":       controlPlane,
+		},
+		{
+			// The Kamaji provider fills in the control plane endpoint once its Service has an address.
+			"apiVersion": infrastructure.apiVersion,
+			"kind":       infrastructure.cluster,
+			"metadata":   metadata(opts.Name),
+			"spec":       infrastructureClusterSpec(config),
+		},
+		{
+			"apiVersion": infrastructure.apiVersion,
+			"kind":       infrastructure.template,
+			"metadata":   metadata(workers),
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{"spec": machineSpec(config, f)},
+			},
+		},
+		{
+			"apiVersion": bootstrapKind.apiVersion,
+			"kind":       bootstrapKind.template,
+			"metadata":   metadata(workers),
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{"spec": bootstrapSpec(config)},
+			},
+		},
+		{
+			"apiVersion": clusterAPIVersion,
+			"kind":       machineDeploymentKind,
+			"metadata":   metadata(workers),
+			"spec": map[string]interface{}{
+				"clusterName": opts.Name,
+				"replicas":    opts.Workers,
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{
+						ClusterNameLabel:    opts.Name,
+						deploymentNameLabel: workers,
+					},
+				},
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{
+							ClusterNameLabel:    opts.Name,
+							deploymentNameLabel: workers,
+							FlavorLabel:         f.name,
+						},
+					},
+					"spec": map[string]interface{}{
+						"clusterName": opts.Name,
+						"version":     opts.Version,
+						"bootstrap": map[string]interface{}{
+							"configRef": map[string]interface{}{
+								"apiVersion": bootstrapKind.apiVersion,
+								"kind":       bootstrapKind.template,
+								"name":       workers,
+							},
+						},
+						"infrastructureRef": map[string]interface{}{
+							"apiVersion": infrastructure.apiVersion,
+							"kind":       infrastructure.template,
+							"name":       workers,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return kubernetes.RenderManifest(objects)
+}
+
+// infrastructureClusterSpec returns the spec of the provider's cluster object.
+func infrastructureClusterSpec(config models.TenantClusters) map[string]interface{} {
+	if config.Provider != capi.InfrastructureProxmox {
+		// CAPX falls back to the Prism Central credentials of its controller.
+		return map[string]interface{}{}
+	}
+	p := config.Proxmox
+	spec := map[string]interface{}{
+		"ipv4Config": map[string]interface{}{
+			"addresses": p.Addresses,
+			"prefix":    p.Prefix,
+			"gateway":   p.Gateway,
+		},
+	}
+	if len(p.AllowedNodes) > 0 {
+		spec["allowedNodes"] = p.AllowedNodes
+	}
+	if len(p.DNSServers) > 0 {
+		spec["dnsServers"] = p.DNSServers
+	}
+	return spec
+}
+
+// machineSpec returns the worker VM spec of the provider's machine template.
+func machineSpec(config models.TenantClusters, f flavor) map[string]interface{} {
+	if config.Provider == capi.InfrastructureProxmox {
+		p := config.Proxmox
+		return map[string]interface{}{
+			"sourceNode": p.SourceNode,
+			"templateID": p.TemplateID,
+			"format":     "qcow2",
+			"full":       true,
+			"numSockets": 1,
+			"numCores":   f.cpu,
+			"memoryMiB":  f.ramGB * 1024,
+			"disks": map[string]interface{}{
+				"bootVolume": map[string]interface{}{"disk": "scsi0", "sizeGb": f.diskGB},
+			},
+			"network": map[string]interface{}{
+				"default": map[string]interface{}{"bridge": p.Bridge, "model": "virtio"},
+			},
+		}
+	}
+
+	n := config.Nutanix
+	return map[string]interface{}{
+		"providerID":     "nutanix://",
+		"bootType":       "legacy",
+		"vcpuSockets":    f.cpu,
+		"vcpusPerSocket": 1,
+		"memorySize":     fmt.Sprintf("%dGi", f.ramGB),
+		"systemDiskSize": fmt.Sprintf("%dGi", f.diskGB),
+		"image":          map[string]interface{}{"type": "name", "name": n.Image},
+		"cluster":        map[string]interface{}{"type": "uuid", "uuid": n.ClusterUUID},
+		"subnet":         []interface{}{map[string]interface{}{"type": "uuid", "uuid": n.SubnetUUID}},
+	}
+}
+
+// bootstrapSpec returns the spec of the kubeadm config template for workers.
+func bootstrapSpec(config models.TenantClusters) map[string]interface{} {
+	nodeRegistration := map[string]interface{}{
+		"name": "{{ ds.meta_data.hostname }}",
+	}
+	if config.Provider == capi.InfrastructureProxmox {
+		// CAPMOX matches nodes to VMs by the provider ID the kubelet registers with.
+		nodeRegistration["kubeletExtraArgs"] = map[string]interface{}{
+			"provider-id": "proxmox://'{{ ds.meta_data.instance_id }}'",
+		}
+	}
+	return map[string]interface{}{
+		"joinConfiguration": map[string]interface{}{"nodeRegistration": nodeRegistration},
+	}
+}
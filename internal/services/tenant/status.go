@@ -0,0 +1,105 @@
+// Package tenant manages the tenant clusters and virtual machines butlerctl creates on the Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ClusterStatus summarizes a tenant cluster from its Cluster, KamajiControlPlane and
+// MachineDeployments.
+type ClusterStatus struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Phase is the Cluster API phase, e.g. Provisioning, Provisioned or Deleting.
+	Phase        string        `json:"phase"`
+	Version      string        `json:"version"`
+	Flavor       string        `json:"flavor,omitempty"`
+	Endpoint     string        `json:"endpoint,omitempty"`
+	ControlPlane ReplicaStatus `json:"controlPlane"`
+	Workers      ReplicaStatus `json:"workers"`
+	Conditions   []Condition   `json:"conditions,omitempty"`
+	Created      time.Time     `json:"created"`
+}
+
+// ReplicaStatus counts desired and ready replicas.
+type ReplicaStatus struct {
+	Desired int64 `json:"desired"`
+	Ready   int64 `json:"ready"`
+}
+
+// Condition is a status condition reported on the Cluster.
+type Condition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// clusterStatus builds the status of a cluster. controlPlane may be nil while it is being created.
+func clusterStatus(cluster *unstructured.Unstructured, controlPlane *unstructured.Unstructured, deployments []unstructured.Unstructured) ClusterStatus {
+	status := ClusterStatus{
+		Name:      cluster.GetName(),
+		Namespace: cluster.GetNamespace(),
+		Flavor:    cluster.GetLabels()[FlavorLabel],
+		Created:   cluster.GetCreationTimestamp().Time,
+	}
+	status.Phase, _, _ = unstructured.NestedString(cluster.Object, "status", "phase")
+	if host, _, _ := unstructured.NestedString(cluster.Object, "spec", "controlPlaneEndpoint", "host"); host != "" {
+		port, _, _ := unstructured.NestedInt64(cluster.Object, "spec", "controlPlaneEndpoint", "port")
+		status.Endpoint = fmt.Sprintf("https://%s:%d", host, port)
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(cluster.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		status.Conditions = append(status.Conditions, Condition{
+			Type:    stringValue(condition["type"]),
+			Status:  stringValue(condition["status"]),
+			Reason:  stringValue(condition["reason"]),
+			Message: stringValue(condition["message"]),
+		})
+	}
+
+	if controlPlane != nil {
+		status.Version, _, _ = unstructured.NestedString(controlPlane.Object, "spec", "version")
+		status.ControlPlane.Desired, _, _ = unstructured.NestedInt64(controlPlane.Object, "spec", "replicas")
+		status.ControlPlane.Ready, _, _ = unstructured.NestedInt64(controlPlane.Object, "status", "readyReplicas")
+	}
+	for _, md := range deployments {
+		desired, _, _ := unstructured.NestedInt64(md.Object, "spec", "replicas")
+		ready, _, _ := unstructured.NestedInt64(md.Object, "status", "readyReplicas")
+		status.Workers.Desired += desired
+		status.Workers.Ready += ready
+		if status.Version == "" {
+			status.Version, _, _ = unstructured.NestedString(md.Object, "spec", "template", "spec", "version")
+		}
+	}
+	return status
+}
+
+// stringValue returns v if it is a string.
+func stringValue(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
@@ -0,0 +1,329 @@
+// Package tenant manages the tenant clusters and virtual machines butlerctl creates on the Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/readiness"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// consoleProtocol is the WebSocket subprotocol of KubeVirt's serial console.
+const consoleProtocol = "plain.kubevirt.io"
+
+// VMStatus summarizes a VirtualMachine and its running instance.
+type VMStatus struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Status is KubeVirt's printable status, e.g. Running, Stopped or Provisioning.
+	Status  string    `json:"status"`
+	Ready   bool      `json:"ready"`
+	Flavor  string    `json:"flavor,omitempty"`
+	CPU     int64     `json:"cpu,omitempty"`
+	Memory  string    `json:"memory,omitempty"`
+	IP      string    `json:"ip,omitempty"`
+	Node    string    `json:"node,omitempty"`
+	Created time.Time `json:"created"`
+}
+
+// VMService creates and operates KubeVirt virtual machines in a tenant namespace.
+type VMService struct {
+	kube   *kubernetes.KubernetesAdapter
+	config models.TenantVMs
+	logger *zap.Logger
+}
+
+// NewVMService constructs a new VMService instance.
+func NewVMService(kube *kubernetes.KubernetesAdapter, config models.TenantVMs, logger *zap.Logger) *VMService {
+	return &VMService{
+		kube:   kube,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Create creates a VM whose root disk CDI imports from an image. With a timeout, it waits until
+// a started VM is Ready.
+func (s *VMService) Create(ctx context.Context, opts VMCreateOptions, timeout time.Duration) error {
+	if err := validateVMName(opts.Name); err != nil {
+		return err
+	}
+	if opts.Flavor == "" {
+		opts.Flavor = DefaultFlavor
+	}
+	f, err := lookupFlavor(s.config.Flavors, opts.Flavor)
+	if err != nil {
+		return err
+	}
+	diskGB := f.diskGB
+	if opts.DiskSize != "" {
+		if diskGB, err = parseGB(opts.DiskSize); err != nil {
+			return fmt.Errorf("invalid disk size: %w", err)
+		}
+	}
+	if opts.Image == "" {
+		opts.Image = s.config.Image
+	}
+	source, err := resolveImage(s.config, opts.Image)
+	if err != nil {
+		return err
+	}
+	if opts.StorageClass == "" {
+		opts.StorageClass = s.config.StorageClass
+	}
+	if opts.Subnet == "" {
+		opts.Subnet = s.config.Subnet
+	}
+	network, err := s.network(ctx, opts.Subnet)
+	if err != nil {
+		return err
+	}
+
+	var userData []byte
+	var userDataSecret string
+	if opts.UserDataFile != "" {
+		if userData, err = os.ReadFile(opts.UserDataFile); err != nil {
+			return fmt.Errorf("failed to read user data: %w", err)
+		}
+		userDataSecret = userDataSecretName(opts.Name)
+	}
+
+	_, err = s.kube.Get(ctx, kubeVirtAPIVersion, "VirtualMachine", opts.Namespace, opts.Name)
+	switch {
+	case err == nil:
+		return fmt.Errorf("VM %s already exists in namespace %s", opts.Name, opts.Namespace)
+	case meta.IsNoMatchError(err):
+		return fmt.Errorf("%w; is KubeVirt installed on the management cluster?", err)
+	case !apierrors.IsNotFound(err):
+		return fmt.Errorf("failed to check for VM %s: %w", opts.Name, err)
+	}
+
+	manifest, err := renderVM(opts, f, source, diskGB, network, userDataSecret)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Creating VM",
+		zap.String("name", opts.Name),
+		zap.String("namespace", opts.Namespace),
+		zap.String("flavor", f.name),
+		zap.String("image", opts.Image),
+		zap.String("subnet", opts.Subnet),
+	)
+	if err := s.kube.Apply(ctx, manifest); err != nil {
+		return fmt.Errorf("failed to create VM %s: %w", opts.Name, err)
+	}
+
+	if userData != nil {
+		// The VM's launcher waits for the Secret, which is owned by the VM and so needs its UID.
+		vm, err := s.kube.Get(ctx, kubeVirtAPIVersion, "VirtualMachine", opts.Namespace, opts.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get VM %s: %w", opts.Name, err)
+		}
+		secret, err := renderUserDataSecret(opts.Name, opts.Namespace, string(vm.GetUID()), userData)
+		if err != nil {
+			return err
+		}
+		if err := s.kube.Apply(ctx, secret); err != nil {
+			return fmt.Errorf("failed to store user data of VM %s: %w", opts.Name, err)
+		}
+	}
+
+	if timeout == 0 || !opts.Start {
+		return nil
+	}
+	return readiness.NewWaiter(s.kube, s.logger).Wait(ctx, readiness.Resource{
+		APIVersion: kubeVirtAPIVersion,
+		Kind:       "VirtualMachine",
+		Namespace:  opts.Namespace,
+		Name:       opts.Name,
+		Condition:  "Ready",
+		Timeout:    timeout,
+	})
+}
+
+// List returns the status of every VM in a namespace, or in all namespaces when namespace is
+// empty, sorted by namespace and name.
+func (s *VMService) List(ctx context.Context, namespace string) ([]VMStatus, error) {
+	vms, err := s.kube.List(ctx, kubeVirtAPIVersion, "VirtualMachine", namespace, "")
+	if meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("%w; is KubeVirt installed on the management cluster?", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	instances, err := s.kube.List(ctx, kubeVirtAPIVersion, "VirtualMachineInstance", namespace, "")
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*unstructured.Unstructured{}
+	for i := range instances {
+		byName[instances[i].GetNamespace()+"/"+instances[i].GetName()] = &instances[i]
+	}
+
+	statuses := make([]VMStatus, 0, len(vms))
+	for i := range vms {
+		statuses = append(statuses, vmStatus(&vms[i], byName[vms[i].GetNamespace()+"/"+vms[i].GetName()]))
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Namespace != statuses[j].Namespace {
+			return statuses[i].Namespace < statuses[j].Namespace
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses, nil
+}
+
+// Start starts a stopped VM.
+func (s *VMService) Start(ctx context.Context, namespace, name string) error {
+	return s.action(ctx, namespace, name, "start")
+}
+
+// Stop shuts a VM down and keeps it stopped.
+func (s *VMService) Stop(ctx context.Context, namespace, name string) error {
+	return s.action(ctx, namespace, name, "stop")
+}
+
+// Restart restarts a running VM.
+func (s *VMService) Restart(ctx context.Context, namespace, name string) error {
+	return s.action(ctx, namespace, name, "restart")
+}
+
+// Delete deletes a VM. Its root disk and user data are owned by the VM and removed with it.
+func (s *VMService) Delete(ctx context.Context, namespace, name string) error {
+	if _, err := s.getVM(ctx, namespace, name); err != nil {
+		return err
+	}
+	s.logger.Info("Deleting VM", zap.String("name", name), zap.String("namespace", namespace))
+	return s.kube.Delete(ctx, kubeVirtAPIVersion, "VirtualMachine", namespace, name)
+}
+
+// Console attaches in and out to the serial console of a running VM until in is exhausted or the
+// console closes.
+func (s *VMService) Console(ctx context.Context, namespace, name string, in io.Reader, out io.Writer) error {
+	if _, err := s.getVM(ctx, namespace, name); err != nil {
+		return err
+	}
+	_, err := s.kube.Get(ctx, kubeVirtAPIVersion, "VirtualMachineInstance", namespace, name)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("VM %s is not running; start it with 'butlerctl vm start %s'", name, name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get VM instance %s: %w", name, err)
+	}
+
+	stream, err := s.kube.Stream(ctx, subresourcePath(namespace, "virtualmachineinstances", name, "console"), consoleProtocol)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	done := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(out, stream)
+		done <- err
+	}()
+	go func() {
+		_, err := io.Copy(stream, in)
+		done <- err
+	}()
+	return <-done
+}
+
+// action changes a VM's run state through a KubeVirt subresource such as start or stop, like virtctl.
+func (s *VMService) action(ctx context.Context, namespace, name, action string) error {
+	if _, err := s.getVM(ctx, namespace, name); err != nil {
+		return err
+	}
+	s.logger.Info("Changing VM run state", zap.String("name", name), zap.String("action", action))
+	if _, err := s.kube.Request(ctx, http.MethodPut, subresourcePath(namespace, "virtualmachines", name, action), []byte("{}")); err != nil {
+		return fmt.Errorf("failed to %s VM %s: %w", action, name, err)
+	}
+	return nil
+}
+
+// network looks up the Kube-OVN subnet a VM is placed in.
+func (s *VMService) network(ctx context.Context, subnet string) (vmNetwork, error) {
+	if subnet == "" {
+		return vmNetwork{}, nil
+	}
+	obj, err := s.kube.Get(ctx, subnetAPIVersion, "Subnet", "", subnet)
+	if apierrors.IsNotFound(err) {
+		return vmNetwork{}, fmt.Errorf("Kube-OVN subnet %s not found", subnet)
+	}
+	if err != nil {
+		return vmNetwork{}, fmt.Errorf("failed to get Kube-OVN subnet %s: %w", subnet, err)
+	}
+	provider, _, _ := unstructured.NestedString(obj.Object, "spec", "provider")
+	return subnetNetwork(subnet, provider)
+}
+
+// getVM returns a VirtualMachine, with a readable error when it does not exist.
+func (s *VMService) getVM(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	vm, err := s.kube.Get(ctx, kubeVirtAPIVersion, "VirtualMachine", namespace, name)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("VM %s not found in namespace %s", name, namespace)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VM %s: %w", name, err)
+	}
+	return vm, nil
+}
+
+// subresourcePath is the API path of a KubeVirt subresource.
+func subresourcePath(namespace, resource, name, subresource string) string {
+	return fmt.Sprintf("/apis/subresources.kubevirt.io/v1/namespaces/%s/%s/%s/%s", namespace, resource, name, subresource)
+}
+
+// vmStatus builds the status of a VM. instance is nil while the VM is stopped.
+func vmStatus(vm *unstructured.Unstructured, instance *unstructured.Unstructured) VMStatus {
+	status := VMStatus{
+		Name:      vm.GetName(),
+		Namespace: vm.GetNamespace(),
+		Flavor:    vm.GetLabels()[FlavorLabel],
+		Created:   vm.GetCreationTimestamp().Time,
+	}
+	status.Status, _, _ = unstructured.NestedString(vm.Object, "status", "printableStatus")
+	status.Ready, _, _ = unstructured.NestedBool(vm.Object, "status", "ready")
+	status.CPU, _, _ = unstructured.NestedInt64(vm.Object, "spec", "template", "spec", "domain", "cpu", "cores")
+	status.Memory, _, _ = unstructured.NestedString(vm.Object, "spec", "template", "spec", "domain", "memory", "guest")
+
+	if instance != nil {
+		status.Node, _, _ = unstructured.NestedString(instance.Object, "status", "nodeName")
+		interfaces, _, _ := unstructured.NestedSlice(instance.Object, "status", "interfaces")
+		if len(interfaces) > 0 {
+			if iface, ok := interfaces[0].(map[string]interface{}); ok {
+				status.IP = stringValue(iface["ipAddress"])
+			}
+		}
+	}
+	return status
+}
@@ -0,0 +1,248 @@
+// Package tenant manages the tenant clusters and virtual machines butlerctl creates on the Butler management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// API versions of the objects a VM is made of.
+const (
+	kubeVirtAPIVersion = "kubevirt.io/v1"
+	subnetAPIVersion   = "kubeovn.io/v1"
+)
+
+// Annotations Kube-OVN places a VM's pod network interface in a subnet with.
+const (
+	logicalSwitchAnnotation = "ovn.kubernetes.io/logical_switch"
+	// The bridge binding keeps the VM's address from the subnet; Kube-OVN keeps it across live migration.
+	bridgeMigrationAnnotation = "kubevirt.io/allow-pod-bridge-network-live-migration"
+)
+
+// VMCreateOptions describes a VM to create.
+type VMCreateOptions struct {
+	Name      string
+	Namespace string
+	Flavor    string
+	// Image is a configured image name or an image URL.
+	Image string
+	// DiskSize overrides the flavor's root disk size, e.g. "100GB".
+	DiskSize     string
+	StorageClass string
+	// Subnet is the Kube-OVN subnet the VM's network interface is placed in.
+	Subnet string
+	// UserDataFile is a cloud-init user data file, stored in a Secret owned by the VM.
+	UserDataFile string
+	// Start starts the VM once it is created; otherwise it is created stopped.
+	Start bool
+}
+
+// vmNetwork is how a VM is attached to its Kube-OVN subnet.
+type vmNetwork struct {
+	// subnet is set for subnets of the default pod network, attachment for subnets behind a
+	// Multus NetworkAttachmentDefinition ("namespace/name"). Both empty uses the pod network.
+	subnet     string
+	attachment string
+}
+
+// validateVMName checks that a VM name can be used as the VM's host name.
+func validateVMName(name string) error {
+	if errs := validation.IsDNS1035Label(name); len(errs) > 0 {
+		return fmt.Errorf("invalid VM name %q: %s", name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// resolveImage returns the DataVolume source for an image name or URL.
+func resolveImage(config models.TenantVMs, image string) (map[string]interface{}, error) {
+	if image == "" {
+		return nil, fmt.Errorf("an image is required; pass --image or set vms.image")
+	}
+
+	url := image
+	var names []string
+	for _, candidate := range config.Images {
+		if candidate.Name == image {
+			url = candidate.URL
+			break
+		}
+		names = append(names, candidate.Name)
+	}
+
+	switch {
+	case strings.HasPrefix(url, "http://"), strings.HasPrefix(url, "https://"):
+		return map[string]interface{}{"http": map[string]interface{}{"url": url}}, nil
+	case strings.HasPrefix(url, "docker://"):
+		return map[string]interface{}{"registry": map[string]interface{}{"url": url}}, nil
+	case len(names) > 0:
+		return nil, fmt.Errorf("unknown image %q; pass an http(s):// or docker:// URL or one of %s", image, strings.Join(names, ", "))
+	default:
+		return nil, fmt.Errorf("unknown image %q; pass an http(s):// or docker:// URL", image)
+	}
+}
+
+// subnetNetwork derives how a VM attaches to a Kube-OVN subnet from the subnet's provider:
+// "ovn" for the pod network, or "<attachment>.<namespace>.ovn" for a Multus attachment.
+func subnetNetwork(name, provider string) (vmNetwork, error) {
+	if provider == "" || provider == "ovn" {
+		return vmNetwork{subnet: name}, nil
+	}
+	parts := strings.Split(provider, ".")
+	if len(parts) != 3 || parts[2] != "ovn" {
+		return vmNetwork{}, fmt.Errorf("subnet %s belongs to provider %s, which is not a Kube-OVN attachment", name, provider)
+	}
+	return vmNetwork{attachment: parts[1] + "/" + parts[0]}, nil
+}
+
+// renderVM renders a VirtualMachine with a DataVolume root disk imported by CDI.
+func renderVM(opts VMCreateOptions, f flavor, source map[string]interface{}, diskGB int, network vmNetwork, userDataSecret string) ([]byte, error) {
+	runStrategy := "Halted"
+	if opts.Start {
+		runStrategy = "Always"
+	}
+	rootDisk := opts.Name + "-root"
+
+	storage := map[string]interface{}{
+		"resources": map[string]interface{}{
+			"requests": map[string]interface{}{"storage": fmt.Sprintf("%dGi", diskGB)},
+		},
+	}
+	if opts.StorageClass != "" {
+		storage["storageClassName"] = opts.StorageClass
+	}
+
+	disks := []interface{}{
+		map[string]interface{}{"name": "root", "disk": map[string]interface{}{"bus": "virtio"}},
+	}
+	volumes := []interface{}{
+		map[string]interface{}{"name": "root", "dataVolume": map[string]interface{}{"name": rootDisk}},
+	}
+	if userDataSecret != "" {
+		disks = append(disks, map[string]interface{}{"name": "cloudinit", "disk": map[string]interface{}{"bus": "virtio"}})
+		volumes = append(volumes, map[string]interface{}{
+			"name": "cloudinit",
+			"cloudInitNoCloud": map[string]interface{}{
+				"secretRef": map[string]interface{}{"name": userDataSecret},
+			},
+		})
+	}
+
+	annotations := map[string]interface{}{}
+	iface := map[string]interface{}{"name": "default", "masquerade": map[string]interface{}{}}
+	networkSpec := map[string]interface{}{"name": "default", "pod": map[string]interface{}{}}
+	switch {
+	case network.subnet != "":
+		annotations[logicalSwitchAnnotation] = network.subnet
+		annotations[bridgeMigrationAnnotation] = "true"
+		iface = map[string]interface{}{"name": "default", "bridge": map[string]interface{}{}}
+	case network.attachment != "":
+		iface = map[string]interface{}{"name": "default", "bridge": map[string]interface{}{}}
+		networkSpec = map[string]interface{}{
+			"name":   "default",
+			"multus": map[string]interface{}{"networkName": network.attachment, "default": true},
+		}
+	}
+
+	templateMetadata := map[string]interface{}{
+		"labels": map[string]interface{}{"kubevirt.io/vm": opts.Name, FlavorLabel: f.name},
+	}
+	if len(annotations) > 0 {
+		templateMetadata["annotations"] = annotations
+	}
+
+	vm := map[string]interface{}{
+		"apiVersion": kubeVirtAPIVersion,
+		"kind":       "VirtualMachine",
+		"metadata": map[string]interface{}{
+			"name":      opts.Name,
+			"namespace": opts.Namespace,
+			"labels":    map[string]interface{}{FlavorLabel: f.name},
+		},
+		"spec": map[string]interface{}{
+			"runStrategy": runStrategy,
+			"dataVolumeTemplates": []interface{}{
+				map[string]interface{}{
+					"metadata": map[string]interface{}{"name": rootDisk},
+					"spec": map[string]interface{}{
+						"source":  source,
+						"storage": storage,
+					},
+				},
+			},
+			"template": map[string]interface{}{
+				"metadata": templateMetadata,
+				"spec": map[string]interface{}{
+					"domain": map[string]interface{}{
+						"cpu":    map[string]interface{}{"cores": f.cpu},
+						"memory": map[string]interface{}{"guest": fmt.Sprintf("%dGi", f.ramGB)},
+						"devices": map[string]interface{}{
+							"disks":      disks,
+							"interfaces": []interface{}{iface},
+						},
+					},
+					"networks": []interface{}{networkSpec},
+					"volumes":  volumes,
+				},
+			},
+		},
+	}
+
+	out, err := yaml.Marshal(vm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal VirtualMachine: %w", err)
+	}
+	return out, nil
+}
+
+// userDataSecretName names the Secret holding a VM's cloud-init user data.
+func userDataSecretName(vm string) string {
+	return vm + "-cloudinit"
+}
+
+// renderUserDataSecret renders the Secret holding a VM's cloud-init user data, owned by the VM so
+// it is removed with it.
+func renderUserDataSecret(vm, namespace, vmUID string, userData []byte) ([]byte, error) {
+	secret := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]interface{}{
+			"name":      userDataSecretName(vm),
+			"namespace": namespace,
+			"ownerReferences": []interface{}{
+				map[string]interface{}{
+					"apiVersion": kubeVirtAPIVersion,
+					"kind":       "VirtualMachine",
+					"name":       vm,
+					"uid":        vmUID,
+				},
+			},
+		},
+		"type":       "Opaque",
+		"stringData": map[string]interface{}{"userdata": string(userData)},
+	}
+	out, err := yaml.Marshal(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal user data Secret: %w", err)
+	}
+	return out, nil
+}
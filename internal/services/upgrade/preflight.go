@@ -0,0 +1,171 @@
+// Package upgrade provides services for rolling Talos and Kubernetes upgrades of the management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/cluster"
+
+	"go.uber.org/zap"
+)
+
+// rollout is the outcome of the pre-flight checks: every node of the cluster, ordered, and the
+// subset that is not yet at the target version.
+type rollout struct {
+	nodes   []cluster.Node
+	pending []cluster.Node
+}
+
+// preflightTalos verifies the cluster can be upgraded to the target Talos version and returns
+// the ordered nodes, skipping those already running it.
+func (u *UpgradeService) preflightTalos(ctx context.Context, target version) (rollout, error) {
+	plan, err := u.planNodes(ctx)
+	if err != nil {
+		return rollout{}, err
+	}
+
+	result := rollout{nodes: plan}
+	for _, node := range plan {
+		raw, err := u.talos.Version(ctx, node.InternalIP)
+		if err != nil {
+			return rollout{}, err
+		}
+		current, err := parseVersion(raw)
+		if err != nil {
+			return rollout{}, fmt.Errorf("node %s: %w", node.Name, err)
+		}
+		if err := checkMinorStep("Talos", current, target); err != nil {
+			return rollout{}, fmt.Errorf("node %s: %w", node.Name, err)
+		}
+
+		kubelet, err := parseVersion(node.KubeletVersion)
+		if err != nil {
+			return rollout{}, fmt.Errorf("node %s: %w", node.Name, err)
+		}
+		if err := checkTalosSupportsKubernetes(target, kubelet); err != nil {
+			return rollout{}, fmt.Errorf("node %s: %w", node.Name, err)
+		}
+
+		if current == target {
+			u.logger.Info("Node already runs the target Talos version", zap.String("node", node.Name))
+			continue
+		}
+		result.pending = append(result.pending, node)
+	}
+	if len(result.pending) == 0 {
+		return result, nil
+	}
+
+	if err := u.talos.WaitForEtcdQuorum(ctx, controlPlaneIPs(plan), time.Minute); err != nil {
+		return rollout{}, err
+	}
+
+	u.logger.Info("Talos upgrade pre-flight checks passed", zap.Int("nodes", len(result.pending)))
+	return result, nil
+}
+
+// preflightKubernetes verifies the cluster can be upgraded to the target Kubernetes version and
+// returns the ordered nodes, skipping those whose kubelet already runs it.
+func (u *UpgradeService) preflightKubernetes(ctx context.Context, target version) (rollout, error) {
+	plan, err := u.planNodes(ctx)
+	if err != nil {
+		return rollout{}, err
+	}
+
+	result := rollout{nodes: plan}
+	for _, node := range plan {
+		current, err := parseVersion(node.KubeletVersion)
+		if err != nil {
+			return rollout{}, fmt.Errorf("node %s: %w", node.Name, err)
+		}
+		if err := checkMinorStep("Kubernetes", current, target); err != nil {
+			return rollout{}, fmt.Errorf("node %s: %w", node.Name, err)
+		}
+
+		raw, err := u.talos.Version(ctx, node.InternalIP)
+		if err != nil {
+			return rollout{}, err
+		}
+		talosVersion, err := parseVersion(raw)
+		if err != nil {
+			return rollout{}, fmt.Errorf("node %s: %w", node.Name, err)
+		}
+		if err := checkTalosSupportsKubernetes(talosVersion, target); err != nil {
+			return rollout{}, fmt.Errorf("node %s: %w", node.Name, err)
+		}
+
+		if current == target {
+			u.logger.Info("Node already runs the target kubelet version", zap.String("node", node.Name))
+			continue
+		}
+		result.pending = append(result.pending, node)
+	}
+	if len(result.pending) == 0 {
+		return result, nil
+	}
+
+	if err := u.talos.WaitForEtcdQuorum(ctx, controlPlaneIPs(plan), time.Minute); err != nil {
+		return rollout{}, err
+	}
+
+	// Let Talos run its own checks (deprecated APIs, image availability) without changing anything.
+	dryRunCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+	if err := u.talos.UpgradeKubernetesControlPlane(dryRunCtx, plan[0].InternalIP, target.String(), true); err != nil {
+		return rollout{}, err
+	}
+
+	u.logger.Info("Kubernetes upgrade pre-flight checks passed", zap.Int("nodes", len(result.pending)))
+	return result, nil
+}
+
+// planNodes lists the cluster nodes, verifies they are all Ready and orders them control planes
+// first, then workers, each group sorted by name.
+func (u *UpgradeService) planNodes(ctx context.Context) ([]cluster.Node, error) {
+	nodes, err := u.nodes.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no nodes found in the cluster")
+	}
+
+	for _, node := range nodes {
+		if !node.Ready {
+			return nil, fmt.Errorf("node %s is not Ready", node.Name)
+		}
+		if node.InternalIP == "" {
+			return nil, fmt.Errorf("node %s has no InternalIP address", node.Name)
+		}
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].ControlPlane != nodes[j].ControlPlane {
+			return nodes[i].ControlPlane
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	if !nodes[0].ControlPlane {
+		return nil, fmt.Errorf("no control plane nodes found in the cluster")
+	}
+	return nodes, nil
+}
@@ -0,0 +1,82 @@
+// Package upgrade provides services for rolling Talos and Kubernetes upgrades of the management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/butlerdotdev/butler/internal/services/cluster"
+
+	"go.uber.org/zap"
+)
+
+// upgradeFunc performs the actual upgrade of a single, already drained node.
+type upgradeFunc func(ctx context.Context, node cluster.Node) error
+
+// rollNodes upgrades the pending nodes one at a time: cordon, drain, upgrade, wait for Ready
+// (and etcd quorum across all control planes for control planes), uncordon. It stops at the
+// first failure and leaves the failing node cordoned so it can be inspected.
+func (u *UpgradeService) rollNodes(ctx context.Context, plan rollout, opts Options, kubeletVersion string, upgrade upgradeFunc) error {
+	controlPlanes := controlPlaneIPs(plan.nodes)
+
+	for i, node := range plan.pending {
+		u.logger.Info("Upgrading node",
+			zap.String("node", node.Name),
+			zap.String("ip", node.InternalIP),
+			zap.Bool("controlPlane", node.ControlPlane),
+			zap.Int("step", i+1),
+			zap.Int("total", len(plan.pending)),
+		)
+
+		if err := u.nodes.Cordon(ctx, node.Name); err != nil {
+			return err
+		}
+		if err := u.nodes.Drain(ctx, node.Name, opts.DrainTimeout); err != nil {
+			return fmt.Errorf("stopping upgrade at node %s: %w", node.Name, err)
+		}
+		if err := upgrade(ctx, node); err != nil {
+			return fmt.Errorf("stopping upgrade at node %s: %w", node.Name, err)
+		}
+		if err := u.nodes.WaitForNodeReady(ctx, node.Name, kubeletVersion, opts.NodeTimeout); err != nil {
+			return fmt.Errorf("stopping upgrade at node %s: %w", node.Name, err)
+		}
+		if node.ControlPlane {
+			if err := u.talos.WaitForEtcdQuorum(ctx, controlPlanes, opts.NodeTimeout); err != nil {
+				return fmt.Errorf("stopping upgrade at node %s: %w", node.Name, err)
+			}
+		}
+		if err := u.nodes.Uncordon(ctx, node.Name); err != nil {
+			return err
+		}
+
+		u.logger.Info("Node upgraded", zap.String("node", node.Name))
+	}
+
+	return nil
+}
+
+// controlPlaneIPs returns the internal IPs of the control plane nodes in a plan.
+func controlPlaneIPs(nodes []cluster.Node) []string {
+	var ips []string
+	for _, node := range nodes {
+		if node.ControlPlane {
+			ips = append(ips, node.InternalIP)
+		}
+	}
+	return ips
+}
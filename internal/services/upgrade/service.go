@@ -0,0 +1,176 @@
+// Package upgrade provides services for rolling Talos and Kubernetes upgrades of the management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/cluster"
+	"github.com/butlerdotdev/butler/internal/services/machineconfig"
+	"github.com/butlerdotdev/butler/pkg/adapters/exec"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubectl"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/talos"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+)
+
+// defaultKubeletImage is the upstream kubelet image Talos runs.
+const defaultKubeletImage = "ghcr.io/siderolabs/kubelet"
+
+// Options controls a rolling upgrade.
+type Options struct {
+	// Version is the target Talos or Kubernetes version, e.g. "v1.9.5".
+	Version string
+	// Image overrides the Talos installer image. Only used for Talos upgrades.
+	Image string
+	// DrainTimeout bounds how long a single node drain may take.
+	DrainTimeout time.Duration
+	// NodeTimeout bounds how long a node may take to come back Ready after its upgrade.
+	NodeTimeout time.Duration
+}
+
+// UpgradeService orchestrates rolling upgrades of the management cluster.
+type UpgradeService struct {
+	logger *zap.Logger
+	nodes  *cluster.NodeOperator
+	talos  *cluster.TalosOperator
+	config *models.BootstrapConfig
+}
+
+// NewUpgradeService initializes an UpgradeService from the bootstrap configuration.
+func NewUpgradeService(ctx context.Context, config *models.BootstrapConfig, logger *zap.Logger) (*UpgradeService, error) {
+	logger.Info("Initializing UpgradeService")
+
+	execAdapter := exec.NewClient(logger)
+
+	talosAdapter, err := platforms.GetPlatformAdapter("talos", execAdapter, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Talos adapter: %w", err)
+	}
+	talosConcrete, ok := talosAdapter.(*talos.TalosAdapter)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert TalosAdapter type")
+	}
+
+	kubectlAdapter, err := platforms.GetPlatformAdapter("kubectl", execAdapter, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Kubectl adapter: %w", err)
+	}
+	kubectlConcrete, ok := kubectlAdapter.(*kubectl.KubectlAdapter)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert KubectlAdapter type")
+	}
+
+	return &UpgradeService{
+		logger: logger,
+		nodes:  cluster.NewNodeOperator(kubectlConcrete, cluster.KubeconfigPath(config.ManagementCluster.Talos), logger),
+		talos:  cluster.NewTalosOperator(talosConcrete, cluster.TalosconfigPath(config.ManagementCluster.Talos), logger),
+		config: config,
+	}, nil
+}
+
+// UpgradeTalos rolls a Talos upgrade through every node, control planes first, one node at a time.
+func (u *UpgradeService) UpgradeTalos(ctx context.Context, opts Options) error {
+	target, err := parseVersion(opts.Version)
+	if err != nil {
+		return err
+	}
+	// Default to the installer built from the configured schematic so extensions survive the upgrade.
+	image := opts.Image
+	if image == "" {
+		refs, err := machineconfig.ResolveImages(ctx, u.config.ManagementCluster.Talos, target.String(), u.logger)
+		if err != nil {
+			return err
+		}
+		image = refs.InstallerImage
+	}
+
+	u.logger.Info("Starting Talos upgrade",
+		zap.String("cluster", u.config.ManagementCluster.Name),
+		zap.String("version", target.String()),
+		zap.String("image", image),
+	)
+
+	plan, err := u.preflightTalos(ctx, target)
+	if err != nil {
+		return fmt.Errorf("pre-flight checks failed: %w", err)
+	}
+	if len(plan.pending) == 0 {
+		u.logger.Info("All nodes already run the target Talos version, nothing to upgrade", zap.String("version", target.String()))
+		return nil
+	}
+
+	err = u.rollNodes(ctx, plan, opts, "", func(ctx context.Context, node cluster.Node) error {
+		if err := u.talos.Upgrade(ctx, node.InternalIP, image); err != nil {
+			return err
+		}
+		return u.talos.WaitForVersion(ctx, node.InternalIP, target.String(), opts.NodeTimeout)
+	})
+	if err != nil {
+		return err
+	}
+
+	u.logger.Info("Talos upgrade completed successfully", zap.String("version", target.String()))
+	return nil
+}
+
+// UpgradeKubernetes upgrades the Kubernetes control plane components and then rolls the
+// kubelet upgrade through every node, control planes first, one node at a time.
+func (u *UpgradeService) UpgradeKubernetes(ctx context.Context, opts Options) error {
+	target, err := parseVersion(opts.Version)
+	if err != nil {
+		return err
+	}
+
+	u.logger.Info("Starting Kubernetes upgrade",
+		zap.String("cluster", u.config.ManagementCluster.Name),
+		zap.String("version", target.String()),
+	)
+
+	plan, err := u.preflightKubernetes(ctx, target)
+	if err != nil {
+		return fmt.Errorf("pre-flight checks failed: %w", err)
+	}
+	if len(plan.pending) == 0 {
+		u.logger.Info("All nodes already run the target Kubernetes version, nothing to upgrade", zap.String("version", target.String()))
+		return nil
+	}
+
+	// Static pods and bootstrap manifests are upgraded by Talos across all control planes at once.
+	upgradeCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+	defer cancel()
+	if err := u.talos.UpgradeKubernetesControlPlane(upgradeCtx, plan.nodes[0].InternalIP, target.String(), false); err != nil {
+		return err
+	}
+
+	kubeletPatch := fmt.Sprintf(`[{"op": "replace", "path": "/machine/kubelet/image", "value": "%s:%s"}]`,
+		defaultKubeletImage, target)
+
+	err = u.rollNodes(ctx, plan, opts, target.String(), func(ctx context.Context, node cluster.Node) error {
+		return u.talos.PatchMachineConfig(ctx, node.InternalIP, kubeletPatch)
+	})
+	if err != nil {
+		return err
+	}
+
+	u.logger.Info("Kubernetes upgrade completed successfully", zap.String("version", target.String()))
+	return nil
+}
@@ -0,0 +1,118 @@
+// Package upgrade provides services for rolling Talos and Kubernetes upgrades of the management cluster.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// version is a parsed vMAJOR.MINOR.PATCH release number.
+type version struct {
+	major int
+	minor int
+	patch int
+}
+
+// parseVersion parses versions such as "v1.9.5" or "1.31.2". Pre-release and build
+// suffixes are ignored.
+func parseVersion(raw string) (version, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(raw), "v")
+	if idx := strings.IndexAny(trimmed, "-+"); idx >= 0 {
+		trimmed = trimmed[:idx]
+	}
+
+	parts := strings.Split(trimmed, ".")
+	if len(parts) != 3 {
+		return version{}, fmt.Errorf("invalid version %q: expected vMAJOR.MINOR.PATCH", raw)
+	}
+
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return version{}, fmt.Errorf("invalid version %q: %w", raw, err)
+		}
+		nums[i] = n
+	}
+	return version{major: nums[0], minor: nums[1], patch: nums[2]}, nil
+}
+
+// String renders the version with a leading "v".
+func (v version) String() string {
+	return fmt.Sprintf("v%d.%d.%d", v.major, v.minor, v.patch)
+}
+
+// less reports whether v is an older release than other.
+func (v version) less(other version) bool {
+	if v.major != other.major {
+		return v.major < other.major
+	}
+	if v.minor != other.minor {
+		return v.minor < other.minor
+	}
+	return v.patch < other.patch
+}
+
+// kubernetesRange is the inclusive range of Kubernetes minor versions a Talos release supports.
+type kubernetesRange struct {
+	min int
+	max int
+}
+
+// talosKubernetesSupport maps Talos 1.x minor releases to the Kubernetes 1.x minors they support,
+// as published in the Talos support matrix.
+var talosKubernetesSupport = map[int]kubernetesRange{
+	6:  {min: 24, max: 29},
+	7:  {min: 25, max: 30},
+	8:  {min: 26, max: 31},
+	9:  {min: 27, max: 32},
+	10: {min: 28, max: 33},
+	11: {min: 29, max: 34},
+}
+
+// checkTalosSupportsKubernetes returns an error if the given Talos release does not support
+// the given Kubernetes release.
+func checkTalosSupportsKubernetes(talosVersion, kubernetesVersion version) error {
+	if talosVersion.major != 1 || kubernetesVersion.major != 1 {
+		return fmt.Errorf("unsupported major version (Talos %s, Kubernetes %s)", talosVersion, kubernetesVersion)
+	}
+
+	supported, ok := talosKubernetesSupport[talosVersion.minor]
+	if !ok {
+		return fmt.Errorf("Talos %s is not in Butler's compatibility matrix", talosVersion)
+	}
+	if kubernetesVersion.minor < supported.min || kubernetesVersion.minor > supported.max {
+		return fmt.Errorf("Talos %s supports Kubernetes v1.%d to v1.%d, not %s",
+			talosVersion, supported.min, supported.max, kubernetesVersion)
+	}
+	return nil
+}
+
+// checkMinorStep returns an error if moving from current to target is a downgrade or skips
+// a minor release. A node already at target passes; the rollout skips it.
+func checkMinorStep(component string, current, target version) error {
+	if target.less(current) {
+		return fmt.Errorf("%s downgrade from %s to %s is not supported", component, current, target)
+	}
+	if target.major != current.major || target.minor-current.minor > 1 {
+		return fmt.Errorf("%s upgrade from %s to %s skips a minor release; upgrade one minor version at a time",
+			component, current, target)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+// Package virtualization installs KubeVirt and CDI so the management cluster can run virtual machines.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package virtualization
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"time"
+
+	"github.com/butlerdotdev/butler/internal/services/cluster"
+	"github.com/butlerdotdev/butler/internal/services/cni"
+	"github.com/butlerdotdev/butler/internal/services/readiness"
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/kubernetes"
+	"github.com/butlerdotdev/butler/pkg/models"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+)
+
+// Namespaces of the KubeVirt and CDI operators.
+const (
+	KubeVirtNamespace = "kubevirt"
+	CDINamespace      = "cdi"
+)
+
+// Releases Butler is tested with.
+const (
+	DefaultKubeVirtVersion = "v1.4.0"
+	DefaultCDIVersion      = "v1.61.0"
+	DefaultMultusVersion   = "v4.1.4"
+)
+
+const (
+	kubeVirtOperatorURL = "https://github.com/kubevirt/kubevirt/releases/download/%s/kubevirt-operator.yaml"
+	cdiOperatorURL      = "https://github.com/kubevirt/containerized-data-importer/releases/download/%s/cdi-operator.yaml"
+	multusURL           = "https://raw.githubusercontent.com/k8snetworkplumbingwg/multus-cni/%s/deployments/multus-daemonset-thick.yml"
+)
+
+// networkName matches the names Kube-OVN subnets and NetworkAttachmentDefinitions accept.
+var networkName = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// KubeVirtInstaller installs KubeVirt, CDI and the Multus attachments VMs use.
+type KubeVirtInstaller struct {
+	kube   *kubernetes.KubernetesAdapter
+	talos  *cluster.TalosOperator
+	logger *zap.Logger
+}
+
+// NewKubeVirtInstaller constructs a new KubeVirtInstaller instance.
+func NewKubeVirtInstaller(kube *kubernetes.KubernetesAdapter, talos *cluster.TalosOperator, logger *zap.Logger) *KubeVirtInstaller {
+	return &KubeVirtInstaller{
+		kube:   kube,
+		talos:  talos,
+		logger: logger,
+	}
+}
+
+// Validate checks the virtualization settings. VM networks are Kube-OVN subnets, so they need the
+// Kube-OVN CNI and names that no network.kubeOvn subnet uses.
+func Validate(config models.VirtualizationConfig, network models.NetworkConfig) error {
+	if !config.Enabled || len(config.Networks) == 0 {
+		return nil
+	}
+	if network.CNI != "" && network.CNI != cni.KubeOvn {
+		return fmt.Errorf("virtualization.networks need the %s CNI", cni.KubeOvn)
+	}
+
+	names := map[string]bool{}
+	for _, subnet := range network.KubeOvn.Subnets {
+		names[subnet.Name] = true
+	}
+	for _, vmNetwork := range config.Networks {
+		if !networkName.MatchString(vmNetwork.Name) {
+			return fmt.Errorf("invalid virtualization network name %q", vmNetwork.Name)
+		}
+		if names[vmNetwork.Name] {
+			return fmt.Errorf("virtualization network %s must not share its name with another network or Kube-OVN subnet", vmNetwork.Name)
+		}
+		names[vmNetwork.Name] = true
+
+		if _, _, err := net.ParseCIDR(vmNetwork.CIDR); err != nil {
+			return fmt.Errorf("virtualization network %s has invalid cidr %q: %w", vmNetwork.Name, vmNetwork.CIDR, err)
+		}
+		if vmNetwork.Gateway != "" && net.ParseIP(vmNetwork.Gateway) == nil {
+			return fmt.Errorf("virtualization network %s has invalid gateway %q", vmNetwork.Name, vmNetwork.Gateway)
+		}
+	}
+	return nil
+}
+
+// Install installs the KubeVirt and CDI operators and their custom resources, then the Multus
+// attachments for the configured networks. nodes are the IPs of the nodes VMs run on; they decide
+// whether KubeVirt has to fall back to software emulation.
+func (k *KubeVirtInstaller) Install(ctx context.Context, server string, config models.VirtualizationConfig, nodes []string) error {
+	kube := k.kube.WithServer(server)
+	waiter := readiness.NewWaiter(kube, k.logger)
+
+	emulation, err := k.useEmulation(ctx, config, nodes)
+	if err != nil {
+		return err
+	}
+
+	version := versionOr(config.KubeVirtVersion, DefaultKubeVirtVersion)
+	k.logger.Info("Installing KubeVirt", zap.String("version", version), zap.Bool("emulation", emulation))
+	if err := kube.ApplyURL(ctx, fmt.Sprintf(kubeVirtOperatorURL, version)); err != nil {
+		return fmt.Errorf("failed to apply KubeVirt operator: %w", err)
+	}
+	if err := waiter.Wait(ctx,
+		readiness.CRD("kubevirts.kubevirt.io"),
+		readiness.Deployment(KubeVirtNamespace, "virt-operator").WithTimeout(5*time.Minute),
+	); err != nil {
+		return fmt.Errorf("KubeVirt operator did not become ready: %w", err)
+	}
+	if err := apply(ctx, kube, kubeVirtResource(emulation)); err != nil {
+		return fmt.Errorf("failed to apply KubeVirt: %w", err)
+	}
+	if err := waiter.Wait(ctx, readiness.Resource{
+		APIVersion: "kubevirt.io/v1", Kind: "KubeVirt", Namespace: KubeVirtNamespace, Name: "kubevirt",
+		Condition: "Available", Timeout: 15 * time.Minute,
+	}); err != nil {
+		return fmt.Errorf("KubeVirt did not become available: %w", err)
+	}
+
+	version = versionOr(config.CDIVersion, DefaultCDIVersion)
+	k.logger.Info("Installing CDI", zap.String("version", version))
+	if err := kube.ApplyURL(ctx, fmt.Sprintf(cdiOperatorURL, version)); err != nil {
+		return fmt.Errorf("failed to apply CDI operator: %w", err)
+	}
+	if err := waiter.Wait(ctx,
+		readiness.CRD("cdis.cdi.kubevirt.io"),
+		readiness.Deployment(CDINamespace, "cdi-operator").WithTimeout(5*time.Minute),
+	); err != nil {
+		return fmt.Errorf("CDI operator did not become ready: %w", err)
+	}
+	if err := apply(ctx, kube, cdiResource()); err != nil {
+		return fmt.Errorf("failed to apply CDI: %w", err)
+	}
+	if err := waiter.Wait(ctx, readiness.Resource{
+		APIVersion: "cdi.kubevirt.io/v1beta1", Kind: "CDI", Name: "cdi",
+		Condition: "Available", Timeout: 10 * time.Minute,
+	}); err != nil {
+		return fmt.Errorf("CDI did not become available: %w", err)
+	}
+
+	if len(config.Networks) > 0 {
+		if err := k.configureNetworks(ctx, kube, config); err != nil {
+			return err
+		}
+	}
+
+	k.logger.Info("KubeVirt installed successfully", zap.Bool("emulation", emulation), zap.Int("networks", len(config.Networks)))
+	return nil
+}
+
+// useEmulation returns whether KubeVirt must emulate CPUs. Unless configured, it is needed when
+// any node that runs VMs does not expose KVM.
+func (k *KubeVirtInstaller) useEmulation(ctx context.Context, config models.VirtualizationConfig, nodes []string) (bool, error) {
+	var missing []string
+	for _, node := range nodes {
+		support, err := k.talos.Virtualization(ctx, node)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case !support.KVM:
+			missing = append(missing, node)
+			k.logger.Warn("Node does not expose KVM", zap.String("node", node), zap.Bool("virtualMachine", support.Nested))
+		case support.Nested:
+			k.logger.Info("Node supports nested virtualization", zap.String("node", node))
+		default:
+			k.logger.Info("Node supports hardware virtualization", zap.String("node", node))
+		}
+	}
+
+	if config.Emulation != nil {
+		if !*config.Emulation && len(missing) > 0 {
+			return false, fmt.Errorf("virtualization.emulation is false, but nodes %v do not expose KVM; enable nested virtualization on their hypervisor", missing)
+		}
+		return *config.Emulation, nil
+	}
+	if len(missing) > 0 {
+		k.logger.Warn("Enabling KubeVirt software emulation; VMs will run slowly", zap.Strings("nodes", missing))
+		return true, nil
+	}
+	return false, nil
+}
+
+// configureNetworks installs Multus and applies the attachments and their Kube-OVN subnets.
+func (k *KubeVirtInstaller) configureNetworks(ctx context.Context, kube *kubernetes.KubernetesAdapter, config models.VirtualizationConfig) error {
+	version := versionOr(config.MultusVersion, DefaultMultusVersion)
+	k.logger.Info("Installing Multus", zap.String("version", version))
+	if err := kube.ApplyURL(ctx, fmt.Sprintf(multusURL, version)); err != nil {
+		return fmt.Errorf("failed to apply Multus: %w", err)
+	}
+
+	waiter := readiness.NewWaiter(kube, k.logger)
+	if err := waiter.Wait(ctx,
+		readiness.CRD("network-attachment-definitions.k8s.cni.cncf.io"),
+		readiness.DaemonSet("kube-system", "kube-multus-ds").WithTimeout(5*time.Minute),
+	); err != nil {
+		return fmt.Errorf("Multus did not become ready: %w", err)
+	}
+
+	manifest, subnets, err := cni.RenderKubeOvnAttachments(config.Networks)
+	if err != nil {
+		return err
+	}
+	if err := kube.Apply(ctx, manifest); err != nil {
+		return fmt.Errorf("failed to apply VM network attachments: %w", err)
+	}
+	if err := waiter.Wait(ctx, subnets...); err != nil {
+		return fmt.Errorf("VM network subnets did not become ready: %w", err)
+	}
+	return nil
+}
+
+// kubeVirtResource is the KubeVirt custom resource. VMs are live migrated when KubeVirt updates.
+func kubeVirtResource(emulation bool) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "kubevirt.io/v1",
+		"kind":       "KubeVirt",
+		"metadata":   map[string]interface{}{"name": "kubevirt", "namespace": KubeVirtNamespace},
+		"spec": map[string]interface{}{
+			"configuration": map[string]interface{}{
+				"developerConfiguration": map[string]interface{}{"useEmulation": emulation},
+			},
+			"workloadUpdateStrategy": map[string]interface{}{
+				"workloadUpdateMethods": []string{"LiveMigrate"},
+			},
+		},
+	}
+}
+
+// cdiResource is the CDI custom resource. Imports wait for the first consumer so volumes are
+// created where the VM is scheduled.
+func cdiResource() map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "cdi.kubevirt.io/v1beta1",
+		"kind":       "CDI",
+		"metadata":   map[string]interface{}{"name": "cdi"},
+		"spec": map[string]interface{}{
+			"config": map[string]interface{}{
+				"featureGates": []string{"HonorWaitForFirstConsumer"},
+			},
+			"imagePullPolicy": "IfNotPresent",
+		},
+	}
+}
+
+// apply server-side applies a single object.
+func apply(ctx context.Context, kube *kubernetes.KubernetesAdapter, obj map[string]interface{}) error {
+	manifest, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return kube.Apply(ctx, manifest)
+}
+
+func versionOr(version, fallback string) string {
+	if version == "" {
+		return fallback
+	}
+	return version
+}
@@ -0,0 +1,38 @@
+// Package templates embeds the Flux manifests Butler renders into the GitOps repository and
+// holds the template functions shared by Butler's manifest templates.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"embed"
+	"encoding/json"
+	"text/template"
+)
+
+// FS holds the component templates, one file per manifest of a component directory.
+//
+//go:embed *.tmpl
+var FS embed.FS
+
+// FuncMap holds the functions available to Butler's manifest templates. json renders a value as
+// JSON, which is also a valid YAML scalar and keeps user-supplied values safely quoted.
+var FuncMap = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		out, err := json.Marshal(v)
+		return string(out), err
+	},
+}
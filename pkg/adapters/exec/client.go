@@ -37,10 +37,16 @@ func NewClient(logger *zap.Logger) *Client {
 }
 
 func (c *Client) RunCommand(ctx context.Context, cmd string, args ...string) (models.CommandResult, error) {
-	c.logger.Info("Executing command", zap.String("command", cmd), zap.Strings("args", args))
+	// Secrets such as Git passwords are passed as flags, so they are masked before logging.
+	c.logger.Info("Executing command", zap.String("command", cmd), zap.Strings("args", RedactArgs(args)))
 
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
+	// Long-running commands (drains, upgrades) set their own deadline on the context;
+	// everything else gets the default 30 second budget.
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+	}
 
 	command := exec.CommandContext(ctx, cmd, args...)
 	var stdout, stderr bytes.Buffer
@@ -51,7 +57,7 @@ func (c *Client) RunCommand(ctx context.Context, cmd string, args ...string) (mo
 
 	result := models.CommandResult{
 		Command: cmd,
-		Args:    args,
+		Args:    RedactArgs(args),
 		Stdout:  stdout.String(),
 		Stderr:  stderr.String(),
 		Success: err == nil,
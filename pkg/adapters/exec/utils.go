@@ -31,3 +31,24 @@ func ValidateCommand(cmd string) error {
 	}
 	return errors.New("unauthorized command execution attempt")
 }
+
+// sensitiveFlags take a secret value that must not appear in logs.
+var sensitiveFlags = []string{"--password", "--token", "--secret"}
+
+// RedactArgs returns a copy of args with the values of sensitive flags masked, in both the
+// "--flag value" and "--flag=value" forms.
+func RedactArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	copy(redacted, args)
+	for i, arg := range redacted {
+		for _, flag := range sensitiveFlags {
+			switch {
+			case arg == flag && i+1 < len(redacted):
+				redacted[i+1] = "<redacted>"
+			case strings.HasPrefix(arg, flag+"="):
+				redacted[i] = flag + "=<redacted>"
+			}
+		}
+	}
+	return redacted
+}
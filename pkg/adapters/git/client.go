@@ -17,42 +17,87 @@ package git
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	"go.uber.org/zap"
 )
 
-// GitClient implements the GitAdapter interface
-type GitClient struct {
-	RepoURL  string
+const (
+	defaultAuthorName  = "Butler Automation"
+	defaultAuthorEmail = "butler@butler.dev"
+
+	// maxPushAttempts bounds how often a rejected push is rebased and retried.
+	maxPushAttempts = 3
+)
+
+// Options configure a GitClient.
+type Options struct {
+	RepoURL string
+	// Branch is the branch to clone. Empty uses the remote's default branch.
+	Branch string
+	// Username and Token authenticate over HTTPS. Username defaults to oauth2.
 	Username string
 	Token    string
-	Logger   *zap.Logger
+	// SSHKeyFile authenticates over SSH. Host keys are checked against KnownHostsFile, or the
+	// user's known_hosts files when it is empty.
+	SSHKeyFile       string
+	SSHKeyPassphrase string
+	KnownHostsFile   string
+	// CABundle is a PEM bundle for HTTPS servers with certificates from a private CA.
+	CABundle []byte
+	// AuthorName and AuthorEmail default to Butler Automation <butler@butler.dev>.
+	AuthorName  string
+	AuthorEmail string
+	// SigningFormat is gpg or ssh. SigningKey is the GPG key ID (empty for the default key) or
+	// the SSH private key file.
+	SigningFormat string
+	SigningKey    string
+}
+
+// GitClient implements the GitAdapter interface
+type GitClient struct {
+	opts   Options
+	signer git.Signer
+	logger *zap.Logger
 }
 
 // NewGitClient initializes a Git client
-func NewGitClient(repoURL, username, token string, logger *zap.Logger) *GitClient {
-	return &GitClient{
-		RepoURL:  repoURL,
-		Username: username,
-		Token:    token,
-		Logger:   logger,
+func NewGitClient(opts Options, logger *zap.Logger) (*GitClient, error) {
+	signer, err := newSigner(opts.SigningFormat, opts.SigningKey)
+	if err != nil {
+		return nil, err
 	}
+	return &GitClient{
+		opts:   opts,
+		signer: signer,
+		logger: logger,
+	}, nil
 }
 
 // CloneRepo clones a Git repository to the specified local path
 func (g *GitClient) CloneRepo(ctx context.Context, localPath string) error {
-	g.Logger.Info("Cloning Git repository", zap.String("repo", g.RepoURL), zap.String("path", localPath))
+	g.logger.Info("Cloning Git repository", zap.String("repo", g.opts.RepoURL), zap.String("path", localPath))
+
+	auth, err := g.auth()
+	if err != nil {
+		return err
+	}
 
 	// Check if the repo already exists
 	if _, err := os.Stat(filepath.Join(localPath, ".git")); err == nil {
-		g.Logger.Info("Repository already cloned, pulling latest changes...")
+		g.logger.Info("Repository already cloned, pulling latest changes...")
 		repo, err := git.PlainOpen(localPath)
 		if err != nil {
 			return fmt.Errorf("failed to open existing repo: %w", err)
@@ -61,38 +106,90 @@ func (g *GitClient) CloneRepo(ctx context.Context, localPath string) error {
 		if err != nil {
 			return fmt.Errorf("failed to get worktree: %w", err)
 		}
-		err = w.Pull(&git.PullOptions{
+		err = w.PullContext(ctx, &git.PullOptions{
 			RemoteName: "origin",
-			Auth: &http.BasicAuth{
-				Username: "oauth2",
-				Password: g.Token,
-			},
+			Auth:       auth,
+			CABundle:   g.opts.CABundle,
 		})
 		if err != nil && err != git.NoErrAlreadyUpToDate {
 			return fmt.Errorf("failed to pull latest changes: %w", err)
 		}
-		g.Logger.Info("Git repository is up-to-date")
+		g.logger.Info("Git repository is up-to-date")
 		return nil
 	}
 
 	// Clone the repo if it doesn't exist
-	_, err := git.PlainClone(localPath, false, &git.CloneOptions{
-		URL: g.RepoURL,
-		Auth: &http.BasicAuth{
-			Username: "oauth2",
-			Password: g.Token,
-		},
+	opts := &git.CloneOptions{
+		URL:      g.opts.RepoURL,
+		Auth:     auth,
+		CABundle: g.opts.CABundle,
 		Progress: os.Stdout,
-	})
-	if err != nil {
+	}
+	if g.opts.Branch != "" {
+		opts.ReferenceName = plumbing.NewBranchReferenceName(g.opts.Branch)
+	}
+	if _, err := git.PlainCloneContext(ctx, localPath, false, opts); err != nil {
 		return fmt.Errorf("failed to clone repository: %w", err)
 	}
 
-	g.Logger.Info("Git repository cloned successfully", zap.String("path", localPath))
+	g.logger.Info("Git repository cloned successfully", zap.String("path", localPath))
+	return nil
+}
+
+// CurrentBranch returns the branch checked out in the repository.
+func (g *GitClient) CurrentBranch(ctx context.Context, localPath string) (string, error) {
+	repo, err := git.PlainOpen(localPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open repository: %w", err)
+	}
+	return currentBranch(repo)
+}
+
+// CreateBranch creates a branch at HEAD and checks it out. An existing branch is checked out as is.
+func (g *GitClient) CreateBranch(ctx context.Context, localPath, branch string) error {
+	repo, err := git.PlainOpen(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+	w, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	ref := plumbing.NewBranchReferenceName(branch)
+	_, err = repo.Reference(ref, false)
+	create := errors.Is(err, plumbing.ErrReferenceNotFound)
+	if err != nil && !create {
+		return fmt.Errorf("failed to look up branch %s: %w", branch, err)
+	}
+
+	// Keep uncommitted changes, which are carried over to the new branch.
+	if err := w.Checkout(&git.CheckoutOptions{Branch: ref, Create: create, Keep: true}); err != nil {
+		return fmt.Errorf("failed to check out branch %s: %w", branch, err)
+	}
+	g.logger.Info("Checked out branch", zap.String("branch", branch), zap.Bool("created", create))
 	return nil
 }
 
-// CommitAndPush stages, commits, and pushes changes
+// HasChanges reports whether the worktree has uncommitted changes.
+func (g *GitClient) HasChanges(ctx context.Context, localPath string) (bool, error) {
+	repo, err := git.PlainOpen(localPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to open repository: %w", err)
+	}
+	w, err := repo.Worktree()
+	if err != nil {
+		return false, fmt.Errorf("failed to get worktree: %w", err)
+	}
+	status, err := w.Status()
+	if err != nil {
+		return false, fmt.Errorf("failed to get worktree status: %w", err)
+	}
+	return !status.IsClean(), nil
+}
+
+// CommitAndPush stages, commits, and pushes changes to the current branch. A push rejected
+// because the remote branch moved is rebased onto it and retried.
 func (g *GitClient) CommitAndPush(ctx context.Context, localPath, commitMessage string) error {
 	repo, err := git.PlainOpen(localPath)
 	if err != nil {
@@ -104,9 +201,8 @@ func (g *GitClient) CommitAndPush(ctx context.Context, localPath, commitMessage
 		return fmt.Errorf("failed to get worktree: %w", err)
 	}
 
-	// Stage changes
-	_, err = w.Add(".")
-	if err != nil {
+	// Stage changes, including deletions
+	if err := w.AddWithOptions(&git.AddOptions{All: true}); err != nil {
 		return fmt.Errorf("failed to stage changes: %w", err)
 	}
 
@@ -117,33 +213,131 @@ func (g *GitClient) CommitAndPush(ctx context.Context, localPath, commitMessage
 	}
 
 	if status.IsClean() {
-		g.Logger.Info("No changes detected, skipping commit.")
+		g.logger.Info("No changes detected, skipping commit.")
 		return nil
 	}
 
 	// Commit changes
-	_, err = w.Commit(commitMessage, &git.CommitOptions{
-		Author: &object.Signature{
-			Name:  "Butler Automation",
-			Email: "butler@butler.dev",
-			When:  time.Now(),
-		},
-	})
-	if err != nil {
+	if _, err := w.Commit(commitMessage, g.commitOptions(nil)); err != nil {
 		return fmt.Errorf("failed to commit changes: %w", err)
 	}
 
+	branch, err := currentBranch(repo)
+	if err != nil {
+		return err
+	}
+
 	// Push changes to remote
-	err = repo.Push(&git.PushOptions{
-		Auth: &http.BasicAuth{
-			Username: g.Username,
-			Password: g.Token,
-		},
+	for attempt := 1; ; attempt++ {
+		err = g.push(ctx, repo, branch)
+		if err == nil || errors.Is(err, git.NoErrAlreadyUpToDate) {
+			break
+		}
+		if !isNonFastForward(err) || attempt == maxPushAttempts {
+			return fmt.Errorf("failed to push changes: %w", err)
+		}
+
+		g.logger.Warn("Push rejected because the remote branch moved, rebasing",
+			zap.String("branch", branch),
+			zap.Int("attempt", attempt),
+		)
+		if err := g.rebase(ctx, repo, branch); err != nil {
+			return err
+		}
+	}
+
+	g.logger.Info("Git changes pushed successfully", zap.String("branch", branch))
+	return nil
+}
+
+// push pushes a local branch to the branch of the same name on origin.
+func (g *GitClient) push(ctx context.Context, repo *git.Repository, branch string) error {
+	auth, err := g.auth()
+	if err != nil {
+		return err
+	}
+	ref := plumbing.NewBranchReferenceName(branch)
+	return repo.PushContext(ctx, &git.PushOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{config.RefSpec(ref + ":" + ref)},
+		Auth:       auth,
+		CABundle:   g.opts.CABundle,
 	})
+}
+
+// commitOptions returns the author, committer and signer for a commit. A replayed commit keeps
+// its original author.
+func (g *GitClient) commitOptions(author *object.Signature) *git.CommitOptions {
+	committer := &object.Signature{
+		Name:  g.opts.AuthorName,
+		Email: g.opts.AuthorEmail,
+		When:  time.Now(),
+	}
+	if committer.Name == "" {
+		committer.Name = defaultAuthorName
+	}
+	if committer.Email == "" {
+		committer.Email = defaultAuthorEmail
+	}
+	if author == nil {
+		author = committer
+	}
+	return &git.CommitOptions{
+		Author:    author,
+		Committer: committer,
+		Signer:    g.signer,
+	}
+}
+
+// auth returns the transport credentials for the configured authentication method.
+func (g *GitClient) auth() (transport.AuthMethod, error) {
+	if g.opts.SSHKeyFile != "" {
+		user := "git"
+		if ep, err := transport.NewEndpoint(g.opts.RepoURL); err == nil && ep.User != "" {
+			user = ep.User
+		}
+		keys, err := ssh.NewPublicKeysFromFile(user, g.opts.SSHKeyFile, g.opts.SSHKeyPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load SSH key %s: %w", g.opts.SSHKeyFile, err)
+		}
+		var knownHosts []string
+		if g.opts.KnownHostsFile != "" {
+			knownHosts = append(knownHosts, g.opts.KnownHostsFile)
+		}
+		callback, err := ssh.NewKnownHostsCallback(knownHosts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load known hosts: %w", err)
+		}
+		keys.HostKeyCallback = callback
+		return keys, nil
+	}
+
+	if g.opts.Token != "" {
+		username := g.opts.Username
+		if username == "" {
+			username = "oauth2"
+		}
+		return &http.BasicAuth{Username: username, Password: g.opts.Token}, nil
+	}
+	return nil, nil
+}
+
+// currentBranch returns the short name of the branch HEAD points to.
+func currentBranch(repo *git.Repository) (string, error) {
+	head, err := repo.Head()
 	if err != nil {
-		return fmt.Errorf("failed to push changes: %w", err)
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	if !head.Name().IsBranch() {
+		return "", fmt.Errorf("HEAD is not on a branch")
 	}
+	return head.Name().Short(), nil
+}
 
-	g.Logger.Info("Git changes pushed successfully")
-	return nil
+// isNonFastForward reports whether a push failed because the remote branch has commits the
+// local branch does not.
+func isNonFastForward(err error) bool {
+	return errors.Is(err, git.ErrNonFastForwardUpdate) ||
+		strings.Contains(err.Error(), "non-fast-forward") ||
+		strings.Contains(err.Error(), "fetch first")
 }
@@ -0,0 +1,283 @@
+// Package git defines an adapter for git commands natively within Butler.
+//
+// # Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package git
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Forges that can open pull or merge requests.
+const (
+	ForgeGitHub = "github"
+	ForgeGitLab = "gitlab"
+	ForgeGitea  = "gitea"
+)
+
+// PullRequestOptions describe a pull request from Head into Base.
+type PullRequestOptions struct {
+	Title string
+	Body  string
+	Head  string
+	Base  string
+}
+
+// PullRequest is an open pull or merge request.
+type PullRequest struct {
+	Number int
+	URL    string
+}
+
+// ForgeClient opens pull requests through the REST API of GitHub, GitLab or Gitea.
+type ForgeClient struct {
+	kind   string
+	apiURL string
+	owner  string
+	repo   string
+	token  string
+	client *http.Client
+	logger *zap.Logger
+}
+
+// ForgeAPIURL returns the API root of a forge on host, e.g. https://api.github.com for github.com.
+func ForgeAPIURL(kind, host string) (string, error) {
+	switch kind {
+	case ForgeGitHub:
+		if host == "" || host == "github.com" {
+			return "https://api.github.com", nil
+		}
+		return "https://" + host + "/api/v3", nil
+	case ForgeGitLab:
+		if host == "" {
+			host = "gitlab.com"
+		}
+		return "https://" + host + "/api/v4", nil
+	case ForgeGitea:
+		if host == "" {
+			return "", fmt.Errorf("a hostname is required for %s", ForgeGitea)
+		}
+		return "https://" + host + "/api/v1", nil
+	default:
+		return "", fmt.Errorf("pull requests are not supported for %q (must be %s, %s or %s)", kind, ForgeGitHub, ForgeGitLab, ForgeGitea)
+	}
+}
+
+// NewForgeClient creates a ForgeClient for owner/repo. caBundle, if set, is trusted for the API's
+// TLS certificate.
+func NewForgeClient(kind, apiURL, owner, repo, token string, caBundle []byte, logger *zap.Logger) (*ForgeClient, error) {
+	switch kind {
+	case ForgeGitHub, ForgeGitLab, ForgeGitea:
+	default:
+		return nil, fmt.Errorf("pull requests are not supported for %q (must be %s, %s or %s)", kind, ForgeGitHub, ForgeGitLab, ForgeGitea)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no certificates found in CA bundle")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	return &ForgeClient{
+		kind:   kind,
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		owner:  owner,
+		repo:   repo,
+		token:  token,
+		client: &http.Client{Transport: transport, Timeout: 30 * time.Second},
+		logger: logger,
+	}, nil
+}
+
+// OpenPullRequest opens a pull request, or returns the one already open for the same branches.
+func (f *ForgeClient) OpenPullRequest(ctx context.Context, opts PullRequestOptions) (*PullRequest, error) {
+	f.logger.Info("Opening pull request",
+		zap.String("forge", f.kind),
+		zap.String("repo", f.owner+"/"+f.repo),
+		zap.String("head", opts.Head),
+		zap.String("base", opts.Base),
+	)
+
+	var (
+		pr  *PullRequest
+		err error
+	)
+	switch f.kind {
+	case ForgeGitHub:
+		pr, err = f.openGitHub(ctx, opts)
+	case ForgeGitLab:
+		pr, err = f.openGitLab(ctx, opts)
+	case ForgeGitea:
+		pr, err = f.openGitea(ctx, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pull request for %s: %w", opts.Head, err)
+	}
+
+	f.logger.Info("Pull request open", zap.Int("number", pr.Number), zap.String("url", pr.URL))
+	return pr, nil
+}
+
+// githubPull is the part of a GitHub or Gitea pull request Butler reads.
+type githubPull struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+	Head    struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+}
+
+func (f *ForgeClient) openGitHub(ctx context.Context, opts PullRequestOptions) (*PullRequest, error) {
+	base := fmt.Sprintf("%s/repos/%s/%s/pulls", f.apiURL, url.PathEscape(f.owner), url.PathEscape(f.repo))
+	body := map[string]string{"title": opts.Title, "body": opts.Body, "head": opts.Head, "base": opts.Base}
+
+	var created githubPull
+	status, err := f.do(ctx, http.MethodPost, base, body, &created)
+	if err != nil && status != http.StatusUnprocessableEntity {
+		return nil, err
+	}
+	if err == nil {
+		return &PullRequest{Number: created.Number, URL: created.HTMLURL}, nil
+	}
+
+	// 422 is also returned when a pull request for the branch already exists.
+	query := url.Values{"state": {"open"}, "head": {f.owner + ":" + opts.Head}, "base": {opts.Base}}
+	var existing []githubPull
+	if _, listErr := f.do(ctx, http.MethodGet, base+"?"+query.Encode(), nil, &existing); listErr != nil || len(existing) == 0 {
+		return nil, err
+	}
+	return &PullRequest{Number: existing[0].Number, URL: existing[0].HTMLURL}, nil
+}
+
+func (f *ForgeClient) openGitea(ctx context.Context, opts PullRequestOptions) (*PullRequest, error) {
+	base := fmt.Sprintf("%s/repos/%s/%s/pulls", f.apiURL, url.PathEscape(f.owner), url.PathEscape(f.repo))
+	body := map[string]string{"title": opts.Title, "body": opts.Body, "head": opts.Head, "base": opts.Base}
+
+	var created githubPull
+	status, err := f.do(ctx, http.MethodPost, base, body, &created)
+	if err != nil && status != http.StatusConflict {
+		return nil, err
+	}
+	if err == nil {
+		return &PullRequest{Number: created.Number, URL: created.HTMLURL}, nil
+	}
+
+	// 409 means a pull request for the branch is already open.
+	var existing []githubPull
+	if _, listErr := f.do(ctx, http.MethodGet, base+"?state=open", nil, &existing); listErr != nil {
+		return nil, err
+	}
+	for _, pr := range existing {
+		if pr.Head.Ref == opts.Head {
+			return &PullRequest{Number: pr.Number, URL: pr.HTMLURL}, nil
+		}
+	}
+	return nil, err
+}
+
+// gitlabMergeRequest is the part of a GitLab merge request Butler reads.
+type gitlabMergeRequest struct {
+	IID    int    `json:"iid"`
+	WebURL string `json:"web_url"`
+}
+
+func (f *ForgeClient) openGitLab(ctx context.Context, opts PullRequestOptions) (*PullRequest, error) {
+	base := fmt.Sprintf("%s/projects/%s/merge_requests", f.apiURL, url.PathEscape(f.owner+"/"+f.repo))
+	body := map[string]string{
+		"title":         opts.Title,
+		"description":   opts.Body,
+		"source_branch": opts.Head,
+		"target_branch": opts.Base,
+	}
+
+	var created gitlabMergeRequest
+	status, err := f.do(ctx, http.MethodPost, base, body, &created)
+	if err != nil && status != http.StatusConflict {
+		return nil, err
+	}
+	if err == nil {
+		return &PullRequest{Number: created.IID, URL: created.WebURL}, nil
+	}
+
+	// 409 means a merge request for the branch is already open.
+	query := url.Values{"state": {"opened"}, "source_branch": {opts.Head}, "target_branch": {opts.Base}}
+	var existing []gitlabMergeRequest
+	if _, listErr := f.do(ctx, http.MethodGet, base+"?"+query.Encode(), nil, &existing); listErr != nil || len(existing) == 0 {
+		return nil, err
+	}
+	return &PullRequest{Number: existing[0].IID, URL: existing[0].WebURL}, nil
+}
+
+// do sends a JSON request and decodes a successful response into out. It returns the HTTP status
+// so callers can recognise conflicts.
+func (f *ForgeClient) do(ctx context.Context, method, endpoint string, body, out interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	switch f.kind {
+	case ForgeGitHub:
+		req.Header.Set("Authorization", "Bearer "+f.token)
+	case ForgeGitLab:
+		req.Header.Set("PRIVATE-TOKEN", f.token)
+	case ForgeGitea:
+		req.Header.Set("Authorization", "token "+f.token)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%s %s failed: %w", method, endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("%s %s returned %s: %s", method, endpoint, resp.Status, strings.TrimSpace(string(data)))
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
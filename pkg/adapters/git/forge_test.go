@@ -0,0 +1,173 @@
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// fakeForge serves the pull request endpoints of one forge. When exists is set, creation fails
+// the way the forge reports a duplicate and the list endpoint returns the open pull request.
+type fakeForge struct {
+	t        *testing.T
+	path     string
+	auth     [2]string
+	conflict int
+	created  interface{}
+	listed   func(r *http.Request) interface{}
+	exists   bool
+	posts    int
+}
+
+func (f *fakeForge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.EscapedPath() != f.path {
+		f.t.Errorf("unexpected request path %s, want %s", r.URL.EscapedPath(), f.path)
+		http.NotFound(w, r)
+		return
+	}
+	if got := r.Header.Get(f.auth[0]); got != f.auth[1] {
+		f.t.Errorf("%s header = %q, want %q", f.auth[0], got, f.auth[1])
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		f.posts++
+		if f.exists {
+			http.Error(w, `{"message":"already exists"}`, f.conflict)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(f.created)
+	case http.MethodGet:
+		_ = json.NewEncoder(w).Encode(f.listed(r))
+	default:
+		f.t.Errorf("unexpected method %s", r.Method)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestOpenPullRequest(t *testing.T) {
+	opts := PullRequestOptions{Title: "Update", Body: "Changes", Head: "butler/update", Base: "main"}
+
+	forges := map[string]func(t *testing.T) *fakeForge{
+		ForgeGitHub: func(t *testing.T) *fakeForge {
+			return &fakeForge{
+				path:     "/repos/acme/fleet/pulls",
+				auth:     [2]string{"Authorization", "Bearer secret"},
+				conflict: http.StatusUnprocessableEntity,
+				created:  map[string]interface{}{"number": 7, "html_url": "https://forge/pull/7"},
+				listed: func(r *http.Request) interface{} {
+					q := r.URL.Query()
+					if q.Get("head") != "acme:butler/update" || q.Get("base") != "main" || q.Get("state") != "open" {
+						t.Errorf("unexpected list query %s", r.URL.RawQuery)
+						return []interface{}{}
+					}
+					return []interface{}{map[string]interface{}{"number": 3, "html_url": "https://forge/pull/3"}}
+				},
+			}
+		},
+		ForgeGitea: func(t *testing.T) *fakeForge {
+			return &fakeForge{
+				path:     "/repos/acme/fleet/pulls",
+				auth:     [2]string{"Authorization", "token secret"},
+				conflict: http.StatusConflict,
+				created:  map[string]interface{}{"number": 7, "html_url": "https://forge/pull/7"},
+				listed: func(r *http.Request) interface{} {
+					return []interface{}{
+						map[string]interface{}{"number": 2, "html_url": "https://forge/pull/2", "head": map[string]string{"ref": "other"}},
+						map[string]interface{}{"number": 3, "html_url": "https://forge/pull/3", "head": map[string]string{"ref": "butler/update"}},
+					}
+				},
+			}
+		},
+		ForgeGitLab: func(t *testing.T) *fakeForge {
+			return &fakeForge{
+				path:     "/projects/acme%2Ffleet/merge_requests",
+				auth:     [2]string{"PRIVATE-TOKEN", "secret"},
+				conflict: http.StatusConflict,
+				created:  map[string]interface{}{"iid": 7, "web_url": "https://forge/pull/7"},
+				listed: func(r *http.Request) interface{} {
+					q := r.URL.Query()
+					if q.Get("source_branch") != "butler/update" || q.Get("target_branch") != "main" || q.Get("state") != "opened" {
+						t.Errorf("unexpected list query %s", r.URL.RawQuery)
+						return []interface{}{}
+					}
+					return []interface{}{map[string]interface{}{"iid": 3, "web_url": "https://forge/pull/3"}}
+				},
+			}
+		},
+	}
+
+	for kind, newForge := range forges {
+		for _, exists := range []bool{false, true} {
+			name := kind + "/created"
+			want := PullRequest{Number: 7, URL: "https://forge/pull/7"}
+			if exists {
+				name = kind + "/exists"
+				want = PullRequest{Number: 3, URL: "https://forge/pull/3"}
+			}
+
+			t.Run(name, func(t *testing.T) {
+				forge := newForge(t)
+				forge.t = t
+				forge.exists = exists
+				server := httptest.NewServer(forge)
+				defer server.Close()
+
+				client, err := NewForgeClient(kind, server.URL+"/", "acme", "fleet", "secret", nil, zap.NewNop())
+				if err != nil {
+					t.Fatalf("NewForgeClient: %v", err)
+				}
+				pr, err := client.OpenPullRequest(context.Background(), opts)
+				if err != nil {
+					t.Fatalf("OpenPullRequest: %v", err)
+				}
+				if *pr != want {
+					t.Errorf("OpenPullRequest = %+v, want %+v", *pr, want)
+				}
+				if forge.posts != 1 {
+					t.Errorf("create requests = %d, want 1", forge.posts)
+				}
+			})
+		}
+	}
+}
+
+func TestOpenPullRequestConflictWithoutMatch(t *testing.T) {
+	server := httptest.NewServer(&fakeForge{
+		path:     "/repos/acme/fleet/pulls",
+		auth:     [2]string{"Authorization", "token secret"},
+		conflict: http.StatusConflict,
+		exists:   true,
+		listed: func(r *http.Request) interface{} {
+			return []interface{}{map[string]interface{}{"number": 2, "head": map[string]string{"ref": "other"}}}
+		},
+	})
+	defer server.Close()
+
+	client, err := NewForgeClient(ForgeGitea, server.URL, "acme", "fleet", "secret", nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewForgeClient: %v", err)
+	}
+	opts := PullRequestOptions{Title: "Update", Head: "butler/update", Base: "main"}
+	if _, err := client.OpenPullRequest(context.Background(), opts); err == nil {
+		t.Fatal("OpenPullRequest succeeded, want the conflict error")
+	}
+}
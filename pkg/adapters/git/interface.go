@@ -20,5 +20,13 @@ import "context"
 // GitAdapter defines methods for interacting with Git repositories
 type GitAdapter interface {
 	CloneRepo(ctx context.Context, localPath string) error
+	CurrentBranch(ctx context.Context, localPath string) (string, error)
+	CreateBranch(ctx context.Context, localPath, branch string) error
+	HasChanges(ctx context.Context, localPath string) (bool, error)
 	CommitAndPush(ctx context.Context, localPath, commitMessage string) error
 }
+
+// Forge opens pull (or merge) requests on a Git hosting service.
+type Forge interface {
+	OpenPullRequest(ctx context.Context, opts PullRequestOptions) (*PullRequest, error)
+}
@@ -0,0 +1,245 @@
+// Package git defines an adapter for git commands natively within Butler.
+//
+// # Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"go.uber.org/zap"
+)
+
+// ErrRebaseConflict is returned when a local commit and the remote branch changed the same file.
+var ErrRebaseConflict = errors.New("rebase conflict")
+
+// rebase fetches the remote branch and replays the local commits it does not contain on top of it,
+// like 'git pull --rebase'. Commits touching files that also changed remotely are not merged;
+// the rebase fails with ErrRebaseConflict instead.
+func (g *GitClient) rebase(ctx context.Context, repo *git.Repository, branch string) error {
+	auth, err := g.auth()
+	if err != nil {
+		return err
+	}
+
+	remoteRef := plumbing.NewRemoteReferenceName("origin", branch)
+	err = repo.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{config.RefSpec("+" + plumbing.NewBranchReferenceName(branch) + ":" + remoteRef)},
+		Auth:       auth,
+		CABundle:   g.opts.CABundle,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("failed to fetch branch %s: %w", branch, err)
+	}
+
+	upstreamRef, err := repo.Reference(remoteRef, true)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", remoteRef, err)
+	}
+	upstream, err := repo.CommitObject(upstreamRef.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to read commit %s: %w", upstreamRef.Hash(), err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	local, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to read commit %s: %w", head.Hash(), err)
+	}
+
+	commits, err := commitsSince(local, upstream)
+	if err != nil {
+		return err
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+	if err := w.Reset(&git.ResetOptions{Commit: upstream.Hash, Mode: git.HardReset}); err != nil {
+		return fmt.Errorf("failed to reset to %s: %w", remoteRef, err)
+	}
+
+	tip := upstream
+	for _, commit := range commits {
+		hash, err := g.replay(repo, w, tip, commit)
+		if err != nil {
+			// Put the branch back where it was, so nothing is lost.
+			if resetErr := w.Reset(&git.ResetOptions{Commit: local.Hash, Mode: git.HardReset}); resetErr != nil {
+				g.logger.Error("Failed to restore branch after rebase failure", zap.Error(resetErr))
+			}
+			return err
+		}
+		if tip, err = repo.CommitObject(hash); err != nil {
+			return fmt.Errorf("failed to read commit %s: %w", hash, err)
+		}
+	}
+
+	g.logger.Info("Rebased local commits onto remote branch",
+		zap.String("branch", branch),
+		zap.Int("commits", len(commits)),
+		zap.String("onto", upstream.Hash.String()),
+	)
+	return nil
+}
+
+// commitsSince returns the commits reachable from local but not from upstream, oldest first.
+func commitsSince(local, upstream *object.Commit) ([]*object.Commit, error) {
+	bases, err := local.MergeBase(upstream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base: %w", err)
+	}
+	if len(bases) == 0 {
+		return nil, fmt.Errorf("local and remote branches have no common history")
+	}
+	base := bases[0].Hash
+
+	var commits []*object.Commit
+	for c := local; c.Hash != base; {
+		if c.NumParents() != 1 {
+			return nil, fmt.Errorf("cannot rebase merge or root commit %s", c.Hash)
+		}
+		commits = append([]*object.Commit{c}, commits...)
+		parent, err := c.Parent(0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read parent of %s: %w", c.Hash, err)
+		}
+		c = parent
+	}
+	return commits, nil
+}
+
+// replay applies the changes of commit on top of tip and commits them with the original message
+// and author.
+func (g *GitClient) replay(repo *git.Repository, w *git.Worktree, tip, commit *object.Commit) (plumbing.Hash, error) {
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to read parent of %s: %w", commit.Hash, err)
+	}
+	parentTree, err := parent.Tree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	commitTree, err := commit.Tree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	tipTree, err := tip.Tree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	changes, err := object.DiffTree(parentTree, commitTree)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to diff commit %s: %w", commit.Hash, err)
+	}
+
+	for _, change := range changes {
+		for _, path := range changedPaths(change) {
+			before := entryHash(parentTree, path)
+			after := entryHash(commitTree, path)
+			current := entryHash(tipTree, path)
+			if current != before && current != after {
+				return plumbing.ZeroHash, fmt.Errorf("%w: %s was changed both locally and on the remote", ErrRebaseConflict, path)
+			}
+
+			if err := writeEntry(w, commitTree, path); err != nil {
+				return plumbing.ZeroHash, err
+			}
+		}
+	}
+
+	if err := w.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to stage rebased changes: %w", err)
+	}
+	opts := g.commitOptions(&commit.Author)
+	opts.AllowEmptyCommits = true
+	hash, err := w.Commit(commit.Message, opts)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to commit rebased changes: %w", err)
+	}
+	return hash, nil
+}
+
+// changedPaths returns the paths a tree change touches; a rename touches two.
+func changedPaths(change *object.Change) []string {
+	if change.From.Name != "" && change.To.Name != "" && change.From.Name != change.To.Name {
+		return []string{change.From.Name, change.To.Name}
+	}
+	if change.To.Name != "" {
+		return []string{change.To.Name}
+	}
+	return []string{change.From.Name}
+}
+
+// entryHash returns the blob hash of path in tree, or the zero hash when it does not exist.
+func entryHash(tree *object.Tree, path string) plumbing.Hash {
+	entry, err := tree.FindEntry(path)
+	if err != nil {
+		return plumbing.ZeroHash
+	}
+	return entry.Hash
+}
+
+// writeEntry makes path in the worktree match its state in tree, deleting it when it is absent.
+func writeEntry(w *git.Worktree, tree *object.Tree, path string) error {
+	target := filepath.Join(w.Filesystem.Root(), filepath.FromSlash(path))
+
+	file, err := tree.File(path)
+	if errors.Is(err, object.ErrFileNotFound) {
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	reader, err := file.Reader()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer reader.Close()
+
+	perm := os.FileMode(0644)
+	if file.Mode == filemode.Executable {
+		perm = 0755
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if _, err := io.Copy(out, reader); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return out.Close()
+}
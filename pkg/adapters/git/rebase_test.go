@@ -0,0 +1,192 @@
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"go.uber.org/zap"
+)
+
+// newRemote creates a bare repository holding one commit on master and returns its path.
+func newRemote(t *testing.T) string {
+	t.Helper()
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	if _, err := git.PlainInit(remote, true); err != nil {
+		t.Fatalf("init bare repository: %v", err)
+	}
+	seed := t.TempDir()
+	repo, err := git.PlainInit(seed, false)
+	if err != nil {
+		t.Fatalf("init seed: %v", err)
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remote}}); err != nil {
+		t.Fatalf("add origin: %v", err)
+	}
+	commitFile(t, seed, "README.md", "seed\n")
+	pushRemote(t, seed)
+	return remote
+}
+
+// cloneRemote clones remote into a temporary directory with go-git directly.
+func cloneRemote(t *testing.T, remote string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if _, err := git.PlainClone(dir, false, &git.CloneOptions{URL: remote}); err != nil {
+		t.Fatalf("clone remote: %v", err)
+	}
+	return dir
+}
+
+// commitFile writes content to name in dir and commits it.
+func commitFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("open %s: %v", dir, err)
+	}
+	w, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("worktree: %v", err)
+	}
+	if _, err := w.Add(name); err != nil {
+		t.Fatalf("add %s: %v", name, err)
+	}
+	sig := &object.Signature{Name: "Other", Email: "other@example.com", When: time.Now()}
+	if _, err := w.Commit("update "+name, &git.CommitOptions{Author: sig}); err != nil {
+		t.Fatalf("commit %s: %v", name, err)
+	}
+}
+
+// pushRemote pushes master from dir to origin.
+func pushRemote(t *testing.T, dir string) {
+	t.Helper()
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("open %s: %v", dir, err)
+	}
+	if err := repo.Push(&git.PushOptions{RemoteName: "origin"}); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+}
+
+// remoteFile returns the content of name at the tip of master in remote.
+func remoteFile(t *testing.T, remote, name string) (string, bool) {
+	t.Helper()
+	repo, err := git.PlainOpen(remote)
+	if err != nil {
+		t.Fatalf("open remote: %v", err)
+	}
+	ref, err := repo.Reference("refs/heads/master", true)
+	if err != nil {
+		t.Fatalf("resolve master: %v", err)
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatalf("read commit: %v", err)
+	}
+	file, err := commit.File(name)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return "", false
+	}
+	if err != nil {
+		t.Fatalf("read %s: %v", name, err)
+	}
+	content, err := file.Contents()
+	if err != nil {
+		t.Fatalf("read %s: %v", name, err)
+	}
+	return content, true
+}
+
+// setupClient clones remote with a GitClient and returns the client and its worktree.
+func setupClient(t *testing.T, remote string) (*GitClient, string) {
+	t.Helper()
+	client, err := NewGitClient(Options{RepoURL: remote, Branch: "master"}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewGitClient: %v", err)
+	}
+	local := filepath.Join(t.TempDir(), "repo")
+	if err := client.CloneRepo(context.Background(), local); err != nil {
+		t.Fatalf("CloneRepo: %v", err)
+	}
+	return client, local
+}
+
+func TestCommitAndPushRebasesOntoMovedRemote(t *testing.T) {
+	remote := newRemote(t)
+	client, local := setupClient(t, remote)
+
+	// Another writer pushes while the local change is being made.
+	other := cloneRemote(t, remote)
+	commitFile(t, other, "other.yaml", "other\n")
+	pushRemote(t, other)
+
+	if err := os.WriteFile(filepath.Join(local, "local.yaml"), []byte("local\n"), 0o644); err != nil {
+		t.Fatalf("write local.yaml: %v", err)
+	}
+	if err := client.CommitAndPush(context.Background(), local, "add local.yaml"); err != nil {
+		t.Fatalf("CommitAndPush: %v", err)
+	}
+
+	for name, want := range map[string]string{"other.yaml": "other\n", "local.yaml": "local\n"} {
+		got, ok := remoteFile(t, remote, name)
+		if !ok {
+			t.Fatalf("%s missing on remote after rebase", name)
+		}
+		if got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestCommitAndPushRebaseConflict(t *testing.T) {
+	remote := newRemote(t)
+	client, local := setupClient(t, remote)
+
+	other := cloneRemote(t, remote)
+	commitFile(t, other, "README.md", "theirs\n")
+	pushRemote(t, other)
+
+	if err := os.WriteFile(filepath.Join(local, "README.md"), []byte("ours\n"), 0o644); err != nil {
+		t.Fatalf("write README.md: %v", err)
+	}
+	err := client.CommitAndPush(context.Background(), local, "edit README.md")
+	if !errors.Is(err, ErrRebaseConflict) {
+		t.Fatalf("CommitAndPush error = %v, want ErrRebaseConflict", err)
+	}
+
+	if got, _ := remoteFile(t, remote, "README.md"); got != "theirs\n" {
+		t.Errorf("remote README.md = %q, want the other writer's change", got)
+	}
+	content, err := os.ReadFile(filepath.Join(local, "README.md"))
+	if err != nil {
+		t.Fatalf("read local README.md: %v", err)
+	}
+	if string(content) != "ours\n" {
+		t.Errorf("local README.md = %q, want the local change restored", content)
+	}
+}
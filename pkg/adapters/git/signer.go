@@ -0,0 +1,73 @@
+// Package git defines an adapter for git commands natively within Butler.
+//
+// # Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	git "github.com/go-git/go-git/v5"
+)
+
+// Commit signing formats.
+const (
+	SigningGPG = "gpg"
+	SigningSSH = "ssh"
+)
+
+// commandSigner signs commits with an external program, as git does, so keys can stay in a
+// gpg-agent, ssh-agent or hardware token.
+type commandSigner struct {
+	name string
+	args []string
+}
+
+// Sign feeds the encoded commit to the program and returns the armored signature it prints.
+func (s *commandSigner) Sign(message io.Reader) ([]byte, error) {
+	cmd := exec.Command(s.name, s.args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = message
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to sign commit with %s: %w: %s", s.name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// newSigner returns the signer for a signing format, or nil when commits are not signed.
+func newSigner(format, key string) (git.Signer, error) {
+	switch format {
+	case "":
+		return nil, nil
+	case SigningGPG:
+		args := []string{"--detach-sign", "--armor"}
+		if key != "" {
+			args = append(args, "--local-user", key)
+		}
+		return &commandSigner{name: "gpg", args: args}, nil
+	case SigningSSH:
+		if key == "" {
+			return nil, fmt.Errorf("SSH commit signing requires a signing key file")
+		}
+		return &commandSigner{name: "ssh-keygen", args: []string{"-Y", "sign", "-n", "git", "-f", key}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing format %q (must be %s or %s)", format, SigningGPG, SigningSSH)
+	}
+}
@@ -27,11 +27,12 @@ import (
 // HelmAdapter provides a high-level interface for interacting with Helm CLI.
 type HelmAdapter struct {
 	client *HelmClient
+	logger *zap.Logger
 }
 
 // NewHelmAdapter initializes a new HelmAdapter.
 func NewHelmAdapter(execAdapter exec.ExecAdapter, logger *zap.Logger) *HelmAdapter {
-	return &HelmAdapter{client: NewHelmClient(execAdapter, logger)}
+	return &HelmAdapter{client: NewHelmClient(execAdapter, logger), logger: logger}
 }
 
 // ExecuteCommand runs a generic helm command with provided arguments.
@@ -19,6 +19,7 @@ package helm
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/butlerdotdev/butler/pkg/adapters/exec"
 
@@ -40,6 +41,9 @@ func NewHelmClient(execAdapter exec.ExecAdapter, logger *zap.Logger) *HelmClient
 func (c *HelmClient) ExecuteCommand(ctx context.Context, args ...string) (string, error) {
 	result, err := c.execAdapter.RunCommand(ctx, "helm", args...)
 	if err != nil {
+		if stderr := strings.TrimSpace(result.Stderr); stderr != "" {
+			return "", fmt.Errorf("helm command failed: %w: %s", err, stderr)
+		}
 		return "", fmt.Errorf("helm command failed: %w", err)
 	}
 	return result.Stdout, nil
@@ -0,0 +1,267 @@
+// Package helm defines an adapter for executing helm commands.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// You may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+)
+
+// ErrReleaseNotFound is returned by Status when the release is not installed.
+var ErrReleaseNotFound = errors.New("helm release not found")
+
+// defaultTimeout bounds helm operations that wait for resources when no timeout is given.
+const defaultTimeout = 5 * time.Minute
+
+// Chart identifies a chart and where to pull it from, without a local 'helm repo add'.
+type Chart struct {
+	// Name is the chart name, or a local path or repo/chart reference when Repo is empty.
+	Name string
+	// Repo is an HTTP(S) chart repository URL or an OCI registry path such as "oci://ghcr.io/org/charts".
+	Repo string
+	// Version pins the chart version. Empty selects the latest.
+	Version string
+}
+
+// ReleaseOptions control a release operation.
+type ReleaseOptions struct {
+	Namespace       string
+	Kubeconfig      string
+	CreateNamespace bool
+	// Values are passed as values files in order, so later maps override earlier ones.
+	Values []map[string]interface{}
+	// Wait waits until the release's resources are ready; Atomic also rolls back a failed upgrade.
+	Wait    bool
+	Atomic  bool
+	Timeout time.Duration
+}
+
+// Release is a release as reported by helm.
+type Release struct {
+	Name         string
+	Namespace    string
+	Revision     int
+	Status       string
+	Description  string
+	Chart        string
+	ChartVersion string
+	AppVersion   string
+}
+
+// releaseJSON is the release document helm prints with -o json.
+type releaseJSON struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   int    `json:"version"`
+	Info      struct {
+		Status      string `json:"status"`
+		Description string `json:"description"`
+	} `json:"info"`
+	Chart struct {
+		Metadata struct {
+			Name       string `json:"name"`
+			Version    string `json:"version"`
+			AppVersion string `json:"appVersion"`
+		} `json:"metadata"`
+	} `json:"chart"`
+}
+
+// UpgradeInstall installs the release, or upgrades it if it already exists, and returns the
+// resulting release. It is safe to run repeatedly.
+func (a *HelmAdapter) UpgradeInstall(ctx context.Context, name string, chart Chart, opts ReleaseOptions) (*Release, error) {
+	a.logger.Info("Installing or upgrading Helm release",
+		zap.String("release", name),
+		zap.String("chart", chart.Name),
+		zap.String("version", chart.Version),
+		zap.String("namespace", opts.Namespace),
+	)
+
+	ref := chart.Name
+	args := []string{"upgrade", name}
+	if strings.HasPrefix(chart.Repo, "oci://") {
+		ref = strings.TrimSuffix(chart.Repo, "/") + "/" + chart.Name
+		args = append(args, ref)
+	} else {
+		args = append(args, ref)
+		if chart.Repo != "" {
+			args = append(args, "--repo", chart.Repo)
+		}
+	}
+	args = append(args, "--install", "--output", "json")
+	if chart.Version != "" {
+		args = append(args, "--version", chart.Version)
+	}
+	if opts.CreateNamespace {
+		args = append(args, "--create-namespace")
+	}
+	if opts.Atomic {
+		args = append(args, "--atomic")
+	}
+
+	valuesFiles, err := writeValuesFiles(opts.Values)
+	defer removeFiles(valuesFiles)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range valuesFiles {
+		args = append(args, "--values", file)
+	}
+
+	ctx, cancel := withTimeout(ctx, opts)
+	defer cancel()
+
+	out, err := a.ExecuteCommand(ctx, append(args, opts.flags(true)...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to install or upgrade release %s: %w", name, err)
+	}
+	return parseRelease(out)
+}
+
+// Status returns the current state of a release, or ErrReleaseNotFound.
+func (a *HelmAdapter) Status(ctx context.Context, name string, opts ReleaseOptions) (*Release, error) {
+	args := append([]string{"status", name, "--output", "json"}, opts.flags(false)...)
+	out, err := a.ExecuteCommand(ctx, args...)
+	if err != nil {
+		if strings.Contains(err.Error(), "release: not found") {
+			return nil, fmt.Errorf("%w: %s", ErrReleaseNotFound, name)
+		}
+		return nil, fmt.Errorf("failed to get status of release %s: %w", name, err)
+	}
+	return parseRelease(out)
+}
+
+// Rollback rolls a release back to a revision; revision 0 selects the previous one.
+func (a *HelmAdapter) Rollback(ctx context.Context, name string, revision int, opts ReleaseOptions) error {
+	a.logger.Info("Rolling back Helm release", zap.String("release", name), zap.Int("revision", revision))
+
+	args := []string{"rollback", name}
+	if revision > 0 {
+		args = append(args, strconv.Itoa(revision))
+	}
+
+	ctx, cancel := withTimeout(ctx, opts)
+	defer cancel()
+
+	if _, err := a.ExecuteCommand(ctx, append(args, opts.flags(true)...)...); err != nil {
+		return fmt.Errorf("failed to roll back release %s: %w", name, err)
+	}
+	return nil
+}
+
+// Uninstall removes a release. A release that is not installed is not an error.
+func (a *HelmAdapter) Uninstall(ctx context.Context, name string, opts ReleaseOptions) error {
+	a.logger.Info("Uninstalling Helm release", zap.String("release", name), zap.String("namespace", opts.Namespace))
+
+	ctx, cancel := withTimeout(ctx, opts)
+	defer cancel()
+
+	args := append([]string{"uninstall", name, "--ignore-not-found"}, opts.flags(true)...)
+	if _, err := a.ExecuteCommand(ctx, args...); err != nil {
+		return fmt.Errorf("failed to uninstall release %s: %w", name, err)
+	}
+	return nil
+}
+
+// flags returns the namespace, kubeconfig and, for operations that change the release, the wait flags.
+func (o ReleaseOptions) flags(mutating bool) []string {
+	var args []string
+	if o.Namespace != "" {
+		args = append(args, "--namespace", o.Namespace)
+	}
+	if o.Kubeconfig != "" {
+		args = append(args, "--kubeconfig", o.Kubeconfig)
+	}
+	if mutating && (o.Wait || o.Atomic) {
+		args = append(args, "--wait", "--timeout", o.timeout().String())
+	}
+	return args
+}
+
+// timeout returns the configured timeout or the default.
+func (o ReleaseOptions) timeout() time.Duration {
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+	return defaultTimeout
+}
+
+// withTimeout gives the helm process a deadline past helm's own --timeout, so helm reports the
+// timeout itself instead of being killed.
+func withTimeout(ctx context.Context, opts ReleaseOptions) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, opts.timeout()+time.Minute)
+}
+
+// parseRelease parses the JSON helm prints for a release.
+func parseRelease(out string) (*Release, error) {
+	var doc releaseJSON
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse helm release output: %w", err)
+	}
+	return &Release{
+		Name:         doc.Name,
+		Namespace:    doc.Namespace,
+		Revision:     doc.Version,
+		Status:       doc.Info.Status,
+		Description:  doc.Info.Description,
+		Chart:        doc.Chart.Metadata.Name,
+		ChartVersion: doc.Chart.Metadata.Version,
+		AppVersion:   doc.Chart.Metadata.AppVersion,
+	}, nil
+}
+
+// writeValuesFiles writes each values map to a private temporary file.
+func writeValuesFiles(values []map[string]interface{}) ([]string, error) {
+	var files []string
+	for _, v := range values {
+		if len(v) == 0 {
+			continue
+		}
+		out, err := yaml.Marshal(v)
+		if err != nil {
+			return files, fmt.Errorf("failed to marshal helm values: %w", err)
+		}
+		file, err := os.CreateTemp("", "helm-values-*.yaml")
+		if err != nil {
+			return files, fmt.Errorf("failed to create values file: %w", err)
+		}
+		files = append(files, file.Name())
+		if _, err := file.Write(out); err != nil {
+			file.Close()
+			return files, fmt.Errorf("failed to write values file: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			return files, fmt.Errorf("failed to close values file: %w", err)
+		}
+	}
+	return files, nil
+}
+
+// removeFiles deletes temporary files.
+func removeFiles(files []string) {
+	for _, file := range files {
+		os.Remove(file)
+	}
+}
@@ -0,0 +1,427 @@
+// Package kubernetes defines a typed adapter for the Kubernetes API built on client-go.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// FieldManager is the server-side apply field manager Butler owns its fields under.
+const FieldManager = "butler"
+
+// KubernetesAdapter provides typed access to a cluster. Unlike the exec-based platform adapters it
+// talks to the API directly, so it is constructed here rather than through GetPlatformAdapter.
+//
+// The kubeconfig is only read on first use, which lets services create the adapter before bootstrap
+// has retrieved the kubeconfig from Talos.
+type KubernetesAdapter struct {
+	kubeconfig string
+	server     string
+	logger     *zap.Logger
+
+	mu     sync.Mutex
+	client *KubernetesClient
+}
+
+// NewKubernetesAdapter initializes a new KubernetesAdapter for the cluster in the given kubeconfig.
+func NewKubernetesAdapter(kubeconfig string, logger *zap.Logger) *KubernetesAdapter {
+	return &KubernetesAdapter{kubeconfig: kubeconfig, logger: logger}
+}
+
+// Kubeconfig returns the path of the kubeconfig the adapter was created with, for tools such as
+// helm and flux that read it themselves.
+func (a *KubernetesAdapter) Kubeconfig() string {
+	return a.kubeconfig
+}
+
+// WithServer returns an adapter for the same cluster that talks to a specific API server,
+// e.g. "https://10.0.0.10:6443". An empty server uses the kubeconfig's endpoint.
+func (a *KubernetesAdapter) WithServer(server string) *KubernetesAdapter {
+	if server == a.server {
+		return a
+	}
+	return &KubernetesAdapter{kubeconfig: a.kubeconfig, server: server, logger: a.logger}
+}
+
+// getClient lazily builds the underlying client.
+func (a *KubernetesAdapter) getClient() (*KubernetesClient, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.client == nil {
+		client, err := NewKubernetesClient(a.kubeconfig, a.server, a.logger)
+		if err != nil {
+			return nil, err
+		}
+		a.client = client
+	}
+	return a.client, nil
+}
+
+// Ready reports whether the API server answers its readiness endpoint.
+func (a *KubernetesAdapter) Ready(ctx context.Context) error {
+	client, err := a.getClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.clientset.Discovery().RESTClient().Get().AbsPath("/readyz").DoRaw(ctx); err != nil {
+		return fmt.Errorf("kubernetes API is not ready: %w", err)
+	}
+	return nil
+}
+
+// ListNodes returns all nodes in the cluster.
+func (a *KubernetesAdapter) ListNodes(ctx context.Context) ([]corev1.Node, error) {
+	client, err := a.getClient()
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := client.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	return nodes.Items, nil
+}
+
+// InternalIPToNodeName maps every node's InternalIP address to its name.
+func (a *KubernetesAdapter) InternalIPToNodeName(ctx context.Context) (map[string]string, error) {
+	nodes, err := a.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ipToNode := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		if ip := NodeInternalIP(node); ip != "" {
+			ipToNode[ip] = node.Name
+		}
+	}
+	return ipToNode, nil
+}
+
+// LabelNode sets labels on a node, replacing existing values. An empty value is a valid label value.
+func (a *KubernetesAdapter) LabelNode(ctx context.Context, name string, labels map[string]string) error {
+	a.logger.Info("Labeling node", zap.String("node", name), zap.Any("labels", labels))
+	return a.patchNode(ctx, name, map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": labels},
+	})
+}
+
+// SetUnschedulable cordons or uncordons a node.
+func (a *KubernetesAdapter) SetUnschedulable(ctx context.Context, name string, unschedulable bool) error {
+	return a.patchNode(ctx, name, map[string]interface{}{
+		"spec": map[string]interface{}{"unschedulable": unschedulable},
+	})
+}
+
+// patchNode applies a strategic merge patch to a node.
+func (a *KubernetesAdapter) patchNode(ctx context.Context, name string, patch map[string]interface{}) error {
+	client, err := a.getClient()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal node patch: %w", err)
+	}
+	if _, err := client.clientset.CoreV1().Nodes().Patch(ctx, name, types.StrategicMergePatchType, data, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to patch node %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteNode removes a node object. A node that does not exist is not an error.
+func (a *KubernetesAdapter) DeleteNode(ctx context.Context, name string) error {
+	client, err := a.getClient()
+	if err != nil {
+		return err
+	}
+	if err := client.clientset.CoreV1().Nodes().Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete node %s: %w", name, err)
+	}
+	return nil
+}
+
+// Get returns a single object of any kind. Cluster-scoped kinds ignore the namespace.
+func (a *KubernetesAdapter) Get(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	client, err := a.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+	mapping, err := client.mapping(gvk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map %s: %w", kind, err)
+	}
+
+	resource := client.dynamic.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return resource.Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	return resource.Get(ctx, name, metav1.GetOptions{})
+}
+
+// List returns the objects of any kind matching a label selector. An empty namespace lists
+// across all namespaces, or cluster-scoped objects.
+func (a *KubernetesAdapter) List(ctx context.Context, apiVersion, kind, namespace, labelSelector string) ([]unstructured.Unstructured, error) {
+	client, err := a.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+	mapping, err := client.mapping(gvk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map %s: %w", kind, err)
+	}
+
+	opts := metav1.ListOptions{LabelSelector: labelSelector}
+	resource := client.dynamic.Resource(mapping.Resource)
+	var list *unstructured.UnstructuredList
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace && namespace != "" {
+		list, err = resource.Namespace(namespace).List(ctx, opts)
+	} else {
+		list, err = resource.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", kind, err)
+	}
+	return list.Items, nil
+}
+
+// Delete removes a single object of any kind. An object that does not exist is not an error.
+func (a *KubernetesAdapter) Delete(ctx context.Context, apiVersion, kind, namespace, name string) error {
+	client, err := a.getClient()
+	if err != nil {
+		return err
+	}
+
+	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+	mapping, err := client.mapping(gvk)
+	if err != nil {
+		return fmt.Errorf("failed to map %s: %w", kind, err)
+	}
+
+	resource := client.dynamic.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		err = resource.Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	} else {
+		err = resource.Delete(ctx, name, metav1.DeleteOptions{})
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %s %s: %w", kind, name, err)
+	}
+	return nil
+}
+
+// Request sends a raw request to an API path, e.g. a subresource that the dynamic client does not
+// cover, and returns the response body.
+func (a *KubernetesAdapter) Request(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	client, err := a.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	req := client.clientset.Discovery().RESTClient().Verb(method).AbsPath(path)
+	if body != nil {
+		req = req.SetHeader("Content-Type", "application/json").Body(body)
+	}
+	out, err := req.DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	return out, nil
+}
+
+// ListEvents returns the events recorded for an object, oldest first.
+func (a *KubernetesAdapter) ListEvents(ctx context.Context, namespace, kind, name string) ([]corev1.Event, error) {
+	client, err := a.getClient()
+	if err != nil {
+		return nil, err
+	}
+	selector := fields.Set{"involvedObject.kind": kind, "involvedObject.name": name}.AsSelector().String()
+	events, err := client.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events for %s %s: %w", kind, name, err)
+	}
+	sort.Slice(events.Items, func(i, j int) bool {
+		return events.Items[i].LastTimestamp.Before(&events.Items[j].LastTimestamp)
+	})
+	return events.Items, nil
+}
+
+// ListPods returns the pods in a namespace matching a label selector.
+func (a *KubernetesAdapter) ListPods(ctx context.Context, namespace, labelSelector string) ([]corev1.Pod, error) {
+	client, err := a.getClient()
+	if err != nil {
+		return nil, err
+	}
+	pods, err := client.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in %s: %w", namespace, err)
+	}
+	return pods.Items, nil
+}
+
+// Apply server-side applies every object in a multi-document YAML or JSON manifest, in order.
+// Namespaced objects without a namespace go to "default".
+func (a *KubernetesAdapter) Apply(ctx context.Context, manifest []byte) error {
+	client, err := a.getClient()
+	if err != nil {
+		return err
+	}
+
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode manifest: %w", err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if err := a.applyObject(ctx, client, obj); err != nil {
+			return err
+		}
+	}
+}
+
+// applyObject server-side applies a single object.
+func (a *KubernetesAdapter) applyObject(ctx context.Context, client *KubernetesClient, obj *unstructured.Unstructured) error {
+	gvk := obj.GroupVersionKind()
+
+	mapping, err := client.mapping(gvk)
+	if err != nil {
+		return fmt.Errorf("failed to map %s %s: %w", gvk.Kind, obj.GetName(), err)
+	}
+
+	resource := client.dynamic.Resource(mapping.Resource)
+	a.logger.Debug("Applying object", zap.String("kind", gvk.Kind), zap.String("name", obj.GetName()), zap.String("namespace", obj.GetNamespace()))
+
+	opts := metav1.ApplyOptions{FieldManager: FieldManager, Force: true}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace := obj.GetNamespace()
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		_, err = resource.Namespace(namespace).Apply(ctx, obj.GetName(), obj, opts)
+	} else {
+		_, err = resource.Apply(ctx, obj.GetName(), obj, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply %s %s: %w", gvk.Kind, obj.GetName(), err)
+	}
+	return nil
+}
+
+// ApplyFile server-side applies the manifest in a local file.
+func (a *KubernetesAdapter) ApplyFile(ctx context.Context, path string) error {
+	manifest, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read manifest %s: %w", path, err)
+	}
+	return a.Apply(ctx, manifest)
+}
+
+// ApplyURL downloads a manifest over HTTP(S) and server-side applies it.
+func (a *KubernetesAdapter) ApplyURL(ctx context.Context, manifestURL string) error {
+	manifest, err := DownloadManifest(ctx, manifestURL)
+	if err != nil {
+		return err
+	}
+	return a.Apply(ctx, manifest)
+}
+
+// DownloadManifest downloads a manifest over HTTP(S).
+func DownloadManifest(ctx context.Context, manifestURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %s: %w", manifestURL, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download manifest %s: %w", manifestURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to download manifest %s: HTTP %d", manifestURL, resp.StatusCode)
+	}
+	manifest, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", manifestURL, err)
+	}
+	return manifest, nil
+}
+
+// RenderManifest marshals objects into a multi-document YAML manifest.
+func RenderManifest(objects []map[string]interface{}) ([]byte, error) {
+	var manifest bytes.Buffer
+	for _, obj := range objects {
+		out, err := sigsyaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", obj["kind"], err)
+		}
+		manifest.WriteString("---\n")
+		manifest.Write(out)
+	}
+	return manifest.Bytes(), nil
+}
+
+// NodeInternalIP returns a node's InternalIP address, or an empty string.
+func NodeInternalIP(node corev1.Node) string {
+	for _, address := range node.Status.Addresses {
+		if address.Type == corev1.NodeInternalIP {
+			return address.Address
+		}
+	}
+	return ""
+}
+
+// NodeReady reports whether a node's Ready condition is True.
+func NodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+// Package kubernetes defines a typed adapter for the Kubernetes API built on client-go.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"fmt"
+	"net/url"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// KubernetesClient holds the typed and dynamic clients for a single API server.
+type KubernetesClient struct {
+	clientset kubernetes.Interface
+	dynamic   dynamic.Interface
+	config    *rest.Config
+	mapper    *restmapper.DeferredDiscoveryRESTMapper
+	logger    *zap.Logger
+}
+
+// NewKubernetesClient builds clients from a kubeconfig file. When server is set it replaces the
+// kubeconfig's API endpoint; the serving certificate is still verified against the kubeconfig's CA
+// and the original endpoint's host name, so a node IP can be used before the VIP is up.
+func NewKubernetesClient(kubeconfigPath, server string, logger *zap.Logger) (*KubernetesClient, error) {
+	config, err := restConfig(kubeconfigPath, server)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic Kubernetes client: %w", err)
+	}
+
+	return &KubernetesClient{
+		clientset: clientset,
+		dynamic:   dynamicClient,
+		config:    config,
+		mapper:    restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(clientset.Discovery())),
+		logger:    logger,
+	}, nil
+}
+
+// restConfig loads the kubeconfig and applies the optional server override.
+func restConfig(kubeconfigPath, server string) (*rest.Config, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig %s: %w", kubeconfigPath, err)
+	}
+
+	if server != "" && server != config.Host {
+		if config.TLSClientConfig.ServerName == "" {
+			original, err := url.Parse(config.Host)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse API server %s from kubeconfig: %w", config.Host, err)
+			}
+			config.TLSClientConfig.ServerName = original.Hostname()
+		}
+		config.Host = server
+	}
+
+	config.UserAgent = "butler"
+	return config, nil
+}
+
+// mapping resolves the REST mapping for a kind. An unknown kind may come from a CRD installed
+// after discovery was cached, so the cache is reset and the lookup retried once.
+func (c *KubernetesClient) mapping(gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		c.mapper.Reset()
+		mapping, err = c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	return mapping, err
+}
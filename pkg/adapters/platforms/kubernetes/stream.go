@@ -0,0 +1,99 @@
+// Package kubernetes defines a typed adapter for the Kubernetes API built on client-go.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	gwebsocket "github.com/gorilla/websocket"
+	"k8s.io/client-go/transport/websocket"
+)
+
+// Stream opens a WebSocket to an API path with the given subprotocol, such as a KubeVirt serial
+// console, and returns it as a byte stream. The caller must close it.
+func (a *KubernetesAdapter) Stream(ctx context.Context, path, protocol string) (io.ReadWriteCloser, error) {
+	client, err := a.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := url.Parse(client.config.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse API server %s: %w", client.config.Host, err)
+	}
+	endpoint.Path = path
+
+	rt, holder, err := websocket.RoundTripperFor(client.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure WebSocket transport: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request for %s: %w", path, err)
+	}
+	conn, err := websocket.Negotiate(rt, holder, req, protocol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stream %s: %w", path, err)
+	}
+	return &wsStream{conn: conn}, nil
+}
+
+// wsStream reads and writes the binary messages of a WebSocket as a continuous stream.
+type wsStream struct {
+	conn   *gwebsocket.Conn
+	reader io.Reader
+}
+
+func (s *wsStream) Read(p []byte) (int, error) {
+	for {
+		if s.reader != nil {
+			n, err := s.reader.Read(p)
+			if err != io.EOF {
+				return n, err
+			}
+			s.reader = nil
+			if n > 0 {
+				return n, nil
+			}
+		}
+		_, reader, err := s.conn.NextReader()
+		if gwebsocket.IsCloseError(err, gwebsocket.CloseNormalClosure) {
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		s.reader = reader
+	}
+}
+
+func (s *wsStream) Write(p []byte) (int, error) {
+	if err := s.conn.WriteMessage(gwebsocket.BinaryMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *wsStream) Close() error {
+	_ = s.conn.WriteControl(gwebsocket.CloseMessage, gwebsocket.FormatCloseMessage(gwebsocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	return s.conn.Close()
+}
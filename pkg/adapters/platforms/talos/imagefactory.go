@@ -0,0 +1,116 @@
+// Package talos defines an adapter for Talos and bootstrapping the OS.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package talos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/butlerdotdev/butler/pkg/adapters/platforms/talos/models"
+
+	"go.uber.org/zap"
+)
+
+// DefaultImageFactoryURL is the public Sidero Labs Image Factory.
+const DefaultImageFactoryURL = "https://factory.talos.dev"
+
+// ImageFactoryClient talks to a Talos Image Factory to register schematics and build image references.
+type ImageFactoryClient struct {
+	baseURL string
+	client  *http.Client
+	logger  *zap.Logger
+}
+
+// NewImageFactoryClient creates a client for the Image Factory at baseURL. An empty baseURL
+// selects the public factory.
+func NewImageFactoryClient(baseURL string, logger *zap.Logger) *ImageFactoryClient {
+	if baseURL == "" {
+		baseURL = DefaultImageFactoryURL
+	}
+	return &ImageFactoryClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: 30 * time.Second},
+		logger:  logger,
+	}
+}
+
+// CreateSchematic uploads a schematic and returns its content-addressed ID. Uploading the same
+// schematic twice returns the same ID, so this is safe to call on every run.
+func (f *ImageFactoryClient) CreateSchematic(ctx context.Context, schematic models.Schematic) (string, error) {
+	// Image Factory expects YAML; JSON is valid YAML so the encoding/json output is accepted as-is.
+	body, err := json.Marshal(schematic)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal schematic: %w", err)
+	}
+
+	f.logger.Info("Uploading schematic to Image Factory",
+		zap.String("factory", f.baseURL),
+		zap.Strings("extensions", schematic.Customization.SystemExtensions.OfficialExtensions),
+		zap.Strings("kernelArgs", schematic.Customization.ExtraKernelArgs),
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.baseURL+"/schematics", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/yaml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("image factory request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("image factory rejected schematic (%d): %s", resp.StatusCode, respBody)
+	}
+
+	var result models.SchematicResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("failed to decode image factory response: %w", err)
+	}
+	if result.ID == "" {
+		return "", fmt.Errorf("image factory returned an empty schematic ID")
+	}
+
+	f.logger.Info("Schematic registered", zap.String("schematicID", result.ID))
+	return result.ID, nil
+}
+
+// References returns the installer image and ISO URL the factory serves for a schematic and
+// Talos version.
+func (f *ImageFactoryClient) References(schematicID, version string) (models.ImageReferences, error) {
+	parsed, err := url.Parse(f.baseURL)
+	if err != nil {
+		return models.ImageReferences{}, fmt.Errorf("invalid image factory URL %q: %w", f.baseURL, err)
+	}
+
+	return models.ImageReferences{
+		SchematicID:    schematicID,
+		Version:        version,
+		InstallerImage: fmt.Sprintf("%s/installer/%s:%s", parsed.Host, schematicID, version),
+		ISOURL:         fmt.Sprintf("%s/image/%s/%s/metal-amd64.iso", f.baseURL, schematicID, version),
+	}, nil
+}
@@ -0,0 +1,46 @@
+// Package models defines data structures for Talos and its config .
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+// Schematic is the Image Factory customization request for a Talos image.
+type Schematic struct {
+	Customization SchematicCustomization `json:"customization"`
+}
+
+// SchematicCustomization holds the kernel arguments and system extensions baked into an image.
+type SchematicCustomization struct {
+	ExtraKernelArgs  []string         `json:"extraKernelArgs,omitempty"`
+	SystemExtensions SystemExtensions `json:"systemExtensions,omitempty"`
+}
+
+// SystemExtensions lists the official Talos system extensions to include, e.g. "siderolabs/iscsi-tools".
+type SystemExtensions struct {
+	OfficialExtensions []string `json:"officialExtensions,omitempty"`
+}
+
+// SchematicResponse is the Image Factory response to a schematic upload.
+type SchematicResponse struct {
+	ID string `json:"id"`
+}
+
+// ImageReferences are the artifacts Image Factory serves for a schematic and Talos version.
+type ImageReferences struct {
+	SchematicID    string `json:"schematicID"`
+	Version        string `json:"version"`
+	InstallerImage string `json:"installerImage"`
+	ISOURL         string `json:"isoURL"`
+}
@@ -5,6 +5,8 @@ import "github.com/butlerdotdev/butler/pkg/models"
 // ProviderInterface defines required cloud provider operations.
 type ProviderInterface interface {
 	CreateVM(vm models.VMConfig) (string, error)
+	// StopVM powers a VM off. Providers refuse to delete a running VM, so callers stop it first.
+	StopVM(vmID string) error
 	DeleteVM(vmID string) error
 	GetVMStatus(vmName string) (models.VMStatus, error)
 }
@@ -99,6 +99,54 @@ func (n *NutanixAdapter) CreateVM(vm sharedModels.VMConfig) (string, error) {
 	return vm.Name, nil
 }
 
+// StopVM powers a VM off in Nutanix AHV by updating its power state. The v3 API takes the
+// VM's full spec and metadata, so the current intent is read and sent back modified.
+func (n *NutanixAdapter) StopVM(vmID string) error {
+	url := fmt.Sprintf("/api/nutanix/v3/vms/%s", vmID)
+
+	resp, err := n.client.DoRequest("GET", url, nil)
+	if err != nil {
+		n.logger.Error("Failed to fetch VM", zap.String("vmID", vmID), zap.Error(err))
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 300 {
+		n.logger.Error("Failed to fetch VM", zap.String("vmID", vmID), zap.Int("status", resp.StatusCode), zap.ByteString("response", body))
+		return fmt.Errorf("failed to fetch VM %s: %s", vmID, body)
+	}
+
+	var intent map[string]interface{}
+	if err := json.Unmarshal(body, &intent); err != nil {
+		return fmt.Errorf("failed to decode VM %s: %w", vmID, err)
+	}
+	spec, _ := intent["spec"].(map[string]interface{})
+	resources, _ := spec["resources"].(map[string]interface{})
+	if resources == nil {
+		return fmt.Errorf("VM %s has no spec resources", vmID)
+	}
+	resources["power_state"] = "OFF"
+
+	n.logger.Info("Stopping VM", zap.String("vmID", vmID))
+	update := map[string]interface{}{"spec": spec, "metadata": intent["metadata"]}
+	putResp, err := n.client.DoRequest("PUT", url, update)
+	if err != nil {
+		n.logger.Error("Failed to stop VM", zap.String("vmID", vmID), zap.Error(err))
+		return err
+	}
+	defer putResp.Body.Close()
+
+	if putResp.StatusCode >= 300 {
+		putBody, _ := io.ReadAll(putResp.Body)
+		n.logger.Error("Failed to stop VM", zap.String("vmID", vmID), zap.Int("status", putResp.StatusCode), zap.ByteString("response", putBody))
+		return fmt.Errorf("failed to stop VM %s: %s", vmID, putBody)
+	}
+
+	n.logger.Info("VM stop requested", zap.String("vmID", vmID))
+	return nil
+}
+
 // DeleteVM removes a VM from Nutanix.
 func (n *NutanixAdapter) DeleteVM(vmID string) error {
 	url := fmt.Sprintf("/api/nutanix/v3/vms/%s", vmID)
@@ -152,7 +200,7 @@ func (n *NutanixAdapter) GetVMStatus(vmName string) (sharedModels.VMStatus, erro
 
 	// Check if VM was found
 	if len(responseData.Entities) == 0 {
-		return sharedModels.VMStatus{}, fmt.Errorf("VM %s not found in Nutanix", vmName)
+		return sharedModels.VMStatus{}, fmt.Errorf("%w: %s not found in Nutanix", sharedModels.ErrVMNotFound, vmName)
 	}
 
 	vm := responseData.Entities[0]
@@ -183,7 +231,9 @@ func (n *NutanixAdapter) GetVMStatus(vmName string) (sharedModels.VMStatus, erro
 	)
 
 	return sharedModels.VMStatus{
+		ID:      vm.Metadata.UUID,
 		Healthy: isHealthy,
+		Running: isPoweredOn,
 		IP:      assignedIP,
 	}, nil
 }
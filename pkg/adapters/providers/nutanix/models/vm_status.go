@@ -23,7 +23,13 @@ type NutanixVMStatus struct {
 
 // VMEntity represents an individual VM entity returned from Nutanix API.
 type VMEntity struct {
-	Status VMStatus `json:"status"`
+	Metadata VMMetadata `json:"metadata"`
+	Status   VMStatus   `json:"status"`
+}
+
+// VMMetadata holds the identifying metadata of a Nutanix VM.
+type VMMetadata struct {
+	UUID string `json:"uuid"`
 }
 
 // VMStatus represents the status details of a Nutanix VM.
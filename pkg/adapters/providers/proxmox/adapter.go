@@ -98,38 +98,40 @@ func (n *ProxmoxAdapter) CreateVM(vm sharedModels.VMConfig) (string, error) {
 	return vm.Name, nil
 }
 
-// DeleteVM removes a VM from Proxmox.
-func (n *ProxmoxAdapter) DeleteVM(vmID string) error {
-	// We dont know what node the VM is on, so we need to get all VMs and find the one with the right name
-	allVms, err := n.GetAllVms()
+// StopVM powers a VM off in Proxmox VE without waiting for it to stop.
+func (n *ProxmoxAdapter) StopVM(vmID string) error {
+	node, vmIdInt, err := n.findVMNode(vmID)
 	if err != nil {
-		n.logger.Error("Failed to get all VMs", zap.Error(err))
 		return err
 	}
-	n.logger.Info("Removing VM", zap.String("vmID", vmID))
+	n.logger.Info("Stopping VM", zap.String("vmID", vmID))
 
-	// Other providers use vmID as a string, but Proxmox uses an int for the VM ID.
-	// We do the conversion to int here to maintain parameter consistency with other provider's adapters
-	vmIdInt, err := strconv.Atoi(vmID)
+	path := fmt.Sprintf("/api2/json/nodes/%s/qemu/%d/status/stop", node, vmIdInt)
+	resp, err := n.client.DoRequest("POST", path, nil)
 	if err != nil {
-		n.logger.Error("Failed to convert vmID to int", zap.String("vmID", vmID), zap.Error(err))
-		return fmt.Errorf("failed to convert vmID to int: %w", err)
+		n.logger.Error("failed to send request to stop VM", zap.Error(err))
+		return err
 	}
 
-	var node string
-	for _, vm := range allVms.Data {
-		if vm.VMId == vmIdInt {
-			n.logger.Info("Found VM", zap.String("name", vm.Name), zap.Int("id", vm.VMId), zap.String("status", vm.Status))
-			node = vm.Node
-			break
-		}
+	// Read and check response
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 300 {
+		n.logger.Error("Failed to stop VM", zap.Int("status", resp.StatusCode), zap.ByteString("response", body))
+		return fmt.Errorf("failed to stop vm: %s", body)
 	}
 
-	// If we didn't find the VM, return an error
-	if node == "" {
-		n.logger.Error("VM not found", zap.String("vmID", vmID))
-		return fmt.Errorf("VM with ID %s not found", vmID)
+	n.logger.Info("vm stop requested", zap.String("vmID", vmID))
+	return nil
+}
+
+// DeleteVM removes a VM from Proxmox. Proxmox refuses to destroy a running VM.
+func (n *ProxmoxAdapter) DeleteVM(vmID string) error {
+	node, vmIdInt, err := n.findVMNode(vmID)
+	if err != nil {
+		return err
 	}
+	n.logger.Info("Removing VM", zap.String("vmID", vmID))
 
 	path := fmt.Sprintf("/api2/extjs/nodes/%s/qemu/%d?purge=1&destroy-unreferenced-disks=1", node, vmIdInt)
 	resp, err := n.client.DoRequest("DELETE", path, nil)
@@ -150,6 +152,35 @@ func (n *ProxmoxAdapter) DeleteVM(vmID string) error {
 	return nil
 }
 
+// findVMNode returns the Proxmox node hosting a VM and the VM's numeric ID.
+func (n *ProxmoxAdapter) findVMNode(vmID string) (string, int, error) {
+	// We dont know what node the VM is on, so we need to get all VMs and find the one with the right ID
+	allVms, err := n.GetAllVms()
+	if err != nil {
+		n.logger.Error("Failed to get all VMs", zap.Error(err))
+		return "", 0, err
+	}
+
+	// Other providers use vmID as a string, but Proxmox uses an int for the VM ID.
+	// We do the conversion to int here to maintain parameter consistency with other provider's adapters
+	vmIdInt, err := strconv.Atoi(vmID)
+	if err != nil {
+		n.logger.Error("Failed to convert vmID to int", zap.String("vmID", vmID), zap.Error(err))
+		return "", 0, fmt.Errorf("failed to convert vmID to int: %w", err)
+	}
+
+	for _, vm := range allVms.Data {
+		if vm.VMId == vmIdInt {
+			n.logger.Info("Found VM", zap.String("name", vm.Name), zap.Int("id", vm.VMId), zap.String("status", vm.Status))
+			return vm.Node, vmIdInt, nil
+		}
+	}
+
+	// If we didn't find the VM, return an error
+	n.logger.Error("VM not found", zap.String("vmID", vmID))
+	return "", 0, fmt.Errorf("VM with ID %s not found", vmID)
+}
+
 // GetVMStatus fetches the VM's health status and IP address from Proxmox VE.
 func (n *ProxmoxAdapter) GetVMStatus(vmName string) (sharedModels.VMStatus, error) {
 	// We dont know what node the VM is on, so we need to get all VMs and find the one with the right name
@@ -162,19 +193,16 @@ func (n *ProxmoxAdapter) GetVMStatus(vmName string) (sharedModels.VMStatus, erro
 	for _, vm := range allVms.Data {
 		if vm.Name == vmName {
 			n.logger.Info("Found VM", zap.String("name", vm.Name), zap.Int("id", vm.VMId), zap.String("status", vm.Status))
-			var vmStatus sharedModels.VMStatus
-			if vm.Status == "running" {
-				vmStatus.Healthy = true
-			} else {
-				vmStatus.Healthy = false
-			}
+			vmStatus := sharedModels.VMStatus{ID: strconv.Itoa(vm.VMId)}
+			vmStatus.Running = vm.Status == "running"
+			vmStatus.Healthy = vmStatus.Running
 			vmStatus.IP = n.GetNetworkIP(vm.Node, vm.VMId)
 			return vmStatus, nil
 		}
 	}
 
 	n.logger.Error("VM not found", zap.String("name", vmName))
-	return sharedModels.VMStatus{}, fmt.Errorf("VM %s not found", vmName)
+	return sharedModels.VMStatus{}, fmt.Errorf("%w: %s", sharedModels.ErrVMNotFound, vmName)
 }
 
 func (n *ProxmoxAdapter) GetRandomNode() (string, error) {
@@ -0,0 +1,95 @@
+// Package s3 defines an adapter for S3-compatible object storage such as AWS S3 or MinIO.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"go.uber.org/zap"
+)
+
+// S3Client implements the S3Adapter interface on top of the MinIO client, which speaks to any
+// S3-compatible endpoint.
+type S3Client struct {
+	client *minio.Client
+	bucket string
+	logger *zap.Logger
+}
+
+// NewS3Client initializes a client for a single bucket. When insecure is true the endpoint is
+// reached over plain HTTP, which is what a local MinIO usually exposes.
+func NewS3Client(endpoint, region, bucket, accessKeyID, secretAccessKey string, insecure bool, logger *zap.Logger) (*S3Client, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKeyID, secretAccessKey, ""),
+		Secure: !insecure,
+		Region: region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client for %s: %w", endpoint, err)
+	}
+
+	return &S3Client{
+		client: client,
+		bucket: bucket,
+		logger: logger,
+	}, nil
+}
+
+// Upload copies a local file to the given object key.
+func (s *S3Client) Upload(ctx context.Context, localPath, key string) error {
+	s.logger.Info("Uploading object", zap.String("bucket", s.bucket), zap.String("key", key), zap.String("file", localPath))
+
+	if _, err := s.client.FPutObject(ctx, s.bucket, key, localPath, minio.PutObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to upload %s to s3://%s/%s: %w", localPath, s.bucket, key, err)
+	}
+	return nil
+}
+
+// Download copies the given object key to a local file.
+func (s *S3Client) Download(ctx context.Context, key, localPath string) error {
+	s.logger.Info("Downloading object", zap.String("bucket", s.bucket), zap.String("key", key), zap.String("file", localPath))
+
+	if err := s.client.FGetObject(ctx, s.bucket, key, localPath, minio.GetObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to download s3://%s/%s: %w", s.bucket, key, err)
+	}
+	return nil
+}
+
+// List returns the keys of all objects under prefix.
+func (s *S3Client) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list s3://%s/%s: %w", s.bucket, prefix, object.Err)
+		}
+		keys = append(keys, object.Key)
+	}
+	return keys, nil
+}
+
+// Delete removes the given object key.
+func (s *S3Client) Delete(ctx context.Context, key string) error {
+	s.logger.Info("Deleting object", zap.String("bucket", s.bucket), zap.String("key", key))
+
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to delete s3://%s/%s: %w", s.bucket, key, err)
+	}
+	return nil
+}
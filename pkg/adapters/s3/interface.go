@@ -0,0 +1,27 @@
+// Package s3 defines an adapter for S3-compatible object storage such as AWS S3 or MinIO.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import "context"
+
+// S3Adapter defines methods for storing and retrieving objects in a single bucket.
+type S3Adapter interface {
+	Upload(ctx context.Context, localPath, key string) error
+	Download(ctx context.Context, key, localPath string) error
+	List(ctx context.Context, prefix string) ([]string, error)
+	Delete(ctx context.Context, key string) error
+}
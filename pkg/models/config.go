@@ -23,24 +23,80 @@ type BootstrapConfig struct {
 
 // ManagementClusterConfig holds the cluster configuration.
 type ManagementClusterConfig struct {
-	Name       string        `mapstructure:"name" yaml:"name"`
-	Provider   string        `mapstructure:"provider" yaml:"provider"`
-	Nutanix    NutanixConfig `mapstructure:"nutanix" yaml:"nutanix"`
-	Proxmox    ProxmoxConfig `mapstructure:"proxmox" yaml:"proxmox"`
-	Nodes      []NodeConfig  `mapstructure:"nodes" yaml:"nodes"`
-	Talos      TalosConfig   `mapstructure:"talos" yaml:"talos"`
-	ClusterAPI ClusterAPI    `mapstructure:"clusterAPI" yaml:"clusterAPI"`
-	Flux       FluxConfig    `mapstructure:"flux" yaml:"flux"`
+	Name       string           `mapstructure:"name" yaml:"name"`
+	Provider   string           `mapstructure:"provider" yaml:"provider"`
+	Nutanix    NutanixConfig    `mapstructure:"nutanix" yaml:"nutanix"`
+	Proxmox    ProxmoxConfig    `mapstructure:"proxmox" yaml:"proxmox"`
+	Nodes      []NodeConfig     `mapstructure:"nodes" yaml:"nodes"`
+	Talos      TalosConfig      `mapstructure:"talos" yaml:"talos"`
+	ClusterAPI ClusterAPI       `mapstructure:"clusterAPI" yaml:"clusterAPI"`
+	Flux       FluxConfig       `mapstructure:"flux" yaml:"flux"`
+	EtcdBackup EtcdBackupConfig `mapstructure:"etcdBackup" yaml:"etcdBackup"`
+	KubeVip    KubeVipConfig    `mapstructure:"kubeVip" yaml:"kubeVip"`
+	Network    NetworkConfig    `mapstructure:"network" yaml:"network"`
+	// LoadBalancer declares the MetalLB address pools. MetalLB is installed when pools are set.
+	LoadBalancer LoadBalancerConfig `mapstructure:"loadBalancer" yaml:"loadBalancer"`
+	// Ingress configures Traefik, which is exposed through a MetalLB address.
+	Ingress IngressConfig `mapstructure:"ingress" yaml:"ingress"`
+	Storage StorageConfig `mapstructure:"storage" yaml:"storage"`
+	// Virtualization configures KubeVirt, which runs the virtual machines butlerctl manages.
+	Virtualization VirtualizationConfig `mapstructure:"virtualization" yaml:"virtualization"`
 }
 
 // FluxConfig holds Flux GitOps settings.
 type FluxConfig struct {
+	// Provider is github, gitlab (default), gitea, bitbucket-server or git for any other server.
+	Provider string `mapstructure:"provider" yaml:"provider"`
+	// Auth is token (default) or ssh. Forge providers always need a token for their API; with ssh,
+	// Flux pulls through a deploy key instead of the token.
+	Auth          string `mapstructure:"auth" yaml:"auth"`
 	GitOwner      string `mapstructure:"gitOwner" yaml:"gitOwner"`
 	GitRepository string `mapstructure:"gitRepository" yaml:"gitRepository"`
 	GitBranch     string `mapstructure:"gitBranch" yaml:"gitBranch"`
 	GitPath       string `mapstructure:"gitPath" yaml:"gitPath"`
 	GitHostname   string `mapstructure:"gitHostname" yaml:"gitHostname"`
-	GitPAT        string `mapstructure:"gitPAT" yaml:"gitPAT"`
+	// GitURL is the repository URL for the git provider, ssh:// or https://.
+	GitURL string `mapstructure:"gitURL" yaml:"gitURL"`
+	// GitUsername is the HTTPS user for the git and bitbucket-server providers.
+	GitUsername string `mapstructure:"gitUsername" yaml:"gitUsername"`
+	GitPAT      string `mapstructure:"gitPAT" yaml:"gitPAT"`
+	// Personal marks GitOwner as a user rather than an organization or group.
+	Personal bool `mapstructure:"personal" yaml:"personal"`
+	// SSHKeyFile is an existing deploy key to use for ssh auth instead of one generated by Flux.
+	SSHKeyFile string `mapstructure:"sshKeyFile" yaml:"sshKeyFile"`
+	// CAFile is a PEM bundle for Git servers with certificates from a private CA.
+	CAFile string `mapstructure:"caFile" yaml:"caFile"`
+	// KnownHostsFile verifies SSH host keys when Butler commits over SSH. Defaults to ~/.ssh/known_hosts.
+	KnownHostsFile string `mapstructure:"knownHostsFile" yaml:"knownHostsFile"`
+	// Commits controls how Butler commits to the repository.
+	Commits GitCommitConfig `mapstructure:"commits" yaml:"commits"`
+	// Components are Helm charts Butler commits to the repository for Flux to install.
+	Components []ComponentConfig `mapstructure:"components" yaml:"components"`
+}
+
+// GitCommitConfig controls the commits Butler makes to the GitOps repository.
+type GitCommitConfig struct {
+	AuthorName  string `mapstructure:"authorName" yaml:"authorName"`
+	AuthorEmail string `mapstructure:"authorEmail" yaml:"authorEmail"`
+	// SigningFormat is gpg or ssh. SigningKey is the GPG key ID or the SSH private key file.
+	SigningFormat string `mapstructure:"signingFormat" yaml:"signingFormat"`
+	SigningKey    string `mapstructure:"signingKey" yaml:"signingKey"`
+	// PullRequest pushes to a new branch and opens a pull or merge request into gitBranch
+	// instead of pushing to gitBranch directly. Supported on github, gitlab and gitea.
+	PullRequest  bool   `mapstructure:"pullRequest" yaml:"pullRequest"`
+	BranchPrefix string `mapstructure:"branchPrefix" yaml:"branchPrefix"`
+}
+
+// ComponentConfig is a platform component delivered as a Flux HelmRelease.
+type ComponentConfig struct {
+	Name string `mapstructure:"name" yaml:"name"`
+	// Namespace defaults to the component name.
+	Namespace string `mapstructure:"namespace" yaml:"namespace"`
+	Chart     string `mapstructure:"chart" yaml:"chart"`
+	Version   string `mapstructure:"version" yaml:"version"`
+	// RepoURL is an HTTP(S) Helm repository or an oci:// registry.
+	RepoURL string                 `mapstructure:"repoURL" yaml:"repoURL"`
+	Values  map[string]interface{} `mapstructure:"values" yaml:"values"`
 }
 
 // NutanixConfig defines the Nutanix API connection and cluster details.
@@ -72,26 +128,346 @@ type NodeConfig struct {
 	Disk       string   `mapstructure:"disk" yaml:"disk"`
 	IsoUUID    string   `mapstructure:"isoUUID" yaml:"isoUUID"`
 	ExtraDisks []string `mapstructure:"extraDisks" yaml:"extraDisks"`
+	// ExtraDiskMode is "volume" to have Talos format and mount the extra disks, or "raw" to leave
+	// them as unformatted partitions for LINSTOR storage pools. Defaults to "volume".
+	ExtraDiskMode string            `mapstructure:"extraDiskMode" yaml:"extraDiskMode"`
+	InstallDisk   InstallDiskConfig `mapstructure:"installDisk" yaml:"installDisk"`
+}
+
+// InstallDiskConfig selects the disk Talos installs to. Disk names a device directly; otherwise
+// the Size, Model and BusPath selectors are matched against the node's disks.
+type InstallDiskConfig struct {
+	Disk    string `mapstructure:"disk" yaml:"disk"`
+	Size    string `mapstructure:"size" yaml:"size"`
+	Model   string `mapstructure:"model" yaml:"model"`
+	BusPath string `mapstructure:"busPath" yaml:"busPath"`
+	Wipe    bool   `mapstructure:"wipe" yaml:"wipe"`
 }
 
 // TalosConfig holds Talos Linux bootstrapping details.
 type TalosConfig struct {
-	Version              string   `mapstructure:"version" yaml:"version"`
-	ControlPlaneEndpoint string   `mapstructure:"controlPlaneEndpoint" yaml:"controlPlaneEndpoint"`
-	ControlPlaneVIP      string   `mapstructure:"controlPlaneVIP" yaml:"controlPlaneVIP"`
-	BoundNodeIP          string   `mapstructure:"boundNodeIP" yaml:"boundNodeIP"`
-	ClusterName          string   `mapstructure:"clusterName" yaml:"clusterName"`
-	CIDR                 string   `mapstructure:"cidr" yaml:"cidr"`
-	Gateway              string   `mapstructure:"gateway" yaml:"gateway"`
-	OutputDir            string   `mapstructure:"outputDir" yaml:"outputDir"`
-	ControlPlaneNodes    []string `mapstructure:"controlPlaneNodes" yaml:"controlPlaneNodes"`
-	WorkerNodes          []string `mapstructure:"workerNodes" yaml:"workerNodes"`
+	Version              string               `mapstructure:"version" yaml:"version"`
+	ControlPlaneEndpoint string               `mapstructure:"controlPlaneEndpoint" yaml:"controlPlaneEndpoint"`
+	ControlPlaneVIP      string               `mapstructure:"controlPlaneVIP" yaml:"controlPlaneVIP"`
+	BoundNodeIP          string               `mapstructure:"boundNodeIP" yaml:"boundNodeIP"`
+	ClusterName          string               `mapstructure:"clusterName" yaml:"clusterName"`
+	CIDR                 string               `mapstructure:"cidr" yaml:"cidr"`
+	Gateway              string               `mapstructure:"gateway" yaml:"gateway"`
+	OutputDir            string               `mapstructure:"outputDir" yaml:"outputDir"`
+	ControlPlaneNodes    []string             `mapstructure:"controlPlaneNodes" yaml:"controlPlaneNodes"`
+	WorkerNodes          []string             `mapstructure:"workerNodes" yaml:"workerNodes"`
+	Extensions           []string             `mapstructure:"extensions" yaml:"extensions"`
+	KernelArgs           []string             `mapstructure:"kernelArgs" yaml:"kernelArgs"`
+	ImageFactoryURL      string               `mapstructure:"imageFactoryURL" yaml:"imageFactoryURL"`
+	ControlPlaneHA       ControlPlaneHAConfig `mapstructure:"controlPlaneHA" yaml:"controlPlaneHA"`
+}
+
+// ControlPlaneHAConfig selects how the control plane endpoint is kept available: "kube-vip-arp"
+// (the default), "kube-vip-bgp", "talos-vip" or "external". The VIP modes serve ControlPlaneVIP;
+// "external" expects ControlPlaneEndpoint to point at a load balancer in front of the control plane.
+// Interface names the NIC Talos puts the VIP on in "talos-vip" mode; it defaults to the physical NIC.
+type ControlPlaneHAConfig struct {
+	Mode      string    `mapstructure:"mode" yaml:"mode"`
+	Interface string    `mapstructure:"interface" yaml:"interface"`
+	BGP       BGPConfig `mapstructure:"bgp" yaml:"bgp"`
+}
+
+// BGPConfig configures kube-vip to advertise the VIP to BGP peers. RouterID defaults to the
+// address of each node's VIP interface.
+type BGPConfig struct {
+	LocalASN uint32    `mapstructure:"localASN" yaml:"localASN"`
+	RouterID string    `mapstructure:"routerID" yaml:"routerID"`
+	Peers    []BGPPeer `mapstructure:"peers" yaml:"peers"`
+}
+
+// BGPPeer is a BGP neighbour kube-vip peers with.
+type BGPPeer struct {
+	Address  string `mapstructure:"address" yaml:"address"`
+	ASN      uint32 `mapstructure:"asn" yaml:"asn"`
+	Password string `mapstructure:"password" yaml:"password"`
+	Multihop bool   `mapstructure:"multihop" yaml:"multihop"`
 }
 
 // ClusterAPI represents the Cluster API provider settings.
 type ClusterAPI struct {
-	Version              string `mapstructure:"version" yaml:"version"`
-	Provider             string `mapstructure:"provider" yaml:"provider"`
-	BootstrapProvider    string `mapstructure:"bootstrapProvider" yaml:"bootstrapProvider"`
-	ControlPlaneProvider string `mapstructure:"controlPlaneProvider" yaml:"controlPlaneProvider"`
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Version is the core Cluster API release. Empty versions use the releases Butler is tested with.
+	Version string `mapstructure:"version" yaml:"version"`
+	// Provider is the infrastructure provider, nutanix or proxmox. Defaults to the management
+	// cluster's provider.
+	Provider        string `mapstructure:"provider" yaml:"provider"`
+	ProviderVersion string `mapstructure:"providerVersion" yaml:"providerVersion"`
+	// BootstrapProvider is kubeadm, the only provider whose workers can join a Kamaji control plane.
+	BootstrapProvider        string `mapstructure:"bootstrapProvider" yaml:"bootstrapProvider"`
+	BootstrapProviderVersion string `mapstructure:"bootstrapProviderVersion" yaml:"bootstrapProviderVersion"`
+	// ControlPlaneProvider is kamaji, which runs tenant control planes as pods on the management cluster.
+	ControlPlaneProvider        string `mapstructure:"controlPlaneProvider" yaml:"controlPlaneProvider"`
+	ControlPlaneProviderVersion string `mapstructure:"controlPlaneProviderVersion" yaml:"controlPlaneProviderVersion"`
+	// KamajiVersion pins the Kamaji Helm chart and CertManagerVersion the cert-manager release.
+	KamajiVersion      string                 `mapstructure:"kamajiVersion" yaml:"kamajiVersion"`
+	KamajiValues       map[string]interface{} `mapstructure:"kamajiValues" yaml:"kamajiValues"`
+	CertManagerVersion string                 `mapstructure:"certManagerVersion" yaml:"certManagerVersion"`
+	// Variables are substituted into the provider manifests like clusterctl variables, e.g.
+	// PROXMOX_TOKEN. Values may be secret references.
+	Variables map[string]string `mapstructure:"variables" yaml:"variables"`
+}
+
+// NetworkConfig describes the management cluster's pod network. CNI is "kube-ovn" (the default)
+// or "cilium". Empty fields take Butler's defaults; Values is merged over the rendered CNI chart
+// values for anything not covered here.
+type NetworkConfig struct {
+	CNI         string                 `mapstructure:"cni" yaml:"cni"`
+	PodCIDR     string                 `mapstructure:"podCIDR" yaml:"podCIDR"`
+	ServiceCIDR string                 `mapstructure:"serviceCIDR" yaml:"serviceCIDR"`
+	JoinCIDR    string                 `mapstructure:"joinCIDR" yaml:"joinCIDR"`
+	TunnelType  string                 `mapstructure:"tunnelType" yaml:"tunnelType"`
+	Interface   string                 `mapstructure:"interface" yaml:"interface"`
+	MTU         int                    `mapstructure:"mtu" yaml:"mtu"`
+	Image       ImageConfig            `mapstructure:"image" yaml:"image"`
+	Values      map[string]interface{} `mapstructure:"values" yaml:"values"`
+	KubeOvn     KubeOvnConfig          `mapstructure:"kubeOvn" yaml:"kubeOvn"`
+}
+
+// KubeOvnConfig declares Kube-OVN networks beyond the default overlay, such as the underlay
+// provider networks and VLANs KubeVirt VMs attach to and per-tenant VPCs.
+type KubeOvnConfig struct {
+	ProviderNetworks []KubeOvnProviderNetwork `mapstructure:"providerNetworks" yaml:"providerNetworks"`
+	Vlans            []KubeOvnVlan            `mapstructure:"vlans" yaml:"vlans"`
+	Vpcs             []KubeOvnVpc             `mapstructure:"vpcs" yaml:"vpcs"`
+	Subnets          []KubeOvnSubnet          `mapstructure:"subnets" yaml:"subnets"`
+}
+
+// KubeOvnProviderNetwork bridges a host interface into OVN for underlay networking.
+type KubeOvnProviderNetwork struct {
+	Name             string   `mapstructure:"name" yaml:"name"`
+	DefaultInterface string   `mapstructure:"defaultInterface" yaml:"defaultInterface"`
+	ExcludeNodes     []string `mapstructure:"excludeNodes" yaml:"excludeNodes"`
+	ExchangeLinkName bool     `mapstructure:"exchangeLinkName" yaml:"exchangeLinkName"`
+}
+
+// KubeOvnVlan is a VLAN on a provider network.
+type KubeOvnVlan struct {
+	Name     string `mapstructure:"name" yaml:"name"`
+	ID       int    `mapstructure:"id" yaml:"id"`
+	Provider string `mapstructure:"provider" yaml:"provider"`
+}
+
+// KubeOvnVpc is an isolated tenant network with its own routing.
+type KubeOvnVpc struct {
+	Name           string               `mapstructure:"name" yaml:"name"`
+	Namespaces     []string             `mapstructure:"namespaces" yaml:"namespaces"`
+	StaticRoutes   []KubeOvnStaticRoute `mapstructure:"staticRoutes" yaml:"staticRoutes"`
+	EnableExternal bool                 `mapstructure:"enableExternal" yaml:"enableExternal"`
+}
+
+// KubeOvnStaticRoute routes a CIDR to a next hop within a VPC.
+type KubeOvnStaticRoute struct {
+	CIDR      string `mapstructure:"cidr" yaml:"cidr"`
+	NextHopIP string `mapstructure:"nextHopIP" yaml:"nextHopIP"`
+}
+
+// KubeOvnSubnet is an IP range in a VPC, or on a VLAN for underlay subnets.
+type KubeOvnSubnet struct {
+	Name        string   `mapstructure:"name" yaml:"name"`
+	CIDR        string   `mapstructure:"cidr" yaml:"cidr"`
+	Gateway     string   `mapstructure:"gateway" yaml:"gateway"`
+	ExcludeIPs  []string `mapstructure:"excludeIPs" yaml:"excludeIPs"`
+	Vpc         string   `mapstructure:"vpc" yaml:"vpc"`
+	Vlan        string   `mapstructure:"vlan" yaml:"vlan"`
+	Namespaces  []string `mapstructure:"namespaces" yaml:"namespaces"`
+	NatOutgoing bool     `mapstructure:"natOutgoing" yaml:"natOutgoing"`
+	Private     bool     `mapstructure:"private" yaml:"private"`
+}
+
+// ImageConfig overrides where a component's image is pulled from, e.g. for a private mirror.
+type ImageConfig struct {
+	Registry   string `mapstructure:"registry" yaml:"registry"`
+	Repository string `mapstructure:"repository" yaml:"repository"`
+	Tag        string `mapstructure:"tag" yaml:"tag"`
+}
+
+// KubeVipConfig controls the kube-vip DaemonSet that announces the control plane VIP.
+// Interface is detected from the control plane node's addresses when empty.
+type KubeVipConfig struct {
+	Version   string `mapstructure:"version" yaml:"version"`
+	Image     string `mapstructure:"image" yaml:"image"`
+	Interface string `mapstructure:"interface" yaml:"interface"`
+}
+
+// LoadBalancerConfig declares the addresses MetalLB hands out to LoadBalancer Services.
+type LoadBalancerConfig struct {
+	// Version pins the MetalLB chart. Empty uses the version Butler is tested with.
+	Version  string                `mapstructure:"version" yaml:"version"`
+	Pools    []LoadBalancerPool    `mapstructure:"pools" yaml:"pools"`
+	BGPPeers []LoadBalancerBGPPeer `mapstructure:"bgpPeers" yaml:"bgpPeers"`
+}
+
+// LoadBalancerPool is a MetalLB IPAddressPool and how its addresses are announced.
+type LoadBalancerPool struct {
+	Name string `mapstructure:"name" yaml:"name"`
+	// Addresses are CIDRs or ranges such as "10.0.0.200-10.0.0.220".
+	Addresses []string `mapstructure:"addresses" yaml:"addresses"`
+	// Advertisement is l2 (default) or bgp.
+	Advertisement string `mapstructure:"advertisement" yaml:"advertisement"`
+	// AutoAssign false reserves the pool for Services that request it. Defaults to true.
+	AutoAssign *bool `mapstructure:"autoAssign" yaml:"autoAssign"`
+	// Interfaces limits L2 announcements to these node interfaces.
+	Interfaces []string `mapstructure:"interfaces" yaml:"interfaces"`
+}
+
+// LoadBalancerBGPPeer is a router MetalLB announces bgp pools to.
+type LoadBalancerBGPPeer struct {
+	Name         string `mapstructure:"name" yaml:"name"`
+	Address      string `mapstructure:"address" yaml:"address"`
+	ASN          uint32 `mapstructure:"asn" yaml:"asn"`
+	LocalASN     uint32 `mapstructure:"localASN" yaml:"localASN"`
+	Password     string `mapstructure:"password" yaml:"password"`
+	EBGPMultiHop bool   `mapstructure:"ebgpMultiHop" yaml:"ebgpMultiHop"`
+}
+
+// IngressConfig configures the Traefik ingress controller.
+type IngressConfig struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Version pins the Traefik chart. Empty uses the version Butler is tested with.
+	Version string `mapstructure:"version" yaml:"version"`
+	// LoadBalancerIP requests a fixed address, which must be in one of the loadBalancer pools.
+	LoadBalancerIP string `mapstructure:"loadBalancerIP" yaml:"loadBalancerIP"`
+	// Pool takes the address from this loadBalancer pool when LoadBalancerIP is empty.
+	Pool string `mapstructure:"pool" yaml:"pool"`
+	// EntryPoints replace the default web (80, redirected to websecure) and websecure (443) entrypoints.
+	EntryPoints        []IngressEntryPoint      `mapstructure:"entryPoints" yaml:"entryPoints"`
+	DefaultCertificate IngressCertificateConfig `mapstructure:"defaultCertificate" yaml:"defaultCertificate"`
+	Dashboard          IngressDashboardConfig   `mapstructure:"dashboard" yaml:"dashboard"`
+	Values             map[string]interface{}   `mapstructure:"values" yaml:"values"`
+}
+
+// IngressEntryPoint is a port Traefik listens on and the port the LoadBalancer Service exposes.
+type IngressEntryPoint struct {
+	Name string `mapstructure:"name" yaml:"name"`
+	// Port is the container port; ExposedPort the Service port, defaulting to Port.
+	Port        int `mapstructure:"port" yaml:"port"`
+	ExposedPort int `mapstructure:"exposedPort" yaml:"exposedPort"`
+	// Protocol is TCP (default) or UDP.
+	Protocol string `mapstructure:"protocol" yaml:"protocol"`
+	TLS      bool   `mapstructure:"tls" yaml:"tls"`
+	// RedirectTo permanently redirects requests to another entrypoint, such as web to websecure.
+	RedirectTo string `mapstructure:"redirectTo" yaml:"redirectTo"`
+}
+
+// IngressCertificateConfig is Traefik's default TLS certificate. Without CertFile and KeyFile a
+// self-signed certificate is generated for DNSNames.
+type IngressCertificateConfig struct {
+	CertFile string   `mapstructure:"certFile" yaml:"certFile"`
+	KeyFile  string   `mapstructure:"keyFile" yaml:"keyFile"`
+	DNSNames []string `mapstructure:"dnsNames" yaml:"dnsNames"`
+}
+
+// IngressDashboardConfig exposes the Traefik dashboard through an IngressRoute with basic auth.
+type IngressDashboardConfig struct {
+	Enabled  bool   `mapstructure:"enabled" yaml:"enabled"`
+	Host     string `mapstructure:"host" yaml:"host"`
+	Username string `mapstructure:"username" yaml:"username"`
+	Password string `mapstructure:"password" yaml:"password"`
+}
+
+// StorageConfig configures the management cluster's persistent storage.
+type StorageConfig struct {
+	Linstor    LinstorConfig    `mapstructure:"linstor" yaml:"linstor"`
+	NutanixCSI NutanixCSIConfig `mapstructure:"nutanixCSI" yaml:"nutanixCSI"`
+}
+
+// LinstorConfig configures the Piraeus operator, which builds replicated LINSTOR storage pools
+// from the workers' extra disks. The workers need extraDiskMode "raw".
+type LinstorConfig struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Version pins the Piraeus operator release. Empty uses the version Butler is tested with.
+	Version string `mapstructure:"version" yaml:"version"`
+	// PoolType is lvm-thin (default) or zfs.
+	PoolType string `mapstructure:"poolType" yaml:"poolType"`
+	// PoolName names the storage pool on every worker. Defaults to "butler-pool".
+	PoolName string `mapstructure:"poolName" yaml:"poolName"`
+	// StorageClass names the default StorageClass. Defaults to "linstor".
+	StorageClass string `mapstructure:"storageClass" yaml:"storageClass"`
+	// Replicas is how many workers hold a copy of each volume. Defaults to 2, or 1 with one worker.
+	Replicas int `mapstructure:"replicas" yaml:"replicas"`
+}
+
+// NutanixCSIConfig configures the Nutanix CSI driver on Nutanix-backed management clusters.
+type NutanixCSIConfig struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// Version pins the nutanix-csi-storage chart. Empty uses the version Butler is tested with.
+	Version string `mapstructure:"version" yaml:"version"`
+	// PrismElement is the host:port of the Prism Element the volumes live on; the port defaults
+	// to 9440. Required, since nutanix.endpoint is Prism Central.
+	PrismElement string `mapstructure:"prismElement" yaml:"prismElement"`
+	// Username and Password default to the nutanix credentials.
+	Username       string                 `mapstructure:"username" yaml:"username"`
+	Password       string                 `mapstructure:"password" yaml:"password"`
+	StorageClasses []NutanixStorageClass  `mapstructure:"storageClasses" yaml:"storageClasses"`
+	Values         map[string]interface{} `mapstructure:"values" yaml:"values"`
+}
+
+// NutanixStorageClass is a StorageClass backed by a Nutanix storage container or Files server.
+type NutanixStorageClass struct {
+	Name string `mapstructure:"name" yaml:"name"`
+	// Type is volumes (default) for block volumes or files for NFS shares.
+	Type string `mapstructure:"type" yaml:"type"`
+	// StorageContainer holds volumes; FileServer is the Nutanix Files server that shares are created on.
+	StorageContainer string `mapstructure:"storageContainer" yaml:"storageContainer"`
+	FileServer       string `mapstructure:"fileServer" yaml:"fileServer"`
+	// FSType is the volume filesystem. Defaults to ext4.
+	FSType string `mapstructure:"fsType" yaml:"fsType"`
+	// ReclaimPolicy is Delete (default) or Retain.
+	ReclaimPolicy string `mapstructure:"reclaimPolicy" yaml:"reclaimPolicy"`
+	Default       bool   `mapstructure:"default" yaml:"default"`
+}
+
+// VirtualizationConfig configures KubeVirt, CDI and the networks virtual machines attach to.
+type VirtualizationConfig struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+	// KubeVirtVersion, CDIVersion and MultusVersion pin the releases. Empty versions use the
+	// releases Butler is tested with.
+	KubeVirtVersion string `mapstructure:"kubeVirtVersion" yaml:"kubeVirtVersion"`
+	CDIVersion      string `mapstructure:"cdiVersion" yaml:"cdiVersion"`
+	MultusVersion   string `mapstructure:"multusVersion" yaml:"multusVersion"`
+	// Emulation forces software emulation on (true) or off (false). By default it is enabled only
+	// when a node running VMs does not expose KVM.
+	Emulation *bool `mapstructure:"emulation" yaml:"emulation"`
+	// Networks are secondary networks VMs attach to through Multus. Each is backed by its own
+	// Kube-OVN subnet, so the Kube-OVN CNI is required.
+	Networks []VirtualMachineNetwork `mapstructure:"networks" yaml:"networks"`
+}
+
+// VirtualMachineNetwork is a Multus NetworkAttachmentDefinition backed by a Kube-OVN subnet.
+type VirtualMachineNetwork struct {
+	Name string `mapstructure:"name" yaml:"name"`
+	// Namespace holds the NetworkAttachmentDefinition. Defaults to "default".
+	Namespace  string   `mapstructure:"namespace" yaml:"namespace"`
+	CIDR       string   `mapstructure:"cidr" yaml:"cidr"`
+	Gateway    string   `mapstructure:"gateway" yaml:"gateway"`
+	ExcludeIPs []string `mapstructure:"excludeIPs" yaml:"excludeIPs"`
+	// Vpc or Vlan place the subnet in a Kube-OVN VPC or on an underlay VLAN.
+	Vpc  string `mapstructure:"vpc" yaml:"vpc"`
+	Vlan string `mapstructure:"vlan" yaml:"vlan"`
+}
+
+// EtcdBackupConfig defines where etcd snapshots are stored and how many are kept.
+// Snapshots go to S3 when a bucket is configured, otherwise to LocalPath.
+type EtcdBackupConfig struct {
+	LocalPath     string   `mapstructure:"localPath" yaml:"localPath"`
+	S3            S3Config `mapstructure:"s3" yaml:"s3"`
+	Retention     int      `mapstructure:"retention" yaml:"retention"`
+	Schedule      string   `mapstructure:"schedule" yaml:"schedule"`
+	UploaderImage string   `mapstructure:"uploaderImage" yaml:"uploaderImage"`
+}
+
+// S3Config defines an S3-compatible bucket, such as AWS S3 or MinIO.
+type S3Config struct {
+	Endpoint        string `mapstructure:"endpoint" yaml:"endpoint"`
+	Region          string `mapstructure:"region" yaml:"region"`
+	Bucket          string `mapstructure:"bucket" yaml:"bucket"`
+	Prefix          string `mapstructure:"prefix" yaml:"prefix"`
+	AccessKeyID     string `mapstructure:"accessKeyID" yaml:"accessKeyID"`
+	SecretAccessKey string `mapstructure:"secretAccessKey" yaml:"secretAccessKey"`
+	Insecure        bool   `mapstructure:"insecure" yaml:"insecure"`
 }
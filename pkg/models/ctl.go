@@ -0,0 +1,101 @@
+// Package models defines data structures for Butler's cluster provisioning.
+//
+// Copyright (c) 2025, The Butler Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+// CtlConfig is the butlerctl configuration, read from butlerctl.yaml in the working directory or
+// ~/.butler. The platform team hands it out to the teams requesting tenant clusters and VMs.
+type CtlConfig struct {
+	// Kubeconfig is the management cluster kubeconfig. Defaults to $KUBECONFIG or ~/.kube/config.
+	Kubeconfig string `mapstructure:"kubeconfig" yaml:"kubeconfig"`
+	// Namespace holds a team's clusters and VMs on the management cluster. Defaults to "default".
+	Namespace string         `mapstructure:"namespace" yaml:"namespace"`
+	Clusters  TenantClusters `mapstructure:"clusters" yaml:"clusters"`
+	VMs       TenantVMs      `mapstructure:"vms" yaml:"vms"`
+}
+
+// TenantClusters describes how tenant clusters are built: their control planes run in Kamaji on
+// the management cluster and their workers are VMs created through Cluster API.
+type TenantClusters struct {
+	// Provider is the Cluster API infrastructure provider, nutanix or proxmox.
+	Provider string `mapstructure:"provider" yaml:"provider"`
+	// KubernetesVersion is used when butlerctl cluster create is run without --version.
+	KubernetesVersion string `mapstructure:"kubernetesVersion" yaml:"kubernetesVersion"`
+	// ControlPlaneReplicas is the number of Kamaji API server pods per cluster. Defaults to 2.
+	ControlPlaneReplicas int `mapstructure:"controlPlaneReplicas" yaml:"controlPlaneReplicas"`
+	// DataStore is the Kamaji DataStore tenant control planes keep their state in. Empty uses
+	// Kamaji's default.
+	DataStore   string `mapstructure:"dataStore" yaml:"dataStore"`
+	PodCIDR     string `mapstructure:"podCIDR" yaml:"podCIDR"`
+	ServiceCIDR string `mapstructure:"serviceCIDR" yaml:"serviceCIDR"`
+	// Flavors are the worker sizes offered with --flavor. Butler's small, medium and large
+	// flavors are used when none are configured.
+	Flavors []Flavor            `mapstructure:"flavors" yaml:"flavors"`
+	Nutanix TenantNutanixConfig `mapstructure:"nutanix" yaml:"nutanix"`
+	Proxmox TenantProxmoxConfig `mapstructure:"proxmox" yaml:"proxmox"`
+}
+
+// Flavor is a VM size, for tenant cluster workers and KubeVirt VMs.
+type Flavor struct {
+	Name string `mapstructure:"name" yaml:"name"`
+	CPU  int    `mapstructure:"cpu" yaml:"cpu"`
+	RAM  string `mapstructure:"ram" yaml:"ram"`
+	Disk string `mapstructure:"disk" yaml:"disk"`
+}
+
+// TenantNutanixConfig places tenant workers on Nutanix. Prism Central credentials are the ones
+// the CAPX controller was installed with.
+type TenantNutanixConfig struct {
+	ClusterUUID string `mapstructure:"clusterUUID" yaml:"clusterUUID"`
+	SubnetUUID  string `mapstructure:"subnetUUID" yaml:"subnetUUID"`
+	// Image is the name of the Prism image workers boot from, built for the Kubernetes version.
+	Image string `mapstructure:"image" yaml:"image"`
+}
+
+// TenantProxmoxConfig places tenant workers on Proxmox by cloning a VM template.
+type TenantProxmoxConfig struct {
+	SourceNode   string   `mapstructure:"sourceNode" yaml:"sourceNode"`
+	TemplateID   int      `mapstructure:"templateID" yaml:"templateID"`
+	AllowedNodes []string `mapstructure:"allowedNodes" yaml:"allowedNodes"`
+	Bridge       string   `mapstructure:"bridge" yaml:"bridge"`
+	// Addresses are the IP ranges or CIDRs workers are assigned from, e.g. "10.0.20.10-10.0.20.50".
+	Addresses  []string `mapstructure:"addresses" yaml:"addresses"`
+	Prefix     int      `mapstructure:"prefix" yaml:"prefix"`
+	Gateway    string   `mapstructure:"gateway" yaml:"gateway"`
+	DNSServers []string `mapstructure:"dnsServers" yaml:"dnsServers"`
+}
+
+// TenantVMs describes the KubeVirt virtual machines butlerctl creates on the management cluster.
+type TenantVMs struct {
+	// Flavors are the VM sizes offered with --flavor. Butler's small, medium and large flavors
+	// are used when none are configured.
+	Flavors []Flavor `mapstructure:"flavors" yaml:"flavors"`
+	// Images are the disk images offered with --image by name. A URL may be passed directly.
+	Images []VMImage `mapstructure:"images" yaml:"images"`
+	// Image is used when butlerctl vm create is run without --image.
+	Image string `mapstructure:"image" yaml:"image"`
+	// StorageClass holds the root disk DataVolumes. Empty uses the default StorageClass.
+	StorageClass string `mapstructure:"storageClass" yaml:"storageClass"`
+	// Subnet is the Kube-OVN subnet VMs are placed in without --subnet. Empty uses the pod network.
+	Subnet string `mapstructure:"subnet" yaml:"subnet"`
+}
+
+// VMImage is a disk image CDI imports root disks from: an http(s):// URL to a qcow2 or raw image,
+// or a docker:// container disk.
+type VMImage struct {
+	Name string `mapstructure:"name" yaml:"name"`
+	URL  string `mapstructure:"url" yaml:"url"`
+}
@@ -16,6 +16,8 @@
 
 package models
 
+import "errors"
+
 // VMConfig represents a generic VM configuration that works across all providers.
 type VMConfig struct {
 	Name               string
@@ -40,8 +42,15 @@ type ClusterConfig struct {
 	Provider string
 }
 
+// ErrVMNotFound is returned (wrapped) by providers when no VM with the requested name exists.
+var ErrVMNotFound = errors.New("VM not found")
+
 // VMStatus represents the status of a VM in Nutanix.
 type VMStatus struct {
+	// ID is the provider's identifier for the VM, as accepted by DeleteVM.
+	ID      string `json:"id"`
 	Healthy bool   `json:"healthy"`
+	// Running reports whether the VM is powered on, regardless of whether it has an IP yet.
+	Running bool   `json:"running"`
 	IP      string `json:"ip"`
 }